@@ -0,0 +1,184 @@
+package alicloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redactedApiParams are the request parameters whose values are never logged, matched case-insensitively.
+// A parameter containing "password" is redacted as well, e.g. Password or NewPassword.
+var redactedApiParams = []string{"SecretKey", "SecurityToken", "AccessKeySecret"}
+
+// The bodies of the responses larger than it are too big to look for the request ID and error code.
+const maxLoggedApiResponse = 64 * 1024
+
+var installApiLoggingOnce sync.Once
+
+// installApiLogging makes the API calls of the SDK clients be logged at the DEBUG level. The aliyungo clients
+// use the default transport of net/http and have no way to set another one, so it is wrapped in place.
+func installApiLogging() {
+	installApiLoggingOnce.Do(func() {
+		http.DefaultTransport = &apiLoggingTransport{transport: http.DefaultTransport}
+	})
+}
+
+// apiLoggingTransport logs the action, request ID, latency and error code of every API call,
+// which is what Alibaba Cloud asks for in a support ticket.
+type apiLoggingTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *apiLoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !apiLoggingEnabled() {
+		return t.transport.RoundTrip(req)
+	}
+
+	action := apiRequestAction(req)
+	params := redactApiParams(apiRequestParams(req))
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		log.Printf("[DEBUG] AliCloud API %s %s failed in %s: %s. Params: %s", req.URL.Host, action, latency, err, params)
+		return resp, err
+	}
+
+	requestId, code := apiResponseIds(resp)
+	if code != "" {
+		log.Printf("[DEBUG] AliCloud API %s %s (RequestId: %s) took %s and got an error %d %s. Params: %s",
+			req.URL.Host, action, requestId, latency, resp.StatusCode, code, params)
+	} else {
+		log.Printf("[DEBUG] AliCloud API %s %s (RequestId: %s) took %s and got %d. Params: %s",
+			req.URL.Host, action, requestId, latency, resp.StatusCode, params)
+	}
+	return resp, nil
+}
+
+// apiLoggingEnabled returns whether TF_LOG is at the DEBUG level or higher. Terraform takes an unknown level as TRACE.
+func apiLoggingEnabled() bool {
+	switch strings.ToUpper(os.Getenv("TF_LOG")) {
+	case "", "INFO", "WARN", "ERROR":
+		return false
+	}
+	return true
+}
+
+// apiRequestAction returns the action of an RPC style API call, or the method and path of a REST one, e.g. OSS.
+func apiRequestAction(req *http.Request) string {
+	if action := req.URL.Query().Get("Action"); action != "" {
+		return action
+	}
+	action := req.Method + " " + req.URL.Path
+	if req.URL.RawQuery != "" {
+		// The subresource of OSS, e.g. ?acl, without the signed parameters.
+		for name, values := range req.URL.Query() {
+			if len(values) == 1 && values[0] == "" {
+				action += "?" + name
+				break
+			}
+		}
+	}
+	return action
+}
+
+// apiRequestParams returns the parameters in the query and the form body of the request,
+// leaving the body to be read again by the transport.
+func apiRequestParams(req *http.Request) url.Values {
+	params := url.Values{}
+	for name, values := range req.URL.Query() {
+		params[name] = values
+	}
+	if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err == nil {
+			if form, err := url.ParseQuery(string(body)); err == nil {
+				for name, values := range form {
+					params[name] = values
+				}
+			}
+		}
+	}
+	return params
+}
+
+// redactApiParams returns the parameters encoded for the log, without the values of the secret ones.
+func redactApiParams(params url.Values) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, name := range names {
+		value := strings.Join(params[name], ",")
+		if isRedactedApiParam(name) {
+			value = "<redacted>"
+		}
+		pairs = append(pairs, name+"="+value)
+	}
+	return strings.Join(pairs, "&")
+}
+
+func isRedactedApiParam(name string) bool {
+	if strings.Contains(strings.ToLower(name), "password") {
+		return true
+	}
+	for _, redacted := range redactedApiParams {
+		if strings.EqualFold(name, redacted) {
+			return true
+		}
+	}
+	return false
+}
+
+// apiResponseIds returns the request ID and the error code of the response. They are read from the headers
+// of OSS, and from the JSON or XML body of the other APIs, which is left to be read again by the SDK.
+func apiResponseIds(resp *http.Response) (requestId, code string) {
+	requestId = resp.Header.Get("x-oss-request-id")
+	if requestId == "" {
+		requestId = resp.Header.Get("x-acs-request-id")
+	}
+	if resp.Body == nil || resp.ContentLength > maxLoggedApiResponse {
+		return
+	}
+	if requestId != "" && resp.StatusCode < http.StatusBadRequest {
+		return
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	var ids struct {
+		RequestId string
+		Code      string
+	}
+	if strings.Contains(resp.Header.Get("Content-Type"), "xml") {
+		_ = xml.Unmarshal(body, &ids)
+	} else {
+		_ = json.Unmarshal(body, &ids)
+	}
+	if requestId == "" {
+		requestId = ids.RequestId
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		code = ids.Code
+	}
+	return
+}
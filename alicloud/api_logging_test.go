@@ -0,0 +1,90 @@
+package alicloud
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRedactApiParams(t *testing.T) {
+	params := url.Values{
+		"Action":          {"CreateInstance"},
+		"Password":        {"Test12345"},
+		"NewPassword":     {"Test54321"},
+		"SecurityToken":   {"sts-token"},
+		"accesskeysecret": {"ak-secret"},
+		"SecretKey":       {"sk-value"},
+		"InstanceName":    {"foo"},
+	}
+	redacted := redactApiParams(params)
+	for _, secret := range []string{"Test12345", "Test54321", "sts-token", "ak-secret", "sk-value"} {
+		if strings.Contains(redacted, secret) {
+			t.Fatalf("expected %q redacted, got %s", secret, redacted)
+		}
+	}
+	for _, param := range []string{"Action=CreateInstance", "InstanceName=foo", "Password=<redacted>"} {
+		if !strings.Contains(redacted, param) {
+			t.Fatalf("expected %q logged, got %s", param, redacted)
+		}
+	}
+}
+
+func TestApiLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("Action") == "DeleteInstance" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"RequestId": "request-2", "Code": "IncorrectInstanceStatus", "Message": "The instance is running."}`)
+			return
+		}
+		fmt.Fprint(w, `{"RequestId": "request-1", "InstanceId": "i-abc"}`)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+	defer os.Setenv("TF_LOG", os.Getenv("TF_LOG"))
+	os.Setenv("TF_LOG", "DEBUG")
+
+	client := &http.Client{Transport: &apiLoggingTransport{transport: http.DefaultTransport}}
+	resp, err := client.Get(server.URL + "/?Action=CreateInstance&Password=Test12345&InstanceName=foo")
+	if err != nil {
+		t.Fatalf("calling the API got an error: %#v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "i-abc") {
+		t.Fatalf("expected the response body left to the SDK, got %s", body)
+	}
+	if _, err := client.Get(server.URL + "/?Action=DeleteInstance"); err != nil {
+		t.Fatalf("calling the API got an error: %#v", err)
+	}
+
+	logged := output.String()
+	for _, expected := range []string{"CreateInstance (RequestId: request-1)", "InstanceName=foo", "Password=<redacted>",
+		"DeleteInstance (RequestId: request-2)", "got an error 403 IncorrectInstanceStatus"} {
+		if !strings.Contains(logged, expected) {
+			t.Fatalf("expected %q logged, got %s", expected, logged)
+		}
+	}
+	if strings.Contains(logged, "Test12345") {
+		t.Fatalf("expected the password redacted, got %s", logged)
+	}
+
+	output.Reset()
+	os.Setenv("TF_LOG", "")
+	if _, err := client.Get(server.URL + "/?Action=CreateInstance"); err != nil {
+		t.Fatalf("calling the API got an error: %#v", err)
+	}
+	if output.Len() > 0 {
+		t.Fatalf("expected nothing logged without TF_LOG, got %s", output.String())
+	}
+}
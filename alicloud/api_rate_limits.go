@@ -0,0 +1,237 @@
+package alicloud
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The throttling of Alibaba Cloud is counted per account and per API, so the calls are limited per access key
+// and per service. The providers sharing an access key share the limiters, and the limit configured last wins.
+// Like the API logging, the limiters are applied by the default transport of net/http, which all of the SDK
+// clients use, since the aliyungo clients have no way to set another one.
+
+var installApiRateLimitsOnce sync.Once
+
+// installApiRateLimits makes the API calls wait for the rate limits of their services before being sent.
+// It is installed over the API logging, so the logged latency doesn't include the time spent in the queue.
+func installApiRateLimits() {
+	installApiRateLimitsOnce.Do(func() {
+		http.DefaultTransport = &apiRateLimitTransport{transport: http.DefaultTransport, limits: apiRateLimits}
+	})
+}
+
+// apiRateLimits holds the rate limiters of all of the provider configurations.
+var apiRateLimits = newApiRateLimitRegistry()
+
+// apiHostServices maps the first part of the host of a default endpoint to its service,
+// e.g. ecs-cn-hangzhou.aliyuncs.com and alidns.aliyuncs.com.
+var apiHostServices = map[string]ServiceCode{
+	"ecs":    EcsCode,
+	"rds":    RdsCode,
+	"slb":    SlbCode,
+	"ram":    RamCode,
+	"alidns": DnsCode,
+	"dns":    DnsCode,
+	"cdn":    CdnCode,
+	"cs":     CsCode,
+	"ess":    EssCode,
+	"vpc":    VpcCode,
+}
+
+type apiRateLimitRegistry struct {
+	lock sync.Mutex
+
+	// The limiters of the services by the access key.
+	limiters map[string]map[ServiceCode]*rateLimiter
+
+	// The services of the custom endpoints, without the scheme.
+	endpoints map[string]ServiceCode
+}
+
+func newApiRateLimitRegistry() *apiRateLimitRegistry {
+	return &apiRateLimitRegistry{
+		limiters:  make(map[string]map[ServiceCode]*rateLimiter),
+		endpoints: make(map[string]ServiceCode),
+	}
+}
+
+// register sets up the limiters of the services limited by the config. It is called again with the renewed
+// credentials, since the temporary ones come with a new access key.
+func (r *apiRateLimitRegistry) register(c Config) {
+	if len(c.ApiRateLimits) == 0 {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	limiters, ok := r.limiters[c.AccessKey]
+	if !ok {
+		limiters = make(map[ServiceCode]*rateLimiter)
+		r.limiters[c.AccessKey] = limiters
+	}
+	for code, limit := range c.ApiRateLimits {
+		if limit <= 0 {
+			continue
+		}
+		if limiter, ok := limiters[code]; ok {
+			limiter.setRate(float64(limit), limit)
+		} else {
+			limiters[code] = newRateLimiter(float64(limit), limit)
+		}
+	}
+	for code, endpoint := range c.Endpoints {
+		if endpoint != "" {
+			r.endpoints[trimEndpointScheme(endpoint)] = code
+		}
+	}
+}
+
+// limiter returns the limiter of the request, or nil if its service isn't limited.
+func (r *apiRateLimitRegistry) limiter(req *http.Request) *rateLimiter {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.limiters) == 0 {
+		return nil
+	}
+	limiters, ok := r.limiters[apiRequestAccessKey(req)]
+	if !ok {
+		return nil
+	}
+	return limiters[r.service(req)]
+}
+
+// service returns the service called by the request, by the longest custom endpoint which the URL starts with,
+// or by the host of the default endpoint otherwise.
+func (r *apiRateLimitRegistry) service(req *http.Request) ServiceCode {
+	target := req.URL.Host + req.URL.Path
+	var service ServiceCode
+	matched := 0
+	for endpoint, code := range r.endpoints {
+		if len(endpoint) > matched && strings.HasPrefix(target, endpoint) {
+			service = code
+			matched = len(endpoint)
+		}
+	}
+	if service != "" {
+		return service
+	}
+
+	labels := strings.Split(req.URL.Hostname(), ".")
+	// The host of OSS may start with the bucket, e.g. bucket.oss-cn-beijing.aliyuncs.com.
+	for _, label := range labels {
+		if label == "oss" || strings.HasPrefix(label, "oss-") {
+			return OssCode
+		}
+	}
+	return apiHostServices[strings.SplitN(labels[0], "-", 2)[0]]
+}
+
+func trimEndpointScheme(endpoint string) string {
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		endpoint = endpoint[i+3:]
+	}
+	return strings.TrimSuffix(endpoint, "/")
+}
+
+// apiRequestAccessKey returns the access key which signs the request. It is a parameter of the RPC style APIs,
+// and is in the Authorization header of the other ones, e.g. "OSS AccessKeyId:Signature".
+func apiRequestAccessKey(req *http.Request) string {
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		if i := strings.Index(auth, " "); i >= 0 {
+			auth = auth[i+1:]
+		}
+		return strings.SplitN(auth, ":", 2)[0]
+	}
+	if accessKey := req.URL.Query().Get("AccessKeyId"); accessKey != "" {
+		return accessKey
+	}
+	return apiRequestParams(req).Get("AccessKeyId")
+}
+
+// apiRateLimitTransport queues the API calls of the limited services until they are allowed by their limiters.
+type apiRateLimitTransport struct {
+	transport http.RoundTripper
+	limits    *apiRateLimitRegistry
+}
+
+func (t *apiRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if limiter := t.limits.limiter(req); limiter != nil {
+		if err := limiter.wait(req); err != nil {
+			return nil, err
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// rateLimiter is a token bucket, which allows rate calls per second on average and bursts of at most burst calls.
+// A call takes a token, or reserves the next one and waits for it when the bucket is empty. The tokens are reserved
+// in the order of the calls, so the concurrent callers are served first come, first served, and none starves.
+type rateLimiter struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  int
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+func (l *rateLimiter) setRate(rate float64, burst int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.advance(time.Now())
+	l.rate = rate
+	l.burst = burst
+	l.tokens = math.Min(l.tokens, float64(burst))
+}
+
+// advance fills the bucket with the tokens generated since the last time.
+func (l *rateLimiter) advance(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+}
+
+// reserve takes a token and returns how long to wait for it. The bucket goes negative by the reserved tokens.
+func (l *rateLimiter) reserve() time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.advance(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// wait blocks until the call is allowed, or the request is canceled.
+func (l *rateLimiter) wait(req *http.Request) error {
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,160 @@
+package alicloud
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+)
+
+func TestRateLimiter_fairness(t *testing.T) {
+	const (
+		callers = 4
+		calls   = 10
+		rate    = 200
+	)
+	limiter := newRateLimiter(rate, 1)
+	req := httptest.NewRequest("GET", "https://ecs.aliyuncs.com/", nil)
+
+	var lock sync.Mutex
+	var served []int
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(caller int) {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				if err := limiter.wait(req); err != nil {
+					t.Errorf("waiting for the limiter got an error: %#v", err)
+					return
+				}
+				lock.Lock()
+				served = append(served, caller)
+				lock.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if elapsed, expected := time.Since(start), time.Duration(callers*calls-1)*time.Second/rate; elapsed < expected*9/10 {
+		t.Fatalf("expected the calls limited to %d per second taking %s, got %s", rate, expected, elapsed)
+	}
+
+	// The callers are served in turn, so none of them gets ahead of the others by more than a round.
+	counts := make([]int, callers)
+	for i, caller := range served {
+		counts[caller]++
+		round := (i + 1) / callers
+		for c, n := range counts {
+			if n < round-1 {
+				t.Fatalf("expected every caller served in turn, but caller %d was served %d times in the first %d calls: %v", c, n, i+1, served)
+			}
+		}
+	}
+}
+
+func TestRateLimiter_canceled(t *testing.T) {
+	limiter := newRateLimiter(1, 1)
+	req := httptest.NewRequest("GET", "https://ecs.aliyuncs.com/", nil)
+	if err := limiter.wait(req); err != nil {
+		t.Fatalf("waiting for the first token got an error: %#v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(req.WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Fatalf("expected the canceled call to stop waiting, got %#v", err)
+	}
+}
+
+func TestApiRateLimitRegistry(t *testing.T) {
+	registry := newApiRateLimitRegistry()
+	registry.register(Config{
+		AccessKey:     "LimitedKey",
+		ApiRateLimits: map[ServiceCode]int{EcsCode: 20, DnsCode: 5, OssCode: 10, VpcCode: 0},
+		Endpoints:     map[ServiceCode]string{EcsCode: "http://127.0.0.1:8080/ecs", OssCode: "http://127.0.0.1:8080"},
+	})
+
+	cases := []struct {
+		url       string
+		accessKey string
+		oss       bool
+		service   ServiceCode
+		limited   bool
+	}{
+		{url: "https://ecs-cn-hangzhou.aliyuncs.com/?Action=DescribeZones", service: EcsCode, limited: true},
+		{url: "https://alidns.aliyuncs.com/?Action=AddDomainRecord", service: DnsCode, limited: true},
+		{url: "https://vpc.aliyuncs.com/?Action=CreateVpc", service: VpcCode},
+		{url: "https://slb.aliyuncs.com/?Action=CreateLoadBalancer", service: SlbCode},
+		{url: "https://ecs-foo.oss-cn-beijing.aliyuncs.com/object", oss: true, service: OssCode, limited: true},
+		{url: "http://127.0.0.1:8080/ecs?Action=DescribeZones", service: EcsCode, limited: true},
+		{url: "http://127.0.0.1:8080/bucket/object", oss: true, service: OssCode, limited: true},
+		{url: "https://ecs-cn-hangzhou.aliyuncs.com/?Action=DescribeZones", accessKey: "OtherKey", service: EcsCode},
+	}
+
+	for _, c := range cases {
+		accessKey := c.accessKey
+		if accessKey == "" {
+			accessKey = "LimitedKey"
+		}
+		req := httptest.NewRequest("GET", c.url, nil)
+		if c.oss {
+			req.Header.Set("Authorization", "OSS "+accessKey+":signature")
+		} else {
+			q := req.URL.Query()
+			q.Set("AccessKeyId", accessKey)
+			req.URL.RawQuery = q.Encode()
+		}
+
+		if service := registry.service(req); service != c.service {
+			t.Fatalf("expected %s calling the service %s, got %s", c.url, c.service, service)
+		}
+		if limited := registry.limiter(req) != nil; limited != c.limited {
+			t.Fatalf("expected %s by %s limited %t, got %t", c.url, accessKey, c.limited, limited)
+		}
+	}
+}
+
+func TestApiRateLimits_ecs(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	const limit = 20
+	config := Config{
+		AccessKey:     "RateLimitedKey",
+		SecretKey:     "SecretKey",
+		Region:        common.Beijing,
+		Endpoints:     map[ServiceCode]string{EcsCode: server.URL + "/ecs"},
+		ApiRateLimits: map[ServiceCode]int{EcsCode: limit},
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client with the fake server got an error: %#v", err)
+	}
+
+	const calls = 2 * limit
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ecsConn().DescribeZones(common.Beijing); err != nil {
+				t.Errorf("describing zones got an error: %#v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// A burst of the limit is allowed at once, and the others are queued instead of failing.
+	if elapsed, expected := time.Since(start), time.Duration(calls-limit)*time.Second/limit; elapsed < expected*9/10 {
+		t.Fatalf("expected the calls limited to %d per second taking %s, got %s", limit, expected, elapsed)
+	}
+	if n := server.calls("ecs/DescribeZones"); n != calls {
+		t.Fatalf("expected all of the %d calls served, got %d", calls, n)
+	}
+}
@@ -24,6 +24,12 @@ const defaultTimeout = 120
 // timeout for long time progerss product, rds e.g.
 const defaultLongTimeout = 1000
 
+// timeoutInSeconds returns the timeout of the operation set by the resource's timeouts block,
+// in seconds as the wait helpers of the SDK expect.
+func timeoutInSeconds(d *schema.ResourceData, key string) int {
+	return int(d.Timeout(key).Seconds())
+}
+
 func getRegion(d *schema.ResourceData, meta interface{}) common.Region {
 	return meta.(*AliyunClient).Region
 }
@@ -90,6 +96,33 @@ func expandStringList(configured []interface{}) []string {
 	return vs
 }
 
+// splitImportId splits the ID of an imported resource which joins the parts named by format with colons,
+// e.g. "<snat_table_id>:<snat_entry_id>". The last part takes the rest of the ID, so it can contain colons.
+func splitImportId(id string, format ...string) ([]string, error) {
+	parts := strings.SplitN(id, COLON_SEPARATED, len(format))
+	valid := len(parts) == len(format)
+	for _, part := range parts {
+		valid = valid && part != ""
+	}
+	if !valid {
+		return nil, fmt.Errorf("Invalid import ID %q. Expected format: <%s>.", id, strings.Join(format, ">:<"))
+	}
+	return parts, nil
+}
+
+// importByCompositeId returns the importer of a resource whose ID joins the parts named by format with colons.
+// It checks the format of the imported ID, and reads the resource by it as it is.
+func importByCompositeId(format ...string) *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if _, err := splitImportId(d.Id(), format...); err != nil {
+				return nil, err
+			}
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
 // Convert the result for an array and returns a Json string
 func convertListToJsonString(configured []interface{}) string {
 	if len(configured) < 1 {
@@ -131,8 +164,11 @@ func getPagination(pageNumber, pageSize int) (pagination common.Pagination) {
 
 const CharityPageUrl = "http://promotion.alicdn.com/help/oss/error.html"
 
+// The status of a cdn domain which is applying its configs.
+const CdnDomainConfiguring = "configuring"
+
 func (client *AliyunClient) JudgeRegionValidation(key string, region common.Region) error {
-	regions, err := client.ecsconn.DescribeRegions()
+	regions, err := client.describeRegions()
 	if err != nil {
 		return fmt.Errorf("DescribeRegions got an error: %#v", err)
 	}
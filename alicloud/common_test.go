@@ -0,0 +1,53 @@
+package alicloud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitImportId(t *testing.T) {
+	cases := []struct {
+		id       string
+		format   []string
+		expected []string
+		err      bool
+	}{
+		{
+			id:       "d-abc:i-abc",
+			format:   []string{"disk_id", "instance_id"},
+			expected: []string{"d-abc", "i-abc"},
+		},
+		{
+			// the last part keeps the rest of the ID
+			id:       "bucket:dir/a:b.txt",
+			format:   []string{"bucket", "key"},
+			expected: []string{"bucket", "dir/a:b.txt"},
+		},
+		{
+			id:     "d-abc",
+			format: []string{"disk_id", "instance_id"},
+			err:    true,
+		},
+		{
+			id:     "d-abc:",
+			format: []string{"disk_id", "instance_id"},
+			err:    true,
+		},
+	}
+
+	for _, c := range cases {
+		parts, err := splitImportId(c.id, c.format...)
+		if c.err {
+			if err == nil {
+				t.Fatalf("expected splitting %q to fail, got %#v", c.id, parts)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("splitting %q got an error: %#v", c.id, err)
+		}
+		if !reflect.DeepEqual(parts, c.expected) {
+			t.Fatalf("expected splitting %q to be %#v, got %#v", c.id, c.expected, parts)
+		}
+	}
+}
@@ -1,9 +1,13 @@
 package alicloud
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/denverdino/aliyungo/cdn"
@@ -16,6 +20,7 @@ import (
 	"github.com/denverdino/aliyungo/ram"
 	"github.com/denverdino/aliyungo/rds"
 	"github.com/denverdino/aliyungo/slb"
+	"github.com/denverdino/aliyungo/sts"
 	"github.com/hashicorp/terraform/terraform"
 )
 
@@ -25,11 +30,81 @@ type Config struct {
 	SecretKey     string
 	Region        common.Region
 	SecurityToken string
+
+	RoleArn               string
+	RoleSessionName       string
+	RoleSessionExpiration int
+	RolePolicy            string
+
+	Profile               string
+	SharedCredentialsFile string
+
+	EcsRoleName         string
+	EcsMetadataEndpoint string
+
+	// Custom endpoints which override the default public ones
+	Endpoints map[ServiceCode]string
+
+	// The max times to retry an API call which fails with a retryable error
+	MaxRetries int
+
+	// The max API calls per second of the services, which are queued once they exceed it
+	ApiRateLimits map[ServiceCode]int
+
+	// The tags applied on every taggable resource, which the resource's own tags override
+	DefaultTags map[string]string
+
+	// Skip checking the region against the ones known by the SDK, so a region newer than the SDK can be used
+	SkipRegionValidation bool
+
+	// Skip calling DescribeRegions to validate the credentials when the provider is configured
+	SkipCredentialsValidation bool
+
+	// The delay before the first retry, DefaultRetryBaseDelay if it is zero.
+	retryBaseDelay time.Duration
+
+	// The time when the temporary credentials expire, zero for the long-lived credentials.
+	credentialsExpiration time.Time
+
+	// Stops renewing the temporary credentials when it is done, e.g. the provider is stopped.
+	stopContext context.Context
 }
 
+type ServiceCode string
+
+const (
+	EcsCode = ServiceCode("ecs")
+	RdsCode = ServiceCode("rds")
+	SlbCode = ServiceCode("slb")
+	OssCode = ServiceCode("oss")
+	RamCode = ServiceCode("ram")
+	DnsCode = ServiceCode("dns")
+	CdnCode = ServiceCode("cdn")
+	CsCode  = ServiceCode("cs")
+	EssCode = ServiceCode("ess")
+	VpcCode = ServiceCode("vpc")
+)
+
+// SupportedServiceCodes lists the services whose endpoint can be customized
+var SupportedServiceCodes = []ServiceCode{EcsCode, RdsCode, SlbCode, OssCode, RamCode, DnsCode, CdnCode, CsCode, EssCode, VpcCode}
+
 // AliyunClient of aliyun
 type AliyunClient struct {
-	Region  common.Region
+	Region common.Region
+
+	// The config which the service clients are built from. It holds the renewed credentials
+	// once the temporary ones are refreshed.
+	config Config
+	lock   sync.Mutex
+
+	// The OSS endpoint of the region, which is discovered once under its own lock since it takes an API call.
+	ossEndpoint string
+	ossLock     sync.Mutex
+
+	// Clients of all the regions used by the resources, which share the same credentials.
+	pool *clientPool
+
+	// Service clients are built on first use and cached. Use the accessors instead of these fields.
 	ecsconn *ecs.Client
 	essconn *ess.Client
 	rdsconn *rds.Client
@@ -46,90 +121,399 @@ type AliyunClient struct {
 
 // Client for AliyunClient
 func (c *Config) Client() (*AliyunClient, error) {
+	source := *c
+	installApiLogging()
+	installApiRateLimits()
+	installApiRetries()
 	err := c.loadAndValidate()
 	if err != nil {
 		return nil, err
 	}
+	apiRateLimits.register(*c)
+	apiRetries.register(*c)
+
+	client := &AliyunClient{
+		Region: c.Region,
+		config: *c,
+		pool: &clientPool{
+			clients:  make(map[common.Region]*AliyunClient),
+			metadata: newMetadataCache(),
+		},
+	}
+	client.pool.clients[c.Region] = client
 
-	ecsconn, err := c.ecsConn()
-	if err != nil {
-		return nil, err
+	if !c.credentialsExpiration.IsZero() {
+		ctx := c.stopContext
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		ctx, client.pool.stop = context.WithCancel(ctx)
+		client.pool.done = make(chan struct{})
+		go func() {
+			defer close(client.pool.done)
+			client.keepCredentialsFresh(ctx, source, c.credentialsExpiration)
+		}()
 	}
 
-	ecsNewconn, err := c.ecsConn()
-	if err != nil {
-		return nil, err
+	return client, nil
+}
+
+// Close stops renewing the temporary credentials of the clients in the pool. The clients keep working
+// until the credentials expire.
+func (client *AliyunClient) Close() {
+	if client.pool.stop != nil {
+		client.pool.stop()
 	}
-	ecsNewconn.SetVersion(EcsApiVersion20160314)
+}
 
-	rdsconn, err := c.rdsConn()
-	if err != nil {
-		return nil, err
+// clientPool caches the clients of different regions which are created from one provider configuration.
+type clientPool struct {
+	lock    sync.Mutex
+	clients map[common.Region]*AliyunClient
+
+	// The regions and zones shared by the clients of all the regions.
+	metadata *metadataCache
+
+	// Stops renewing the temporary credentials, nil for the long-lived credentials.
+	stop context.CancelFunc
+	// Closed once the temporary credentials are no longer renewed.
+	done chan struct{}
+}
+
+// withRegion returns the client of the specified region. It shares the credentials of the current client and
+// the custom endpoints of the global services, and is created on first use without validating the credentials again.
+func (client *AliyunClient) withRegion(region common.Region) (*AliyunClient, error) {
+	if region == "" || region == client.Region {
+		return client, nil
 	}
 
-	slbconn, err := c.slbConn()
-	if err != nil {
-		return nil, err
+	pool := client.pool
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	if regional, ok := pool.clients[region]; ok {
+		return regional, nil
 	}
 
-	vpcconn, err := c.vpcConn()
-	if err != nil {
+	client.lock.Lock()
+	config := client.config.forRegion(region)
+	client.lock.Unlock()
+
+	if err := config.validateRegion(); err != nil {
 		return nil, err
 	}
 
-	essconn, err := c.essConn()
-	if err != nil {
-		return nil, err
+	regional := &AliyunClient{
+		Region: region,
+		config: config,
+		pool:   pool,
 	}
-	ossconn, err := c.ossConn()
-	if err != nil {
-		return nil, err
+	pool.clients[region] = regional
+	return regional, nil
+}
+
+// updateConfig updates all of the clients in the pool with the config holding renewed credentials.
+func (pool *clientPool) updateConfig(config Config) {
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	for region, client := range pool.clients {
+		regional := config
+		if region != config.Region {
+			regional = config.forRegion(region)
+		}
+		client.updateConfig(regional)
 	}
-	dnsconn, err := c.dnsConn()
-	if err != nil {
-		return nil, err
+}
+
+// regionalServiceCodes are the services whose endpoints are of a region, unlike e.g. RAM and DNS.
+var regionalServiceCodes = map[ServiceCode]bool{
+	EcsCode: true,
+	RdsCode: true,
+	SlbCode: true,
+	OssCode: true,
+	EssCode: true,
+	VpcCode: true,
+}
+
+// forRegion returns the config of the clients of another region. The custom endpoints of the regional services
+// are the ones of the provider's region, so the clients of the other regions use their default endpoints.
+func (c Config) forRegion(region common.Region) Config {
+	endpoints := make(map[ServiceCode]string)
+	for code, endpoint := range c.Endpoints {
+		if !regionalServiceCodes[code] {
+			endpoints[code] = endpoint
+		}
 	}
-	ramconn, err := c.ramConn()
-	if err != nil {
-		return nil, err
+	c.Region = region
+	c.Endpoints = endpoints
+	return c
+}
+
+func (client *AliyunClient) ecsConn() *ecs.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.ecsconn == nil {
+		client.ecsconn = client.config.ecsConn()
 	}
-	csconn, err := c.csConn()
-	if err != nil {
-		return nil, err
+	return client.ecsconn
+}
+
+func (client *AliyunClient) ecsNewConn() *ecs.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.ecsNewconn == nil {
+		client.ecsNewconn = client.config.ecsConn()
+		client.ecsNewconn.SetVersion(EcsApiVersion20160314)
+	}
+	return client.ecsNewconn
+}
+
+func (client *AliyunClient) vpcConn() *ecs.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.vpcconn == nil {
+		client.vpcconn = client.config.vpcConn()
 	}
-	cdnconn, err := c.cdnConn()
+	return client.vpcconn
+}
+
+func (client *AliyunClient) slbConn() *slb.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.slbconn == nil {
+		client.slbconn = client.config.slbConn()
+	}
+	return client.slbconn
+}
+
+func (client *AliyunClient) rdsConn() *rds.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.rdsconn == nil {
+		client.rdsconn = client.config.rdsConn()
+	}
+	return client.rdsconn
+}
+
+func (client *AliyunClient) essConn() *ess.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.essconn == nil {
+		client.essconn = client.config.essConn()
+	}
+	return client.essconn
+}
+
+// ossConn returns an error when the OSS endpoint of the region can not be discovered.
+func (client *AliyunClient) ossConn() (*oss.Client, error) {
+	endpoint, err := client.discoverOssEndpoint()
 	if err != nil {
 		return nil, err
 	}
 
-	return &AliyunClient{
-		Region:     c.Region,
-		ecsconn:    ecsconn,
-		ecsNewconn: ecsNewconn,
-		vpcconn:    vpcconn,
-		slbconn:    slbconn,
-		rdsconn:    rdsconn,
-		essconn:    essconn,
-		ossconn:    ossconn,
-		dnsconn:    dnsconn,
-		ramconn:    ramconn,
-		csconn:     csconn,
-		cdnconn:    cdnconn,
-	}, nil
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.ossconn == nil {
+		ossconn, err := client.config.ossConn(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client.ossconn = ossconn
+	}
+	return client.ossconn, nil
+}
+
+// discoverOssEndpoint returns the custom OSS endpoint, or discovers the one of the region on first use.
+// It doesn't hold the lock of the client, so the other service clients aren't blocked by the discovery.
+func (client *AliyunClient) discoverOssEndpoint() (string, error) {
+	client.ossLock.Lock()
+	defer client.ossLock.Unlock()
+	if client.ossEndpoint == "" {
+		client.lock.Lock()
+		config := client.config
+		client.lock.Unlock()
+
+		endpoint := config.Endpoints[OssCode]
+		if endpoint == "" {
+			var err error
+			if endpoint, err = config.describeOssEndpoint(); err != nil {
+				return "", err
+			}
+		}
+		client.ossEndpoint = endpoint
+	}
+	return client.ossEndpoint, nil
+}
+
+func (client *AliyunClient) dnsConn() *dns.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.dnsconn == nil {
+		client.dnsconn = client.config.dnsConn()
+	}
+	return client.dnsconn
+}
+
+func (client *AliyunClient) ramConn() ram.RamClientInterface {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.ramconn == nil {
+		client.ramconn = client.config.ramConn()
+	}
+	return client.ramconn
+}
+
+func (client *AliyunClient) csConn() *cs.Client {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.csconn == nil {
+		client.csconn = client.config.csConn()
+	}
+	return client.csconn
+}
+
+func (client *AliyunClient) cdnConn() *cdn.CdnClient {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	if client.cdnconn == nil {
+		client.cdnconn = client.config.cdnConn()
+	}
+	return client.cdnconn
 }
 
 const BusinessInfoKey = "Terraform"
 
+const DefaultRoleSessionName = "terraform"
+
 func (c *Config) loadAndValidate() error {
 	err := c.validateRegion()
 	if err != nil {
 		return err
 	}
 
+	return c.loadCredentials()
+}
+
+// loadCredentials replaces the configured credentials with temporary ones
+// when an ECS RAM role or a role to assume is specified.
+func (c *Config) loadCredentials() error {
+	if c.EcsRoleName != "" {
+		if err := c.loadEcsRoleCredentials(); err != nil {
+			return err
+		}
+	}
+
+	if c.RoleArn != "" {
+		if err := c.assumeRole(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) loadEcsRoleCredentials() error {
+	credentials, err := getEcsRoleCredentials(c.EcsMetadataEndpoint, c.EcsRoleName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Fetched credentials of ECS RAM role %s and they will expire at %s.", c.EcsRoleName, credentials.Expiration)
+	c.AccessKey = credentials.AccessKeyId
+	c.SecretKey = credentials.AccessKeySecret
+	c.SecurityToken = credentials.SecurityToken
+	c.setCredentialsExpiration(credentials.Expiration)
+
+	return nil
+}
+
+func (c *Config) setCredentialsExpiration(expiration time.Time) {
+	if c.credentialsExpiration.IsZero() || expiration.Before(c.credentialsExpiration) {
+		c.credentialsExpiration = expiration
+	}
+}
+
+// assumeRole exchanges the configured credentials for temporary STS credentials of the specified role,
+// and all of the service clients will be built with them.
+func (c *Config) assumeRole() error {
+	client := sts.NewClient(c.AccessKey, c.SecretKey)
+	if c.SecurityToken != "" {
+		client.SetSecurityToken(c.SecurityToken)
+	}
+	client.SetUserAgent(getUserAgent())
+
+	resp, err := client.AssumeRole(sts.AssumeRoleRequest{
+		RoleArn:         c.RoleArn,
+		RoleSessionName: c.RoleSessionName,
+		DurationSeconds: c.RoleSessionExpiration,
+		Policy:          c.RolePolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("Assuming role %s got an error: %#v.", c.RoleArn, err)
+	}
+
+	log.Printf("[DEBUG] Assumed role %s and its temporary credentials will expire at %s.", resp.AssumedRoleUser.Arn, resp.Credentials.Expiration)
+	c.AccessKey = resp.Credentials.AccessKeyId
+	c.SecretKey = resp.Credentials.AccessKeySecret
+	c.SecurityToken = resp.Credentials.SecurityToken
+	if expiration, err := time.Parse(time.RFC3339, resp.Credentials.Expiration); err == nil {
+		c.setCredentialsExpiration(expiration)
+	}
+
 	return nil
 }
 
+// keepCredentialsFresh renews the temporary credentials from the source config before they expire,
+// and makes the service clients be rebuilt with the renewed ones.
+func (client *AliyunClient) keepCredentialsFresh(ctx context.Context, source Config, expiration time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("[DEBUG] Stopped renewing temporary credentials.")
+			return
+		case <-time.After(credentialsRefreshDelay(expiration, time.Now())):
+		}
+
+		config := source
+		if err := config.loadCredentials(); err != nil {
+			log.Printf("[ERROR] Renewing temporary credentials got an error: %#v. It will be retried later.", err)
+			continue
+		}
+
+		log.Printf("[DEBUG] Renewed temporary credentials and they will expire at %s.", config.credentialsExpiration)
+		apiRateLimits.register(config)
+		apiRetries.register(config)
+		client.pool.updateConfig(config)
+		expiration = config.credentialsExpiration
+	}
+}
+
+// updateConfig replaces the config with the one holding renewed credentials and drops the cached service clients,
+// so they are rebuilt with the renewed credentials on next use. The clients which are still in use keep working
+// until the old credentials expire.
+func (client *AliyunClient) updateConfig(config Config) {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+
+	client.config = config
+	client.ecsconn = nil
+	client.ecsNewconn = nil
+	client.vpcconn = nil
+	client.slbconn = nil
+	client.rdsconn = nil
+	client.essconn = nil
+	client.ossconn = nil
+	client.dnsconn = nil
+	client.ramconn = nil
+	client.csconn = nil
+	client.cdnconn = nil
+}
+
 func (c *Config) validateRegion() error {
+	if c.SkipRegionValidation {
+		return nil
+	}
 
 	for _, valid := range common.ValidRegions {
 		if c.Region == valid {
@@ -140,47 +524,85 @@ func (c *Config) validateRegion() error {
 	return fmt.Errorf("Not a valid region: %s", c.Region)
 }
 
-func (c *Config) ecsConn() (*ecs.Client, error) {
-	client := ecs.NewECSClientWithSecurityToken(c.AccessKey, c.SecretKey, c.SecurityToken, c.Region)
+func (c *Config) ecsConn() *ecs.Client {
+	var client *ecs.Client
+	if endpoint := c.Endpoints[EcsCode]; endpoint != "" {
+		client = ecs.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+		client.SetSecurityToken(c.SecurityToken)
+	} else {
+		client = ecs.NewECSClientWithSecurityToken(c.AccessKey, c.SecretKey, c.SecurityToken, c.Region)
+	}
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-
-	if _, err := client.DescribeRegions(); err != nil {
-		return nil, err
-	}
-
-	return client, nil
+	return client
 }
 
-func (c *Config) rdsConn() (*rds.Client, error) {
-	client := rds.NewRDSClient(c.AccessKey, c.SecretKey, c.Region)
+func (c *Config) rdsConn() *rds.Client {
+	var client *rds.Client
+	if endpoint := c.Endpoints[RdsCode]; endpoint != "" {
+		client = rds.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+	} else {
+		client = rds.NewRDSClient(c.AccessKey, c.SecretKey, c.Region)
+	}
+	client.SetSecurityToken(c.SecurityToken)
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-	return client, nil
+	return client
 }
 
-func (c *Config) slbConn() (*slb.Client, error) {
-	client := slb.NewSLBClient(c.AccessKey, c.SecretKey, c.Region)
+func (c *Config) slbConn() *slb.Client {
+	var client *slb.Client
+	if endpoint := c.Endpoints[SlbCode]; endpoint != "" {
+		client = slb.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+	} else {
+		client = slb.NewSLBClient(c.AccessKey, c.SecretKey, c.Region)
+	}
+	client.SetSecurityToken(c.SecurityToken)
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-	return client, nil
+	return client
 }
 
-func (c *Config) vpcConn() (*ecs.Client, error) {
-	client := ecs.NewVPCClientWithSecurityToken(c.AccessKey, c.SecretKey, c.SecurityToken, c.Region)
+func (c *Config) vpcConn() *ecs.Client {
+	var client *ecs.Client
+	if endpoint := c.Endpoints[VpcCode]; endpoint != "" {
+		client = ecs.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+		client.SetVersion(ecs.VPCAPIVersion)
+		client.SetSecurityToken(c.SecurityToken)
+	} else {
+		client = ecs.NewVPCClientWithSecurityToken(c.AccessKey, c.SecretKey, c.SecurityToken, c.Region)
+	}
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-	return client, nil
-
+	return client
 }
-func (c *Config) essConn() (*ess.Client, error) {
-	client := ess.NewESSClient(c.AccessKey, c.SecretKey, c.Region)
+
+func (c *Config) essConn() *ess.Client {
+	var client *ess.Client
+	if endpoint := c.Endpoints[EssCode]; endpoint != "" {
+		client = ess.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+	} else {
+		client = ess.NewESSClient(c.AccessKey, c.SecretKey, c.Region)
+	}
+	client.SetSecurityToken(c.SecurityToken)
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-	return client, nil
+	return client
 }
-func (c *Config) ossConn() (*oss.Client, error) {
+func (c *Config) ossConn(endpoint string) (*oss.Client, error) {
+	log.Printf("[DEBUG] Instantiate OSS client using endpoint: %#v", endpoint)
+	// The SDK builds its own transport by default, which isn't logged.
+	options := []oss.ClientOption{oss.UserAgent(getUserAgent()), oss.HTTPClient(&http.Client{Transport: http.DefaultTransport})}
+	if c.SecurityToken != "" {
+		options = append(options, oss.SecurityToken(c.SecurityToken))
+	}
+	client, err := oss.New(endpoint, c.AccessKey, c.SecretKey, options...)
 
+	return client, err
+}
+
+// describeOssEndpoint discovers the OSS endpoint of the region by the location service.
+func (c *Config) describeOssEndpoint() (string, error) {
 	endpointClient := location.NewClient(c.AccessKey, c.SecretKey)
 	endpointClient.SetSecurityToken(c.SecurityToken)
 	args := &location.DescribeEndpointsArgs{
@@ -191,7 +613,7 @@ func (c *Config) ossConn() (*oss.Client, error) {
 
 	endpoints, err := endpointClient.DescribeEndpoints(args)
 	if err != nil {
-		return nil, fmt.Errorf("Describe endpoint using region: %#v got an error: %#v.", c.Region, err)
+		return "", fmt.Errorf("Describe endpoint using region: %#v got an error: %#v.", c.Region, err)
 	}
 	endpointItem := endpoints.Endpoints.Endpoint
 	var endpoint string
@@ -206,35 +628,49 @@ func (c *Config) ossConn() (*oss.Client, error) {
 		endpoint = fmt.Sprintf("http://oss-%s.aliyuncs.com", c.Region)
 	}
 
-	log.Printf("[DEBUG] Instantiate OSS client using endpoint: %#v", endpoint)
-	client, err := oss.New(endpoint, c.AccessKey, c.SecretKey, oss.UserAgent(getUserAgent()))
-
-	return client, err
+	return endpoint, nil
 }
 
-func (c *Config) dnsConn() (*dns.Client, error) {
-	client := dns.NewClientNew(c.AccessKey, c.SecretKey)
+func (c *Config) dnsConn() *dns.Client {
+	var client *dns.Client
+	if endpoint := c.Endpoints[DnsCode]; endpoint != "" {
+		client = dns.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+	} else {
+		client = dns.NewClientNew(c.AccessKey, c.SecretKey)
+	}
+	client.SetSecurityToken(c.SecurityToken)
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-	return client, nil
+	return client
 }
 
-func (c *Config) ramConn() (ram.RamClientInterface, error) {
-	client := ram.NewClient(c.AccessKey, c.SecretKey)
-	return client, nil
+func (c *Config) ramConn() ram.RamClientInterface {
+	if endpoint := c.Endpoints[RamCode]; endpoint != "" {
+		return ram.NewClientWithEndpointAndSecurityToken(endpoint, c.AccessKey, c.SecretKey, c.SecurityToken)
+	}
+	return ram.NewClientWithSecurityToken(c.AccessKey, c.SecretKey, c.SecurityToken)
 }
 
-func (c *Config) csConn() (*cs.Client, error) {
+func (c *Config) csConn() *cs.Client {
 	client := cs.NewClientForAussumeRole(c.AccessKey, c.SecretKey, c.SecurityToken)
+	if endpoint := c.Endpoints[CsCode]; endpoint != "" {
+		client.SetEndpoint(endpoint)
+	}
 	client.SetUserAgent(getUserAgent())
-	return client, nil
+	return client
 }
 
-func (c *Config) cdnConn() (*cdn.CdnClient, error) {
-	client := cdn.NewClient(c.AccessKey, c.SecretKey)
+func (c *Config) cdnConn() *cdn.CdnClient {
+	var client *cdn.CdnClient
+	if endpoint := c.Endpoints[CdnCode]; endpoint != "" {
+		client = cdn.NewClientWithEndpoint(endpoint, c.AccessKey, c.SecretKey)
+	} else {
+		client = cdn.NewClient(c.AccessKey, c.SecretKey)
+	}
+	client.SetSecurityToken(c.SecurityToken)
 	client.SetBusinessInfo(BusinessInfoKey)
 	client.SetUserAgent(getUserAgent())
-	return client, nil
+	return client
 }
 
 func getUserAgent() string {
@@ -0,0 +1,234 @@
+package alicloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+)
+
+func TestConfigClient_customEndpoints(t *testing.T) {
+	var lock sync.Mutex
+	actions := make(map[string]bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		actions[r.URL.Query().Get("Action")] = true
+		lock.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"RequestId": "test-request-id", "Regions": {"Region": [{"RegionId": "cn-beijing", "LocalName": "Beijing"}]}}`))
+	}))
+	defer server.Close()
+
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+		Endpoints: make(map[ServiceCode]string),
+	}
+	for _, code := range SupportedServiceCodes {
+		config.Endpoints[code] = server.URL
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client with custom endpoints got an error: %#v", err)
+	}
+
+	if _, err := client.ecsConn().DescribeRegions(); err != nil {
+		t.Fatalf("describing regions got an error: %#v", err)
+	}
+	if !actions["DescribeRegions"] {
+		t.Fatalf("the ECS client should send requests to the custom endpoint %s", server.URL)
+	}
+
+	ossconn, err := client.ossConn()
+	if err != nil {
+		t.Fatalf("building OSS client got an error: %#v", err)
+	}
+	if ossconn.Config.Endpoint != server.URL {
+		t.Fatalf("expected OSS endpoint %s, got %s", server.URL, ossconn.Config.Endpoint)
+	}
+}
+
+func TestConfigClient_lazyServiceClients(t *testing.T) {
+	var lock sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests++
+		lock.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+		Endpoints: map[ServiceCode]string{EcsCode: server.URL},
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client got an error: %#v", err)
+	}
+	if client.ecsconn != nil || client.ossconn != nil || client.ramconn != nil {
+		t.Fatalf("service clients should not be built before they are used")
+	}
+	if requests != 0 {
+		t.Fatalf("building client should not send any request, got %d", requests)
+	}
+
+	var wg sync.WaitGroup
+	conns := make([]interface{}, 10)
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conns[i] = client.ecsConn()
+		}(i)
+	}
+	wg.Wait()
+	for _, conn := range conns {
+		if conn != conns[0] {
+			t.Fatalf("the ECS client should be built once and cached")
+		}
+	}
+
+	client.updateConfig(config)
+	if client.ecsConn() == conns[0] {
+		t.Fatalf("the ECS client should be rebuilt after the config is updated")
+	}
+}
+
+func TestAliyunClientOssConn_discovery(t *testing.T) {
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	location := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"RequestId": "test-request-id", "Endpoints": {"Endpoint": [{"Endpoint": "oss-cn-beijing.aliyuncs.com", "Protocols": {"Protocols": ["HTTP"]}}]}}`))
+	}))
+	defer location.Close()
+	var releaseOnce sync.Once
+	// The blocked request is released before the server is closed, even if the test fails.
+	defer releaseOnce.Do(func() { close(release) })
+	defer os.Setenv("LOCATION_ENDPOINT", os.Getenv("LOCATION_ENDPOINT"))
+	os.Setenv("LOCATION_ENDPOINT", location.URL)
+
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client got an error: %#v", err)
+	}
+
+	type result struct {
+		endpoint string
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ossconn, err := client.ossConn()
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		done <- result{endpoint: ossconn.Config.Endpoint}
+	}()
+	<-requested
+
+	// The other service clients and the default tags aren't blocked while the OSS endpoint is being discovered.
+	built := make(chan struct{})
+	go func() {
+		client.ecsConn()
+		client.defaultTags()
+		close(built)
+	}()
+	select {
+	case <-built:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the ECS client should be built while the OSS endpoint is being discovered")
+	}
+
+	releaseOnce.Do(func() { close(release) })
+	if r := <-done; r.err != nil || r.endpoint != "http://oss-cn-beijing.aliyuncs.com" {
+		t.Fatalf("expected the discovered OSS endpoint, got %q, %#v", r.endpoint, r.err)
+	}
+}
+
+func TestAliyunClientWithRegion(t *testing.T) {
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+		Endpoints: map[ServiceCode]string{
+			EcsCode: "https://ecs.cn-beijing.example.com",
+			RamCode: "https://ram.example.com",
+		},
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client got an error: %#v", err)
+	}
+
+	if regional, err := client.withRegion(""); err != nil || regional != client {
+		t.Fatalf("an empty region should return the provider client, got %#v, %#v", regional, err)
+	}
+	if regional, err := client.withRegion(common.Beijing); err != nil || regional != client {
+		t.Fatalf("the provider region should return the provider client, got %#v, %#v", regional, err)
+	}
+
+	shanghai, err := client.withRegion(common.Shanghai)
+	if err != nil {
+		t.Fatalf("getting client of region %s got an error: %#v", common.Shanghai, err)
+	}
+	if shanghai.Region != common.Shanghai || shanghai.config.Region != common.Shanghai {
+		t.Fatalf("expected client of region %s, got %s", common.Shanghai, shanghai.Region)
+	}
+	if shanghai.config.AccessKey != config.AccessKey || shanghai.config.SecretKey != config.SecretKey {
+		t.Fatalf("the regional client should share the credentials of the provider client")
+	}
+	if shanghai.config.Endpoints[EcsCode] != "" || shanghai.config.Endpoints[RamCode] != config.Endpoints[RamCode] {
+		t.Fatalf("the regional client should only share the custom endpoints of the global services, got %#v", shanghai.config.Endpoints)
+	}
+	if client.config.Endpoints[EcsCode] != config.Endpoints[EcsCode] {
+		t.Fatalf("the provider client should keep its custom endpoints, got %#v", client.config.Endpoints)
+	}
+	if again, _ := client.withRegion(common.Shanghai); again != shanghai {
+		t.Fatalf("the regional client should be cached")
+	}
+	if back, _ := shanghai.withRegion(common.Beijing); back != client {
+		t.Fatalf("the regional client should share the pool of the provider client")
+	}
+
+	if _, err := client.withRegion(common.Region("cn-not-exist")); err == nil {
+		t.Fatalf("an invalid region should get an error")
+	}
+
+	renewed := config
+	renewed.AccessKey = "RenewedAccessKey"
+	renewed.SecretKey = "RenewedSecretKey"
+	renewed.SecurityToken = "RenewedSecurityToken"
+	client.pool.updateConfig(renewed)
+	for _, c := range []*AliyunClient{client, shanghai} {
+		if c.config.AccessKey != renewed.AccessKey || c.config.SecurityToken != renewed.SecurityToken {
+			t.Fatalf("client of region %s should be updated with the renewed credentials", c.Region)
+		}
+		if c.config.Region != c.Region {
+			t.Fatalf("client of region %s should keep its region, got %s", c.Region, c.config.Region)
+		}
+		if (c.config.Endpoints[EcsCode] != "") != (c == client) {
+			t.Fatalf("only the provider client should use the custom ECS endpoint, got %#v for region %s", c.config.Endpoints, c.Region)
+		}
+	}
+}
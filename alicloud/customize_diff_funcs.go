@@ -0,0 +1,161 @@
+package alicloud
+
+import (
+	"fmt"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/denverdino/aliyungo/rds"
+	"github.com/denverdino/aliyungo/slb"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// The funcs in this file validate the rules across the fields of a resource at plan time, which the
+// ValidateFunc of a single field can't express. A field whose value isn't known until apply, e.g. it
+// refers to another resource being created, is taken as set, so the rules never fail a valid plan.
+
+func instanceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	chargeType := common.InstanceChargeType(d.Get("instance_charge_type").(string))
+	if old, _ := d.GetChange("instance_charge_type"); d.Id() != "" && common.InstanceChargeType(old.(string)) == common.PrePaid {
+		// Changing a PrePaid instance to PostPaid is suppressed by ecsChargeTypeSuppressFunc.
+		chargeType = common.PrePaid
+	}
+
+	if chargeType != common.PrePaid {
+		if d.Get("period").(int) != 1 || common.TimeType(d.Get("period_unit").(string)) != common.Month {
+			return fmt.Errorf("'period' and 'period_unit' are only supported when the instance_charge_type is %s.", common.PrePaid)
+		}
+	}
+
+	strategy := ecs.SpotStrategyType(d.Get("spot_strategy").(string))
+	if strategy != ecs.NoSpot && chargeType != common.PostPaid {
+		return fmt.Errorf("'spot_strategy' %s is only supported when the instance_charge_type is %s.", strategy, common.PostPaid)
+	}
+	if d.Get("spot_price_limit").(float64) > 0 && strategy != ecs.SpotWithPriceLimit {
+		return fmt.Errorf("'spot_price_limit' is only supported when the spot_strategy is %s.", ecs.SpotWithPriceLimit)
+	}
+
+	// The system disk can only grow in place, though a new image replaces it with a disk of any size.
+	if d.Id() != "" && d.HasChange("system_disk_size") && d.NewValueKnown("system_disk_size") && !d.HasChange("image_id") {
+		if o, n := d.GetChange("system_disk_size"); n.(int) < o.(int) {
+			return fmt.Errorf("'system_disk_size' can't be shrunk from %d to %d, unless the image_id is changed.", o.(int), n.(int))
+		}
+	}
+
+	if d.Get("allocate_public_ip").(bool) && d.NewValueKnown("internet_max_bandwidth_out") && d.Get("internet_max_bandwidth_out").(int) == 0 {
+		return fmt.Errorf("'internet_max_bandwidth_out' must be greater than 0 when the allocate_public_ip is true.")
+	}
+	return validateDataDisks(d, "data_disks")
+}
+
+func slbListenerCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	protocol := Protocol(d.Get("protocol").(string))
+	if protocol == Https && !diffFieldSet(d, "ssl_certificate_id") {
+		return fmt.Errorf("'ssl_certificate_id': required field is not set when the protocol is 'https'.")
+	}
+	if protocol != Http && protocol != Https || slb.FlagType(d.Get("sticky_session").(string)) != slb.OnFlag {
+		return nil
+	}
+
+	if !diffFieldSet(d, "sticky_session_type") {
+		return fmt.Errorf("'sticky_session_type': required field is not set when the StickySession is %s.", slb.OnFlag)
+	}
+	switch slb.StickySessionType(d.Get("sticky_session_type").(string)) {
+	case slb.InsertStickySessionType:
+		if !diffFieldSet(d, "cookie_timeout") {
+			return fmt.Errorf("'cookie_timeout': required field is not set when the StickySession is %s "+
+				"and StickySessionType is %s.", slb.OnFlag, slb.InsertStickySessionType)
+		}
+	case slb.ServerStickySessionType:
+		if !diffFieldSet(d, "cookie") {
+			return fmt.Errorf("'cookie': required field is not set when the StickySession is %s "+
+				"and StickySessionType is %s.", slb.OnFlag, slb.ServerStickySessionType)
+		}
+	}
+	return nil
+}
+
+// dbEngineVersions are the versions each engine of RDS supports.
+var dbEngineVersions = map[string][]string{
+	"MySQL":      {"5.5", "5.6", "5.7"},
+	"SQLServer":  {"2008r2", "2012"},
+	"PostgreSQL": {"9.4"},
+	"PPAS":       {"9.3"},
+}
+
+func dbInstanceCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if rds.DBPayType(d.Get("instance_charge_type").(string)) != rds.Prepaid && d.Get("period").(int) != 1 {
+		return fmt.Errorf("'period' is only supported when the instance_charge_type is %s.", rds.Prepaid)
+	}
+
+	if d.NewValueKnown("engine") && d.NewValueKnown("engine_version") {
+		engine, version := d.Get("engine").(string), d.Get("engine_version").(string)
+		if versions, ok := dbEngineVersions[engine]; ok {
+			supported := false
+			for _, v := range versions {
+				supported = supported || v == version
+			}
+			if !supported {
+				return fmt.Errorf("'engine_version' of %s must be one of %v, got %s.", engine, versions, version)
+			}
+		}
+	}
+	return nil
+}
+
+func essScalingConfigurationCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	return validateDataDisks(d, "data_disk")
+}
+
+// validateDataDisks checks each data disk of the list field has a size in the range of its category, or a snapshot.
+func validateDataDisks(d *schema.ResourceDiff, key string) error {
+	for i, v := range d.Get(key).([]interface{}) {
+		disk, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prefix := fmt.Sprintf("%s.%d.", key, i)
+		if !diffFieldSet(d, prefix+"size") && !diffFieldSet(d, prefix+"snapshot_id") {
+			return fmt.Errorf("One of size or snapshot_id is required for the %s %d.", key, i)
+		}
+		if d.NewValueKnown(prefix + "size") {
+			if err := validateDiskSize(ecs.DiskCategory(disk["category"].(string)), disk["size"].(int)); err != nil {
+				return fmt.Errorf("Invalid %s %d: %s", key, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func diskCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !diffFieldSet(d, "size") && !diffFieldSet(d, "snapshot_id") {
+		return fmt.Errorf("One of size or snapshot_id is required when specifying an ECS disk.")
+	}
+	if d.NewValueKnown("size") && d.NewValueKnown("category") {
+		return validateDiskSize(ecs.DiskCategory(d.Get("category").(string)), d.Get("size").(int))
+	}
+	return nil
+}
+
+// validateDiskSize checks the size of a disk is in the range of its category. The size 0 is left to the snapshot.
+func validateDiskSize(category ecs.DiskCategory, size int) error {
+	if size == 0 {
+		return nil
+	}
+	if category == ecs.DiskCategoryCloud && (size < 5 || size > 2000) {
+		return fmt.Errorf("the size of cloud disk must between 5 to 2000")
+	}
+	if (category == ecs.DiskCategoryCloudEfficiency || category == ecs.DiskCategoryCloudSSD) && (size < 20 || size > 32768) {
+		return fmt.Errorf("the size of %s disk must between 20 to 32768", category)
+	}
+	return nil
+}
+
+// diffFieldSet returns whether the field has a non-zero value in the plan, or a value which isn't known yet.
+func diffFieldSet(d *schema.ResourceDiff, key string) bool {
+	if !d.NewValueKnown(key) {
+		return true
+	}
+	_, ok := d.GetOk(key)
+	return ok
+}
@@ -96,7 +96,7 @@ func dataSourceAlicloudDnsDomains() *schema.Resource {
 	}
 }
 func dataSourceAlicloudDnsDomainsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DescribeDomainsArgs{}
 
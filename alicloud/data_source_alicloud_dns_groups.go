@@ -46,7 +46,7 @@ func dataSourceAlicloudDnsGroups() *schema.Resource {
 }
 
 func dataSourceAlicloudDnsGroupsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DescribeDomainGroupsArgs{}
 
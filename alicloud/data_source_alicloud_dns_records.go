@@ -118,7 +118,7 @@ func dataSourceAlicloudDnsRecords() *schema.Resource {
 }
 
 func dataSourceAlicloudDnsRecordsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DescribeDomainRecordsNewArgs{
 		DomainName: d.Get("domain_name").(string),
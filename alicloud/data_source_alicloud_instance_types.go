@@ -89,7 +89,7 @@ func dataSourceAlicloudInstanceTypesRead(d *schema.ResourceData, meta interface{
 		return err
 	}
 
-	resp, err := client.ecsconn.DescribeInstanceTypesNew(args)
+	resp, err := client.ecsConn().DescribeInstanceTypesNew(args)
 	if err != nil {
 		return err
 	}
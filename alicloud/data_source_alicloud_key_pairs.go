@@ -62,7 +62,7 @@ func dataSourceAlicloudKeyPairs() *schema.Resource {
 }
 
 func dataSourceAlicloudKeyPairsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	var regex *regexp.Regexp
 	if name, ok := d.GetOk("name_regex"); ok {
@@ -22,7 +22,7 @@ func dataSourceAlicloudRamAccountAlias() *schema.Resource {
 }
 
 func dataSourceAlicloudRamAccountAliasRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	resp, err := conn.GetAccountAlias()
 	if err != nil {
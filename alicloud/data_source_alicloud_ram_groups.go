@@ -64,7 +64,7 @@ func dataSourceAlicloudRamGroups() *schema.Resource {
 }
 
 func dataSourceAlicloudRamGroupsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	allGroups := []interface{}{}
 
 	allGroupsMap := make(map[string]interface{})
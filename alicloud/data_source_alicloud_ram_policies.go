@@ -94,7 +94,7 @@ func dataSourceAlicloudRamPolicies() *schema.Resource {
 }
 
 func dataSourceAlicloudRamPoliciesRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	allPolicies := []interface{}{}
 
 	allPoliciesMap := make(map[string]interface{})
@@ -187,7 +187,7 @@ func dataSourceAlicloudRamPoliciesRead(d *schema.ResourceData, meta interface{})
 }
 
 func ramPoliciesDescriptionAttributes(d *schema.ResourceData, policies []interface{}, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	var ids []string
 	var s []map[string]interface{}
 	for _, v := range policies {
@@ -82,7 +82,7 @@ func dataSourceAlicloudRamRoles() *schema.Resource {
 }
 
 func dataSourceAlicloudRamRolesRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	allRoles := []interface{}{}
 
 	allRolesMap := make(map[string]interface{})
@@ -143,12 +143,16 @@ func dataSourceAlicloudRamRolesRead(d *schema.ResourceData, meta interface{}) er
 }
 
 func ramRolesDescriptionAttributes(d *schema.ResourceData, meta interface{}, roles []interface{}) error {
+	client := meta.(*AliyunClient)
 	var ids []string
 	var s []map[string]interface{}
 	for _, v := range roles {
 		role := v.(ram.Role)
-		conn := meta.(*AliyunClient).ramconn
-		resp, _ := conn.GetRole(ram.RoleQueryRequest{RoleName: role.RoleName})
+		conn := client.ramConn()
+		resp, err := conn.GetRole(ram.RoleQueryRequest{RoleName: role.RoleName})
+		if err != nil {
+			return fmt.Errorf("GetRole got an error: %#v", err)
+		}
 		mapping := map[string]interface{}{
 			"id":                          role.RoleId,
 			"name":                        role.RoleName,
@@ -72,7 +72,7 @@ func dataSourceAlicloudRamUsers() *schema.Resource {
 }
 
 func dataSourceAlicloudRamUsersRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	allUsers := []interface{}{}
 
 	allUsersMap := make(map[string]interface{})
@@ -56,10 +56,10 @@ func dataSourceAlicloudRegions() *schema.Resource {
 }
 
 func dataSourceAlicloudRegionsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
 	currentRegion := getRegion(d, meta)
 
-	resp, err := conn.DescribeRegions()
+	resp, err := client.describeRegions()
 	if err != nil {
 		return err
 	}
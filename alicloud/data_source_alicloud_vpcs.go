@@ -103,7 +103,8 @@ func dataSourceAlicloudVpcs() *schema.Resource {
 	}
 }
 func dataSourceAlicloudVpcsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	args := &ecs.DescribeVpcsArgs{
 		RegionId: getRegion(d, meta),
@@ -147,7 +148,7 @@ func dataSourceAlicloudVpcsRead(d *schema.ResourceData, meta interface{}) error
 			continue
 		}
 
-		vrouters, _, err := meta.(*AliyunClient).vpcconn.DescribeVRouters(&ecs.DescribeVRoutersArgs{
+		vrouters, _, err := client.vpcConn().DescribeVRouters(&ecs.DescribeVRoutersArgs{
 			VRouterId: vpc.VRouterId,
 			RegionId:  getRegion(d, meta),
 		})
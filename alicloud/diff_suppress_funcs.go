@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/denverdino/aliyungo/common"
 	"github.com/denverdino/aliyungo/dns"
@@ -184,3 +185,9 @@ func ecsSpotPriceLimitDiffSuppressFunc(k, old, new string, d *schema.ResourceDat
 	}
 	return true
 }
+
+// ecsDataDiskSizeDiffSuppressFunc ignores the size of a data disk which is left to its snapshot.
+func ecsDataDiskSizeDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	snapshot := strings.TrimSuffix(k, "size") + "snapshot_id"
+	return new == "0" && d.Get(snapshot).(string) != ""
+}
@@ -0,0 +1,80 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// The metadata service which is available on every ECS instance.
+const DefaultEcsMetadataEndpoint = "http://100.100.100.200"
+
+const EcsRoleCredentialsPath = "/latest/meta-data/ram/security-credentials/"
+
+const EcsRoleCredentialsSuccess = "Success"
+
+// Temporary credentials are renewed some minutes before they expire.
+const CredentialsRefreshWindow = 5 * time.Minute
+
+// Never retry renewing credentials more frequently than this.
+const CredentialsMinRefreshInterval = 1 * time.Minute
+
+const ecsMetadataTimeout = 10 * time.Second
+
+type EcsRoleCredentials struct {
+	AccessKeyId     string
+	AccessKeySecret string
+	SecurityToken   string
+	Expiration      time.Time
+	LastUpdated     time.Time
+	Code            string
+}
+
+// getEcsRoleCredentials fetches the STS credentials of the RAM role attached to the current ECS instance
+// from the metadata service.
+func getEcsRoleCredentials(endpoint, roleName string) (*EcsRoleCredentials, error) {
+	if endpoint == "" {
+		endpoint = DefaultEcsMetadataEndpoint
+	}
+	url := strings.TrimSuffix(endpoint, "/") + EcsRoleCredentialsPath + roleName
+
+	client := &http.Client{Timeout: ecsMetadataTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("Fetching credentials of ECS RAM role %s from %s got an error: %#v.", roleName, url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Reading credentials of ECS RAM role %s got an error: %#v.", roleName, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Fetching credentials of ECS RAM role %s got an unexpected status %d: %s. "+
+			"Please make sure the role has been attached to the current ECS instance.", roleName, resp.StatusCode, string(body))
+	}
+
+	var credentials EcsRoleCredentials
+	if err := json.Unmarshal(body, &credentials); err != nil {
+		return nil, fmt.Errorf("Parsing credentials of ECS RAM role %s got an error: %#v.", roleName, err)
+	}
+
+	if credentials.Code != EcsRoleCredentialsSuccess {
+		return nil, fmt.Errorf("Fetching credentials of ECS RAM role %s failed with code %s.", roleName, credentials.Code)
+	}
+
+	return &credentials, nil
+}
+
+// credentialsRefreshDelay returns how long to wait before renewing credentials which expire at the expiration.
+func credentialsRefreshDelay(expiration, now time.Time) time.Duration {
+	delay := expiration.Sub(now) - CredentialsRefreshWindow
+	if delay < CredentialsMinRefreshInterval {
+		return CredentialsMinRefreshInterval
+	}
+	return delay
+}
@@ -0,0 +1,137 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testEcsRoleName = "terraform-runner"
+
+func testEcsMetadataServer(code string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EcsRoleCredentialsPath+testEcsRoleName {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{
+  "AccessKeyId" : "STS.AccessKeyId",
+  "AccessKeySecret" : "AccessKeySecret",
+  "Expiration" : "2017-11-01T05:20:01Z",
+  "SecurityToken" : "SecurityToken",
+  "LastUpdated" : "2017-10-31T23:20:01Z",
+  "Code" : "%s"
+}`, code)
+	}))
+}
+
+func TestGetEcsRoleCredentials(t *testing.T) {
+	server := testEcsMetadataServer(EcsRoleCredentialsSuccess)
+	defer server.Close()
+
+	credentials, err := getEcsRoleCredentials(server.URL, testEcsRoleName)
+	if err != nil {
+		t.Fatalf("fetching ECS role credentials got an error: %#v", err)
+	}
+
+	if credentials.AccessKeyId != "STS.AccessKeyId" || credentials.AccessKeySecret != "AccessKeySecret" ||
+		credentials.SecurityToken != "SecurityToken" {
+		t.Fatalf("unexpected credentials: %#v", credentials)
+	}
+
+	expiration := time.Date(2017, 11, 1, 5, 20, 1, 0, time.UTC)
+	if !credentials.Expiration.Equal(expiration) {
+		t.Fatalf("expected expiration %s, got %s", expiration, credentials.Expiration)
+	}
+}
+
+func TestGetEcsRoleCredentials_invalid(t *testing.T) {
+	server := testEcsMetadataServer(EcsRoleCredentialsSuccess)
+	defer server.Close()
+
+	if _, err := getEcsRoleCredentials(server.URL, "not-attached"); err == nil {
+		t.Fatalf("expected an error for a role which is not attached")
+	}
+
+	failed := testEcsMetadataServer("Failed")
+	defer failed.Close()
+
+	if _, err := getEcsRoleCredentials(failed.URL, testEcsRoleName); err == nil {
+		t.Fatalf("expected an error for a failed code")
+	}
+}
+
+func TestConfigLoadEcsRoleCredentials(t *testing.T) {
+	server := testEcsMetadataServer(EcsRoleCredentialsSuccess)
+	defer server.Close()
+
+	config := Config{
+		AccessKey:           "StaticAccessKey",
+		SecretKey:           "StaticSecretKey",
+		EcsRoleName:         testEcsRoleName,
+		EcsMetadataEndpoint: server.URL,
+	}
+	if err := config.loadCredentials(); err != nil {
+		t.Fatalf("loading credentials got an error: %#v", err)
+	}
+
+	if config.AccessKey != "STS.AccessKeyId" || config.SecretKey != "AccessKeySecret" || config.SecurityToken != "SecurityToken" {
+		t.Fatalf("ECS role credentials should replace the static ones, got %#v", config)
+	}
+	if config.credentialsExpiration.IsZero() {
+		t.Fatalf("the credentials expiration should be set")
+	}
+}
+
+func TestCredentialsRefreshDelay(t *testing.T) {
+	now := time.Now()
+	cases := map[time.Duration]time.Duration{
+		time.Hour:                time.Hour - CredentialsRefreshWindow,
+		CredentialsRefreshWindow: CredentialsMinRefreshInterval,
+		-time.Hour:               CredentialsMinRefreshInterval,
+	}
+
+	for left, expected := range cases {
+		if delay := credentialsRefreshDelay(now.Add(left), now); delay != expected {
+			t.Fatalf("credentials expiring in %s should be renewed after %s, got %s", left, expected, delay)
+		}
+	}
+}
+
+func TestKeepCredentialsFreshStops(t *testing.T) {
+	server := testEcsMetadataServer(EcsRoleCredentialsSuccess)
+	defer server.Close()
+
+	ctx, stop := context.WithCancel(context.Background())
+	config := Config{
+		Region:              "cn-beijing",
+		EcsRoleName:         testEcsRoleName,
+		EcsMetadataEndpoint: server.URL,
+		stopContext:         ctx,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("creating client got an error: %#v", err)
+	}
+	if client.pool.stop == nil {
+		t.Fatalf("the temporary credentials should be renewed in the background")
+	}
+	select {
+	case <-client.pool.done:
+		t.Fatalf("the credentials should be renewed until the context is done")
+	default:
+	}
+	stop()
+
+	// The stopped refresher returns at once instead of waiting for the credentials to expire.
+	select {
+	case <-client.pool.done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("renewing credentials should stop when the context is done")
+	}
+
+	client.Close()
+}
@@ -1,14 +1,20 @@
 package alicloud
 
 import (
+	"net/http"
 	"strings"
 
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/denverdino/aliyungo/common"
 )
 
 const (
 	// common
 	Notfound = "Not found"
+	// throttling
+	Throttling     = "Throttling"
+	ThrottlingUser = "Throttling.User"
+	ThrottlingApi  = "Throttling.Api"
 	// ecs
 	InstanceNotFound        = "Instance.Notfound"
 	MessageInstanceNotFound = "instance is not found"
@@ -128,6 +134,163 @@ const (
 	DependencyViolationRouterInterfaceReferedByRouteEntry = "DependencyViolation.RouterInterfaceReferedByRouteEntry"
 )
 
+// ErrorCategory is the kind of failure an API error means. The resources handle the errors by their categories
+// rather than by the codes, which differ between the services for the same kind of failure.
+type ErrorCategory string
+
+const (
+	ErrorCategoryUnknown       = ErrorCategory("")
+	ErrorCategoryNotFound      = ErrorCategory("NotFound")
+	ErrorCategoryConflict      = ErrorCategory("Conflict")
+	ErrorCategoryThrottled     = ErrorCategory("Throttled")
+	ErrorCategoryRetryable     = ErrorCategory("Retryable")
+	ErrorCategoryQuotaExceeded = ErrorCategory("QuotaExceeded")
+	ErrorCategoryInvalidParam  = ErrorCategory("InvalidParam")
+)
+
+// errorCodeCategories are the categories of the known error codes. A code which isn't listed is classified by
+// its pattern, e.g. the codes ending with NotFound, so the list only has the codes the patterns get wrong.
+var errorCodeCategories = map[string]ErrorCategory{
+	// throttling
+	Throttling:     ErrorCategoryThrottled,
+	ThrottlingUser: ErrorCategoryThrottled,
+	ThrottlingApi:  ErrorCategoryThrottled,
+	// the backend is busy or temporarily unavailable, or another operation is in progress
+	SystemBusy:                ErrorCategoryRetryable,
+	ServiceBusy:               ErrorCategoryRetryable,
+	KeyPairServiceUnavailable: ErrorCategoryRetryable,
+	DiskInternalError:         ErrorCategoryRetryable,
+	UnknownError:              ErrorCategoryRetryable,
+	ServiceIsConfiguring:      ErrorCategoryRetryable,
+	BackendServerconfiguring:  ErrorCategoryRetryable,
+	TaskConflict:              ErrorCategoryRetryable,
+	DiskOperationConflict:     ErrorCategoryRetryable,
+	// not found
+	InstanceNotFound:               ErrorCategoryNotFound,
+	SystemDiskNotFound:             ErrorCategoryNotFound,
+	NotFindSnatEntryBySnatId:       ErrorCategoryNotFound,
+	NotFindForwardEntryByForwardId: ErrorCategoryNotFound,
+	RamInstanceNotFound:            ErrorCategoryNotFound,
+	DomainRecordNotBelongToUser:    ErrorCategoryNotFound,
+	// the resource is in a status which doesn't allow the operation, or has dependencies
+	DiskCreatingSnapshot:            ErrorCategoryConflict,
+	InstanceLockedForSecurity:       ErrorCategoryConflict,
+	DiskInvalidOperation:            ErrorCategoryConflict,
+	RouterEntryForbbiden:            ErrorCategoryConflict,
+	RecordForbiddenDNSChange:        ErrorCategoryConflict,
+	FobiddenNotEmptyGroup:           ErrorCategoryConflict,
+	OperationDeniedDBInstanceStatus: ErrorCategoryConflict,
+	// invalid requests, including the ones whose codes look like not found
+	InvalidParameter:              ErrorCategoryInvalidParam,
+	UnsupportedProtocalPort:       ErrorCategoryInvalidParam,
+	NatGatewayInvalidRegionId:     ErrorCategoryInvalidParam,
+	VswitcInvalidRegionId:         ErrorCategoryInvalidParam,
+	SlbOrderFailed:                ErrorCategoryInvalidParam,
+	"InvalidAction.NotFound":      ErrorCategoryInvalidParam,
+	"InvalidAccessKeyId.NotFound": ErrorCategoryInvalidParam,
+	"InvalidZoneId.NotFound":      ErrorCategoryInvalidParam,
+}
+
+// errorMessageCategories are the messages of the errors whose codes are too generic to tell their categories,
+// by the codes they come with. A message is only told apart under its code, e.g. a missing listener is only
+// InvalidParameter with ListenerNotFound. A text in lower case matches the message case-insensitively.
+var errorMessageCategories = map[string]map[string]ErrorCategory{
+	InvalidParameter: {
+		ListenerNotFound:            ErrorCategoryNotFound,
+		VServerGroupNotFoundMessage: ErrorCategoryNotFound,
+	},
+	// the errors made by the SDK and the provider rather than returned by the APIs
+	AliyunGoClientFailure: {
+		MessageInstanceNotFound: ErrorCategoryNotFound,
+		Notfound:                ErrorCategoryNotFound,
+	},
+}
+
+// ossNotFoundMessage is the message of OSS for a missing object of some APIs, which comes with no code.
+const ossNotFoundMessage = "No Row found"
+
+// ApiError is an error of an API call normalized from the errors of aliyungo, including RAM, and OSS.
+type ApiError struct {
+	Category   ErrorCategory
+	Code       string
+	Message    string
+	RequestId  string
+	StatusCode int
+
+	// The error returned by the SDK
+	Err error
+}
+
+func (e *ApiError) Error() string {
+	return e.Err.Error()
+}
+
+// NormalizeError returns the API error of err, or nil if err isn't an error of an API call.
+func NormalizeError(err error) *ApiError {
+	var e *ApiError
+	ossError := false
+	switch v := err.(type) {
+	case *ApiError:
+		return v
+	case *common.Error:
+		e = &ApiError{Code: v.Code, Message: v.Message, RequestId: v.RequestId, StatusCode: v.StatusCode}
+	case oss.ServiceError:
+		e = &ApiError{Code: v.Code, Message: v.Message, RequestId: v.RequestID, StatusCode: v.StatusCode}
+		ossError = true
+	case *oss.ServiceError:
+		e = &ApiError{Code: v.Code, Message: v.Message, RequestId: v.RequestID, StatusCode: v.StatusCode}
+		ossError = true
+	default:
+		return nil
+	}
+	e.Err = err
+	e.Category = classifyError(e.Code, e.Message)
+
+	// OSS answers a HEAD request of a missing bucket or object with no body, and so no code. The other services
+	// answer that way only when the request doesn't reach them, e.g. by a wrong endpoint, which isn't not found.
+	if ossError && e.Code == "" && (e.StatusCode == http.StatusNotFound || strings.HasPrefix(e.Message, ossNotFoundMessage)) {
+		e.Category = ErrorCategoryNotFound
+	}
+	return e
+}
+
+// ErrorCategoryOf returns the category of the error, ErrorCategoryUnknown if it isn't an error of an API call.
+func ErrorCategoryOf(err error) ErrorCategory {
+	if e := NormalizeError(err); e != nil {
+		return e.Category
+	}
+	return ErrorCategoryUnknown
+}
+
+func classifyError(code, message string) ErrorCategory {
+	// The messages of a generic code are checked before the code itself, since ListenerNotFound comes with InvalidParameter.
+	for text, category := range errorMessageCategories[code] {
+		if strings.Contains(message, text) || strings.Contains(strings.ToLower(message), text) {
+			return category
+		}
+	}
+	if category, ok := errorCodeCategories[code]; ok {
+		return category
+	}
+
+	switch {
+	case strings.HasPrefix(code, "Throttling"):
+		return ErrorCategoryThrottled
+	case strings.HasSuffix(code, "NotFound") || strings.HasPrefix(code, "NoSuch") || strings.Contains(code, "EntityNotExist"):
+		return ErrorCategoryNotFound
+	case strings.HasPrefix(code, "QuotaExceeded"):
+		return ErrorCategoryQuotaExceeded
+	case strings.HasPrefix(code, "DeleteConflict") || strings.HasPrefix(code, "DependencyViolation") ||
+		strings.HasPrefix(code, "Incorrect") || strings.HasSuffix(code, "Status") ||
+		strings.HasSuffix(code, "Duplicate") || strings.HasSuffix(code, "Duplicated") ||
+		strings.HasSuffix(code, "AlreadyExists") || strings.HasSuffix(code, "Exists"):
+		return ErrorCategoryConflict
+	case strings.HasPrefix(code, "Invalid") || strings.HasPrefix(code, "Missing"):
+		return ErrorCategoryInvalidParam
+	}
+	return ErrorCategoryUnknown
+}
+
 func GetNotFoundErrorFromString(str string) error {
 	return &common.Error{
 		ErrorResponse: common.ErrorResponse{
@@ -138,27 +301,38 @@ func GetNotFoundErrorFromString(str string) error {
 	}
 }
 
+// NotFoundError reports whether the resource, or the one it belongs to, doesn't exist.
 func NotFoundError(err error) bool {
-	if e, ok := err.(*common.Error); ok &&
-		(e.Code == InstanceNotFound || e.Code == RamInstanceNotFound ||
-			strings.Contains(strings.ToLower(e.Message), MessageInstanceNotFound)) {
-		return true
-	}
+	return ErrorCategoryOf(err) == ErrorCategoryNotFound
+}
 
-	return false
+// IsConflictError reports whether the resource is in a status which doesn't allow the operation,
+// it has dependencies, or it exists already.
+func IsConflictError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryConflict
 }
 
-func IsExceptedError(err error, expectCode string) bool {
-	if e, ok := err.(*common.Error); ok && (e.Code == expectCode || strings.Contains(e.Message, expectCode)) {
-		return true
-	}
+// IsThrottledError reports whether the API call was rejected by the rate limit.
+func IsThrottledError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryThrottled
+}
 
-	return false
+// IsQuotaExceededError reports whether creating the resource exceeds the quota of the account.
+func IsQuotaExceededError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryQuotaExceeded
+}
+
+// IsInvalidParamError reports whether the request was rejected for its parameters.
+func IsInvalidParamError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryInvalidParam
 }
 
-func RamEntityNotExist(err error) bool {
-	if e, ok := err.(*common.Error); ok && strings.Contains(e.Code, "EntityNotExist") {
+// IsExceptedError reports whether the API call failed with the code, or with a message containing it.
+// Prefer the categories of the errors, unless the code needs handling different from the rest of its category.
+func IsExceptedError(err error, expectCode string) bool {
+	if e := NormalizeError(err); e != nil && (e.Code == expectCode || strings.Contains(e.Message, expectCode)) {
 		return true
 	}
+
 	return false
 }
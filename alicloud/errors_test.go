@@ -0,0 +1,232 @@
+package alicloud
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/denverdino/aliyungo/common"
+)
+
+func TestErrorCategoryOf_codes(t *testing.T) {
+	cases := []struct {
+		code     string
+		category ErrorCategory
+	}{
+		// throttling
+		{Throttling, ErrorCategoryThrottled},
+		{ThrottlingUser, ErrorCategoryThrottled},
+		{ThrottlingApi, ErrorCategoryThrottled},
+		{"Throttling.Resource", ErrorCategoryThrottled},
+		// ecs
+		{InstanceNotFound, ErrorCategoryNotFound},
+		// disk
+		{DiskIncorrectStatus, ErrorCategoryConflict},
+		{DiskCreatingSnapshot, ErrorCategoryConflict},
+		{InstanceLockedForSecurity, ErrorCategoryConflict},
+		{SystemDiskNotFound, ErrorCategoryNotFound},
+		{DiskOperationConflict, ErrorCategoryRetryable},
+		{DiskInternalError, ErrorCategoryRetryable},
+		{DiskInvalidOperation, ErrorCategoryConflict},
+		// eip
+		{EipIncorrectStatus, ErrorCategoryConflict},
+		{InstanceIncorrectStatus, ErrorCategoryConflict},
+		{HaVipIncorrectStatus, ErrorCategoryConflict},
+		// slb
+		{LoadBalancerNotFound, ErrorCategoryNotFound},
+		{UnsupportedProtocalPort, ErrorCategoryInvalidParam},
+		{ListenerAlreadyExists, ErrorCategoryConflict},
+		{ServiceIsConfiguring, ErrorCategoryRetryable},
+		{BackendServerconfiguring, ErrorCategoryRetryable},
+		{SystemBusy, ErrorCategoryRetryable},
+		{SlbOrderFailed, ErrorCategoryInvalidParam},
+		{InvalidParameter, ErrorCategoryInvalidParam},
+		// security_group
+		{InvalidInstanceIdAlreadyExists, ErrorCategoryConflict},
+		{InvalidSecurityGroupIdNotFound, ErrorCategoryNotFound},
+		{SgDependencyViolation, ErrorCategoryConflict},
+		// nat gateway
+		{NatGatewayInvalidRegionId, ErrorCategoryInvalidParam},
+		{DependencyViolationBandwidthPackages, ErrorCategoryConflict},
+		{NotFindSnatEntryBySnatId, ErrorCategoryNotFound},
+		{NotFindForwardEntryByForwardId, ErrorCategoryNotFound},
+		// vpc and vswitch
+		{VpcQuotaExceeded, ErrorCategoryQuotaExceeded},
+		{VswitcInvalidRegionId, ErrorCategoryInvalidParam},
+		// route entry
+		{IncorrectRouteEntryStatus, ErrorCategoryConflict},
+		{TaskConflict, ErrorCategoryRetryable},
+		{RouterEntryForbbiden, ErrorCategoryConflict},
+		{RouterEntryConflictDuplicated, ErrorCategoryConflict},
+		// ess
+		{InvalidScalingGroupIdNotFound, ErrorCategoryNotFound},
+		{IncorrectScalingConfigurationLifecycleState, ErrorCategoryConflict},
+		{IncorrectScalingGroupStatus, ErrorCategoryConflict},
+		// rds
+		{InvalidDBNameNotFound, ErrorCategoryNotFound},
+		{InvalidDBInstanceNameNotFound, ErrorCategoryNotFound},
+		{InvalidCurrentConnectionStringNotFound, ErrorCategoryNotFound},
+		{NetTypeExists, ErrorCategoryConflict},
+		{InvalidAccountNameDuplicate, ErrorCategoryConflict},
+		{InvalidAccountNameNotFound, ErrorCategoryNotFound},
+		{OperationDeniedDBInstanceStatus, ErrorCategoryConflict},
+		{InvalidConnectionStringDuplicate, ErrorCategoryConflict},
+		{AtLeastOneNetTypeExists, ErrorCategoryConflict},
+		// oss
+		{OssBucketNotFound, ErrorCategoryNotFound},
+		// ram
+		{RamInstanceNotFound, ErrorCategoryNotFound},
+		{AliyunGoClientFailure, ErrorCategoryUnknown},
+		{"EntityNotExist.User", ErrorCategoryNotFound},
+		{DeleteConflictUserGroup, ErrorCategoryConflict},
+		{DeleteConflictUserAccessKey, ErrorCategoryConflict},
+		{DeleteConflictUserLoginProfile, ErrorCategoryConflict},
+		{DeleteConflictUserMFADevice, ErrorCategoryConflict},
+		{DeleteConflictUserPolicy, ErrorCategoryConflict},
+		{DeleteConflictVirtualMFADeviceUser, ErrorCategoryConflict},
+		{DeleteConflictGroupUser, ErrorCategoryConflict},
+		{DeleteConflictGroupPolicy, ErrorCategoryConflict},
+		{DeleteConflictRolePolicy, ErrorCategoryConflict},
+		{DeleteConflictPolicyUser, ErrorCategoryConflict},
+		{DeleteConflictPolicyGroup, ErrorCategoryConflict},
+		{DeleteConflictPolicyVersion, ErrorCategoryConflict},
+		// dns
+		{RecordForbiddenDNSChange, ErrorCategoryConflict},
+		{FobiddenNotEmptyGroup, ErrorCategoryConflict},
+		{DomainRecordNotBelongToUser, ErrorCategoryNotFound},
+		// unknown, key pair, container and cdn
+		{UnknownError, ErrorCategoryRetryable},
+		{KeyPairNotFound, ErrorCategoryNotFound},
+		{KeyPairServiceUnavailable, ErrorCategoryRetryable},
+		{ErrorClusterNotFound, ErrorCategoryNotFound},
+		{ServiceBusy, ErrorCategoryRetryable},
+		// ram role and router interface
+		{InvalidRamRoleNotFound, ErrorCategoryNotFound},
+		{InvalidInstanceIdNotFound, ErrorCategoryNotFound},
+		{RouterInterfaceIncorrectStatus, ErrorCategoryConflict},
+		{DependencyViolationRouterInterfaceReferedByRouteEntry, ErrorCategoryConflict},
+		// the invalid requests whose codes look like not found
+		{"InvalidAction.NotFound", ErrorCategoryInvalidParam},
+		{"InvalidAccessKeyId.NotFound", ErrorCategoryInvalidParam},
+		{"InvalidZoneId.NotFound", ErrorCategoryInvalidParam},
+		{"MissingParameter", ErrorCategoryInvalidParam},
+		{"Forbidden.RAM", ErrorCategoryUnknown},
+	}
+
+	for _, c := range cases {
+		if category := ErrorCategoryOf(testApiError(c.code)); category != c.category {
+			t.Errorf("expected the code %s in the category %q, got %q", c.code, c.category, category)
+		}
+	}
+}
+
+func TestErrorCategoryOf_messages(t *testing.T) {
+	cases := []struct {
+		code     string
+		message  string
+		category ErrorCategory
+	}{
+		{AliyunGoClientFailure, MessageInstanceNotFound, ErrorCategoryNotFound},
+		{AliyunGoClientFailure, "The specified instance is not found.", ErrorCategoryNotFound},
+		{AliyunGoClientFailure, "The Instance Is Not Found.", ErrorCategoryNotFound},
+		{AliyunGoClientFailure, Notfound, ErrorCategoryNotFound},
+		{InvalidParameter, ListenerNotFound + ".", ErrorCategoryNotFound},
+		{InvalidParameter, VServerGroupNotFoundMessage + ".", ErrorCategoryNotFound},
+		// the messages of the other codes
+		{"", MessageInstanceNotFound, ErrorCategoryUnknown},
+		{"", Notfound, ErrorCategoryUnknown},
+		{"", OssBodyNotFound, ErrorCategoryUnknown},
+		{DiskIncorrectStatus, ListenerNotFound + ".", ErrorCategoryConflict},
+		{"Forbidden", "The route table was not found in the cache.", ErrorCategoryUnknown},
+		{"", RoleAttachmentUnExpectedJson, ErrorCategoryUnknown},
+	}
+
+	for _, c := range cases {
+		err := &common.Error{ErrorResponse: common.ErrorResponse{Code: c.code, Message: c.message}, StatusCode: 400}
+		if category := ErrorCategoryOf(err); category != c.category {
+			t.Errorf("expected the message %q in the category %q, got %q", c.message, c.category, category)
+		}
+	}
+}
+
+func TestNormalizeError(t *testing.T) {
+	apiErr := testApiError(InvalidDBInstanceNameNotFound).(*common.Error)
+	apiErr.RequestId = "request-1"
+	ossErr := oss.ServiceError{Code: OssBucketNotFound, Message: "The specified bucket does not exist.", RequestID: "request-2", StatusCode: http.StatusNotFound}
+	ossHeadErr := oss.ServiceError{StatusCode: http.StatusNotFound, RawMessage: OssBodyNotFound}
+	ossRowErr := oss.ServiceError{StatusCode: http.StatusBadRequest, Message: ossNotFoundMessage + " for the key."}
+	ossErrorErr := oss.ServiceError{StatusCode: http.StatusBadRequest, Message: "Bad request."}
+
+	cases := []struct {
+		err        error
+		category   ErrorCategory
+		code       string
+		requestId  string
+		statusCode int
+	}{
+		{apiErr, ErrorCategoryNotFound, InvalidDBInstanceNameNotFound, "request-1", 400},
+		{GetNotFoundErrorFromString("instance i-abc is not found"), ErrorCategoryNotFound, InstanceNotFound, "", -1},
+		{ossErr, ErrorCategoryNotFound, OssBucketNotFound, "request-2", http.StatusNotFound},
+		{&ossErr, ErrorCategoryNotFound, OssBucketNotFound, "request-2", http.StatusNotFound},
+		{ossHeadErr, ErrorCategoryNotFound, "", "", http.StatusNotFound},
+		{ossRowErr, ErrorCategoryNotFound, "", "", http.StatusBadRequest},
+		{ossErrorErr, ErrorCategoryUnknown, "", "", http.StatusBadRequest},
+		// a service other than OSS answers with no code only when the request doesn't reach it, e.g. by a wrong endpoint
+		{&common.Error{StatusCode: http.StatusNotFound}, ErrorCategoryUnknown, "", "", http.StatusNotFound},
+		{oss.ServiceError{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}, ErrorCategoryUnknown, "SlowDown", "", http.StatusServiceUnavailable},
+	}
+
+	for _, c := range cases {
+		e := NormalizeError(c.err)
+		if e == nil {
+			t.Fatalf("expected %#v normalized, got nil", c.err)
+		}
+		if e.Category != c.category || e.Code != c.code || e.RequestId != c.requestId || e.StatusCode != c.statusCode {
+			t.Errorf("expected %#v normalized to %q %s %s %d, got %#v", c.err, c.category, c.code, c.requestId, c.statusCode, e)
+		}
+		if e.Error() != c.err.Error() {
+			t.Errorf("expected the message of the original error %q, got %q", c.err.Error(), e.Error())
+		}
+		if NormalizeError(e) != e {
+			t.Errorf("expected the normalized error kept as it is")
+		}
+	}
+
+	if e := NormalizeError(nil); e != nil {
+		t.Errorf("expected nil normalized to nil, got %#v", e)
+	}
+	if e := NormalizeError(fmt.Errorf("connection refused")); e != nil {
+		t.Errorf("expected an error not of an API call normalized to nil, got %#v", e)
+	}
+	if category := ErrorCategoryOf(fmt.Errorf("Instance.Notfound")); category != ErrorCategoryUnknown {
+		t.Errorf("expected an error not of an API call in the unknown category, got %q", category)
+	}
+}
+
+func TestErrorCategoryPredicates(t *testing.T) {
+	cases := []struct {
+		predicate func(error) bool
+		matched   error
+		unmatched error
+	}{
+		{NotFoundError, testApiError(KeyPairNotFound), testApiError(InvalidParameter)},
+		{IsConflictError, testApiError(DeleteConflictUserGroup), testApiError(KeyPairNotFound)},
+		{IsThrottledError, testApiError(Throttling), testApiError(SystemBusy)},
+		{IsRetryableError, testApiError(SystemBusy), testApiError(DiskIncorrectStatus)},
+		{IsQuotaExceededError, testApiError(VpcQuotaExceeded), testApiError(Throttling)},
+		{IsInvalidParamError, testApiError(InvalidParameter), testApiError(VpcQuotaExceeded)},
+	}
+
+	for i, c := range cases {
+		if !c.predicate(c.matched) {
+			t.Errorf("%d: expected %#v matched", i, c.matched)
+		}
+		if c.predicate(c.unmatched) {
+			t.Errorf("%d: expected %#v not matched", i, c.unmatched)
+		}
+		if c.predicate(nil) {
+			t.Errorf("%d: expected nil not matched", i)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package alicloud
 
-import "github.com/denverdino/aliyungo/ecs"
+import (
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+)
 
 type GroupRuleIpProtocol string
 
@@ -47,3 +50,120 @@ var SupportedDiskCategory = map[ecs.DiskCategory]ecs.DiskCategory{
 	ecs.DiskCategoryCloudSSD:        ecs.DiskCategoryCloudSSD,
 	ecs.DiskCategoryCloudEfficiency: ecs.DiskCategoryCloudEfficiency,
 	ecs.DiskCategoryCloud:           ecs.DiskCategoryCloud}
+
+// The automatic snapshot policy APIs aren't supported by the SDK, so they are called by Invoke.
+// Most of their parameters start in lower case, e.g. autoSnapshotPolicyId, which the struct fields can't,
+// so they are passed as url.Values.
+
+// AutoSnapshotPolicyType is an automatic snapshot policy. The time points and the weekdays are JSON arrays,
+// e.g. ["0", "12"].
+type AutoSnapshotPolicyType struct {
+	AutoSnapshotPolicyId   string
+	AutoSnapshotPolicyName string
+	RegionId               common.Region
+	TimePoints             string
+	RepeatWeekdays         string
+	RetentionDays          int
+	DiskNums               int
+	Status                 string
+	CreationTime           string
+}
+
+type CreateAutoSnapshotPolicyResponse struct {
+	common.Response
+	AutoSnapshotPolicyId string
+}
+
+type DescribeAutoSnapshotPolicyExResponse struct {
+	common.Response
+	common.PaginationResult
+	AutoSnapshotPolicies struct {
+		AutoSnapshotPolicy []AutoSnapshotPolicyType
+	}
+}
+
+// DiskAutoSnapshotPolicyType is the automatic snapshot policy applied to a disk, which ecs.DiskItemType lacks.
+type DiskAutoSnapshotPolicyType struct {
+	DiskId               string
+	AutoSnapshotPolicyId string
+}
+
+type DescribeDiskAutoSnapshotPoliciesResponse struct {
+	common.Response
+	common.PaginationResult
+	Disks struct {
+		Disk []DiskAutoSnapshotPolicyType
+	}
+}
+
+type ModifySnapshotAttributeArgs struct {
+	SnapshotId   string
+	SnapshotName string
+	Description  string
+}
+
+// CreateImageArgs adds the disk device mappings, which ecs.CreateImageArgs lacks.
+type CreateImageArgs struct {
+	RegionId          common.Region
+	InstanceId        string
+	SnapshotId        string
+	ImageName         string
+	Description       string
+	DiskDeviceMapping []ImageDiskDeviceMapping
+	ClientToken       string
+}
+
+// ImageDiskDeviceMapping is a disk of the image created from a snapshot, in GiB.
+type ImageDiskDeviceMapping struct {
+	SnapshotId string
+	Device     string
+	Size       int
+}
+
+type ModifyImageAttributeArgs struct {
+	RegionId    common.Region
+	ImageId     string
+	ImageName   string
+	Description string
+}
+
+// ModifyPrepayInstanceSpecArgs changes the instance type of a PrePaid instance, which the SDK lacks.
+type ModifyPrepayInstanceSpecArgs struct {
+	RegionId     common.Region
+	InstanceId   string
+	InstanceType string
+	AutoPay      bool
+	ClientToken  string
+}
+
+// CreateInstanceArgs adds the encryption of the data disks, which ecs.DataDiskType lacks.
+type CreateInstanceArgs struct {
+	ecs.CreateInstanceArgs
+	DataDisk []DataDiskType
+}
+
+type DataDiskType struct {
+	Size               int
+	Category           ecs.DiskCategory
+	SnapshotId         string
+	DiskName           string
+	Description        string
+	Encrypted          bool
+	DeleteWithInstance bool
+}
+
+// ResizeDiskArgs adds the type of the resize, which ecs.ResizeDiskArgs lacks.
+type ResizeDiskArgs struct {
+	DiskId  string
+	NewSize int
+	Type    ResizeDiskType
+}
+
+type ResizeDiskType string
+
+const (
+	// ResizeDiskOnline grows a disk of a running instance, which takes effect at once.
+	ResizeDiskOnline = ResizeDiskType("online")
+	// ResizeDiskOffline grows a disk, which takes effect after the instance is restarted.
+	ResizeDiskOffline = ResizeDiskType("offline")
+)
@@ -1,22 +1,8 @@
 package alicloud
 
-import (
-	"strings"
-
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
-)
-
 type LifecycleRuleStatus string
 
 const (
 	ExpirationStatusEnabled  = LifecycleRuleStatus("Enabled")
 	ExpirationStatusDisabled = LifecycleRuleStatus("Disabled")
 )
-
-func ossNotFoundError(err error) bool {
-	if e, ok := err.(oss.ServiceError); ok &&
-		(e.StatusCode == 404 || strings.HasPrefix(e.Code, "NoSuch") || strings.HasPrefix(e.Message, "No Row found")) {
-		return true
-	}
-	return false
-}
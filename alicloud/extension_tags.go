@@ -1,8 +1,17 @@
 package alicloud
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/denverdino/aliyungo/common"
 	"github.com/denverdino/aliyungo/ecs"
+	"github.com/denverdino/aliyungo/slb"
 )
 
 type Tag struct {
@@ -41,3 +50,367 @@ func RemoveTags(client *ecs.Client, args *RemoveTagsArgs) error {
 	}
 	return err
 }
+
+// tagService adds, removes and describes the tags of a kind of resource by the tag API of its service.
+type tagService interface {
+	addTags(client *AliyunClient, resourceId string, tags []Tag) error
+	removeTags(client *AliyunClient, resourceId string, tags []Tag) error
+	describeTags(client *AliyunClient, resourceId string) ([]Tag, error)
+}
+
+// The security group resource type of the ECS tag API, which the SDK doesn't define.
+const TagResourceSecurityGroup = ecs.TagResourceType("securitygroup")
+
+// ecsTags tags instances, disks and security groups by the ECS tag API.
+type ecsTags ecs.TagResourceType
+
+func (t ecsTags) addTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	return AddTags(client.ecsConn(), &AddTagsArgs{
+		RegionId:     client.Region,
+		ResourceId:   resourceId,
+		ResourceType: ecs.TagResourceType(t),
+		Tag:          tags,
+	})
+}
+
+func (t ecsTags) removeTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	return RemoveTags(client.ecsConn(), &RemoveTagsArgs{
+		RegionId:     client.Region,
+		ResourceId:   resourceId,
+		ResourceType: ecs.TagResourceType(t),
+		Tag:          tags,
+	})
+}
+
+func (t ecsTags) describeTags(client *AliyunClient, resourceId string) ([]Tag, error) {
+	items, _, err := client.ecsConn().DescribeTags(&ecs.DescribeTagsArgs{
+		RegionId:     client.Region,
+		ResourceType: ecs.TagResourceType(t),
+		ResourceId:   resourceId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, item := range items {
+		tags = append(tags, Tag{Key: item.TagKey, Value: item.TagValue})
+	}
+	return tags, nil
+}
+
+// The resource types of the VPC tag API
+const (
+	VpcTagResourceVpc        = vpcTags("VPC")
+	VpcTagResourceVSwitch    = vpcTags("VSWITCH")
+	VpcTagResourceEip        = vpcTags("EIP")
+	VpcTagResourceNatGateway = vpcTags("NATGATEWAY")
+)
+
+type TagResourcesArgs struct {
+	RegionId     common.Region
+	ResourceType string
+	ResourceId   []string `query:"list"`
+	Tag          []Tag
+}
+
+type UntagResourcesArgs struct {
+	RegionId     common.Region
+	ResourceType string
+	ResourceId   []string `query:"list"`
+	TagKey       []string `query:"list"`
+}
+
+type ListTagResourcesArgs struct {
+	RegionId     common.Region
+	ResourceType string
+	ResourceId   []string `query:"list"`
+	NextToken    string
+}
+
+type TagResourceType struct {
+	ResourceId   string
+	ResourceType string
+	TagKey       string
+	TagValue     string
+}
+
+type ListTagResourcesResponse struct {
+	common.Response
+	NextToken    string
+	TagResources struct {
+		TagResource []TagResourceType
+	}
+}
+
+// vpcTags tags VPCs, VSwitches, EIPs and NAT gateways by the VPC tag API.
+type vpcTags string
+
+func (t vpcTags) addTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	args := &TagResourcesArgs{
+		RegionId:     client.Region,
+		ResourceType: string(t),
+		ResourceId:   []string{resourceId},
+		Tag:          tags,
+	}
+	return client.vpcConn().Invoke("TagResources", args, &common.Response{})
+}
+
+func (t vpcTags) removeTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	args := &UntagResourcesArgs{
+		RegionId:     client.Region,
+		ResourceType: string(t),
+		ResourceId:   []string{resourceId},
+	}
+	for _, tag := range tags {
+		args.TagKey = append(args.TagKey, tag.Key)
+	}
+	return client.vpcConn().Invoke("UntagResources", args, &common.Response{})
+}
+
+func (t vpcTags) describeTags(client *AliyunClient, resourceId string) ([]Tag, error) {
+	args := &ListTagResourcesArgs{
+		RegionId:     client.Region,
+		ResourceType: string(t),
+		ResourceId:   []string{resourceId},
+	}
+
+	var tags []Tag
+	for {
+		var response ListTagResourcesResponse
+		err := client.vpcConn().Invoke("ListTagResources", args, &response)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range response.TagResources.TagResource {
+			tags = append(tags, Tag{Key: item.TagKey, Value: item.TagValue})
+		}
+		if response.NextToken == "" {
+			return tags, nil
+		}
+		args.NextToken = response.NextToken
+	}
+}
+
+// slbTags tags load balancers by the SLB tag API.
+type slbTags struct{}
+
+// slbTagsJson encodes the tags in the form of the SLB tag API: [{"TagKey":"key","TagValue":"value"}].
+func slbTagsJson(tags []Tag) (string, error) {
+	var items []slb.TagItem
+	for _, tag := range tags {
+		items = append(items, slb.TagItem{TagKey: tag.Key, TagValue: tag.Value})
+	}
+	b, err := json.Marshal(items)
+	return string(b), err
+}
+
+func (slbTags) addTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	value, err := slbTagsJson(tags)
+	if err != nil {
+		return err
+	}
+	return client.slbConn().AddTags(&slb.AddTagsArgs{
+		RegionId:       client.Region,
+		LoadBalancerID: resourceId,
+		Tags:           value,
+	})
+}
+
+func (slbTags) removeTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	value, err := slbTagsJson(tags)
+	if err != nil {
+		return err
+	}
+	return client.slbConn().RemoveTags(&slb.RemoveTagsArgs{
+		RegionId:       client.Region,
+		LoadBalancerID: resourceId,
+		Tags:           value,
+	})
+}
+
+func (slbTags) describeTags(client *AliyunClient, resourceId string) ([]Tag, error) {
+	args := &slb.DescribeTagsArgs{
+		RegionId:       client.Region,
+		LoadBalancerID: resourceId,
+		Pagination:     getPagination(1, 50),
+	}
+
+	var tags []Tag
+	for {
+		items, pagination, err := client.slbConn().DescribeTags(args)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			tags = append(tags, Tag{Key: item.TagKey, Value: item.TagValue})
+		}
+		next := pagination.NextPage()
+		if next == nil {
+			return tags, nil
+		}
+		args.Pagination = *next
+	}
+}
+
+type AddTagsToResourceArgs struct {
+	RegionId     common.Region
+	DBInstanceId string
+	Tags         string
+}
+
+type DescribeDBTagsArgs struct {
+	RegionId     common.Region
+	DBInstanceId string
+}
+
+type DescribeDBTagsResponse struct {
+	common.Response
+	Items struct {
+		TagInfos []struct {
+			TagKey   string
+			TagValue string
+		}
+	}
+}
+
+// rdsTags tags DB instances by the RDS tag API.
+type rdsTags struct{}
+
+func (rdsTags) addTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	// the tags are in the form of {"key":"value"}
+	m := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		m[tag.Key] = tag.Value
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	args := &AddTagsToResourceArgs{
+		RegionId:     client.Region,
+		DBInstanceId: resourceId,
+		Tags:         string(b),
+	}
+	return client.rdsConn().Invoke("AddTagsToResource", args, &common.Response{})
+}
+
+func (rdsTags) removeTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	// the tags are in the form of Tag.N.key and Tag.N.value, which the SDK cannot build from a struct
+	args := url.Values{}
+	args.Set("RegionId", string(client.Region))
+	args.Set("DBInstanceId", resourceId)
+	for i, tag := range tags {
+		args.Set(fmt.Sprintf("Tag.%d.key", i+1), tag.Key)
+		args.Set(fmt.Sprintf("Tag.%d.value", i+1), tag.Value)
+	}
+	return client.rdsConn().Invoke("RemoveTagsFromResource", args, &common.Response{})
+}
+
+func (rdsTags) describeTags(client *AliyunClient, resourceId string) ([]Tag, error) {
+	args := &DescribeDBTagsArgs{
+		RegionId:     client.Region,
+		DBInstanceId: resourceId,
+	}
+	var response DescribeDBTagsResponse
+	err := client.rdsConn().Invoke("DescribeTags", args, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, item := range response.Items.TagInfos {
+		tags = append(tags, Tag{Key: item.TagKey, Value: item.TagValue})
+	}
+	return tags, nil
+}
+
+type ossTagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Tags    []Tag    `xml:"TagSet>Tag"`
+}
+
+// ossTags tags OSS buckets by the bucket tagging API. The API replaces all the tags of a bucket at once,
+// so adding and removing tags update the bucket's current ones.
+type ossTags struct{}
+
+func (t ossTags) addTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	current, err := t.describeTags(client, resourceId)
+	if err != nil {
+		return err
+	}
+	m := make(map[string]interface{})
+	for _, tag := range current {
+		m[tag.Key] = tag.Value
+	}
+	for _, tag := range tags {
+		m[tag.Key] = tag.Value
+	}
+	return t.putTags(client, resourceId, tagsFromMap(m))
+}
+
+func (t ossTags) removeTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	current, err := t.describeTags(client, resourceId)
+	if err != nil {
+		return err
+	}
+	m := make(map[string]interface{})
+	for _, tag := range current {
+		m[tag.Key] = tag.Value
+	}
+	for _, tag := range tags {
+		delete(m, tag.Key)
+	}
+	return t.putTags(client, resourceId, tagsFromMap(m))
+}
+
+func (ossTags) describeTags(client *AliyunClient, resourceId string) ([]Tag, error) {
+	var tagging ossTagging
+	err := doOssBucketTagging(client, "GET", resourceId, nil, func(resp *oss.Response) error {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return xml.Unmarshal(body, &tagging)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tagging.Tags, nil
+}
+
+func (ossTags) putTags(client *AliyunClient, resourceId string, tags []Tag) error {
+	if len(tags) == 0 {
+		return doOssBucketTagging(client, "DELETE", resourceId, nil, nil)
+	}
+	body, err := xml.Marshal(ossTagging{Tags: tags})
+	if err != nil {
+		return err
+	}
+	return doOssBucketTagging(client, "PUT", resourceId, body, nil)
+}
+
+// doOssBucketTagging sends a request to the tagging API of the bucket, which the OSS SDK doesn't cover,
+// and handles the response by f if it is not nil.
+func doOssBucketTagging(client *AliyunClient, method, bucket string, body []byte, f func(*oss.Response) error) error {
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
+	params := map[string]interface{}{"tagging": nil}
+	headers := map[string]string{}
+	if body != nil {
+		headers[oss.HTTPHeaderContentType] = "application/xml"
+	}
+
+	resp, err := ossconn.Conn.Do(method, bucket, "", params, headers, bytes.NewReader(body), 0, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if f != nil {
+		return f(resp)
+	}
+	return nil
+}
@@ -0,0 +1,973 @@
+package alicloud
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+)
+
+// The resources which the fake ECS has to start with.
+const (
+	fakeZoneId          = "cn-beijing-a"
+	fakeInstanceType    = "ecs.n4.large"
+	fakeInstanceTypeUp  = "ecs.n4.xlarge"
+	fakeSecurityGroupId = "sg-fake"
+	fakeVSwitchId       = "vsw-fake"
+)
+
+func (s *fakeServer) seedEcs() {
+	s.regions = []ecs.RegionType{
+		{RegionId: common.Beijing, LocalName: "China North 2 (Beijing)"},
+		{RegionId: common.Shanghai, LocalName: "China East 2 (Shanghai)"},
+	}
+	s.zones = []ecs.ZoneType{{
+		ZoneId:                    fakeZoneId,
+		AvailableInstanceTypes:    ecs.AvailableInstanceTypesType{InstanceTypes: []string{fakeInstanceType, fakeInstanceTypeUp}},
+		AvailableResourceCreation: ecs.AvailableResourceCreationType{ResourceTypes: []ecs.ResourceType{ecs.ResourceTypeInstance, ecs.ResourceTypeDisk, ecs.ResourceTypeVSwitch}},
+		AvailableDiskCategories:   ecs.AvailableDiskCategoriesType{DiskCategories: []ecs.DiskCategory{ecs.DiskCategoryCloudEfficiency, ecs.DiskCategoryCloudSSD}},
+		AvailableResources: ecs.ResourcesInfoType{ResourcesInfo: []ecs.AvailableResourcesType{{
+			IoOptimized:          true,
+			InstanceTypeFamilies: map[ecs.SupportedResourceType][]string{ecs.SupportedInstanceTypeFamily: {"ecs.n4"}},
+			InstanceTypes:        map[ecs.SupportedResourceType][]string{ecs.SupportedInstanceType: {fakeInstanceType, fakeInstanceTypeUp}},
+		}}},
+	}}
+	s.families = []ecs.InstanceTypeFamily{{InstanceTypeFamilyId: "ecs.n4", Generation: "ecs-3"}}
+	s.instanceTypes = []ecs.InstanceTypeItemType{{
+		InstanceTypeId:     fakeInstanceType,
+		InstanceTypeFamily: "ecs.n4",
+		CpuCoreCount:       2,
+		MemorySize:         4,
+	}, {
+		InstanceTypeId:     fakeInstanceTypeUp,
+		InstanceTypeFamily: "ecs.n4",
+		CpuCoreCount:       4,
+		MemorySize:         8,
+	}, {
+		// The type of the family which isn't available in the zone.
+		InstanceTypeId:     "ecs.n4.2xlarge",
+		InstanceTypeFamily: "ecs.n4",
+		CpuCoreCount:       8,
+		MemorySize:         16,
+	}}
+	s.securityGroups[fakeSecurityGroupId] = &ecs.DescribeSecurityGroupAttributeResponse{
+		SecurityGroupId: fakeSecurityGroupId,
+		RegionId:        common.Beijing,
+	}
+}
+
+// fakeDisk encodes the disk with its automatic snapshot policy, which ecs.DiskItemType lacks.
+type fakeDisk struct {
+	ecs.DiskItemType
+	AutoSnapshotPolicyId string
+}
+
+// fakeInstance encodes the instance like the API does, since the SDK can't decode IoOptimized as it encodes it.
+type fakeInstance struct {
+	ecs.InstanceAttributesType
+	IoOptimized bool
+}
+
+func (s *fakeServer) registerEcs() {
+	s.handle("DescribeRegions", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeRegionsResponse{}
+		response.Regions.Region = s.regions
+		return response, nil
+	}, EcsCode)
+
+	s.handle("DescribeZones", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeZonesResponse{}
+		response.Zones.Zone = s.zones
+		return response, nil
+	}, EcsCode)
+
+	s.handle("DescribeInstanceTypeFamilies", func(params url.Values) (interface{}, error) {
+		return ecs.DescribeInstanceTypeFamiliesResponse{
+			InstanceTypeFamilies: ecs.InstanceTypeFamilies{InstanceTypeFamily: s.families},
+		}, nil
+	}, EcsCode)
+
+	s.handle("DescribeInstanceTypes", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeInstanceTypesResponse{}
+		for _, t := range s.instanceTypes {
+			if family := params.Get("InstanceTypeFamily"); family == "" || family == t.InstanceTypeFamily {
+				response.InstanceTypes.InstanceType = append(response.InstanceTypes.InstanceType, t)
+			}
+		}
+		return response, nil
+	}, EcsCode)
+
+	s.handle("DescribeSecurityGroupAttribute", func(params url.Values) (interface{}, error) {
+		group, ok := s.securityGroups[params.Get("SecurityGroupId")]
+		if !ok {
+			return nil, fakeNotFound(InvalidSecurityGroupIdNotFound, "The specified security group does not exist.")
+		}
+		response := *group
+		response.Permissions.Permission = nil
+		for _, permission := range group.Permissions.Permission {
+			if direction := params.Get("Direction"); direction == "" || direction == permission.Direction {
+				response.Permissions.Permission = append(response.Permissions.Permission, permission)
+			}
+		}
+		return response, nil
+	}, EcsCode)
+
+	s.handle("AuthorizeSecurityGroup", func(params url.Values) (interface{}, error) {
+		group, ok := s.securityGroups[params.Get("SecurityGroupId")]
+		if !ok {
+			return nil, fakeNotFound(InvalidSecurityGroupIdNotFound, "The specified security group does not exist.")
+		}
+		permission := ecs.PermissionType{Direction: string(ecs.DirectionIngress)}
+		decodeFakeParams(params, &permission)
+		return common.Response{}, s.changeChild(group.SecurityGroupId, func() {
+			group.Permissions.Permission = append(group.Permissions.Permission, permission)
+		})
+	}, EcsCode)
+
+	s.handle("RevokeSecurityGroup", func(params url.Values) (interface{}, error) {
+		group, ok := s.securityGroups[params.Get("SecurityGroupId")]
+		if !ok {
+			return nil, fakeNotFound(InvalidSecurityGroupIdNotFound, "The specified security group does not exist.")
+		}
+		revoked := ecs.PermissionType{Direction: string(ecs.DirectionIngress)}
+		decodeFakeParams(params, &revoked)
+		return common.Response{}, s.changeChild(group.SecurityGroupId, func() {
+			var permissions []ecs.PermissionType
+			for _, permission := range group.Permissions.Permission {
+				if permission.Direction != revoked.Direction || permission.IpProtocol != revoked.IpProtocol ||
+					permission.PortRange != revoked.PortRange || permission.SourceCidrIp != revoked.SourceCidrIp {
+					permissions = append(permissions, permission)
+				}
+			}
+			group.Permissions.Permission = permissions
+		})
+	}, EcsCode)
+
+	s.handle("CreateInstance", s.createInstance, EcsCode)
+
+	s.handle("DescribeInstanceAttribute", func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		return fakeInstance{InstanceAttributesType: *instance, IoOptimized: true}, nil
+	}, EcsCode)
+
+	s.handle("DescribeInstances", func(params url.Values) (interface{}, error) {
+		response := struct {
+			common.Response
+			common.PaginationResult
+			Instances struct {
+				Instance []fakeInstance
+			}
+		}{}
+		for _, id := range fakeJSONListParam(params, "InstanceIds") {
+			if instance, ok := s.instances[id]; ok {
+				response.Instances.Instance = append(response.Instances.Instance, fakeInstance{InstanceAttributesType: *instance, IoOptimized: true})
+			}
+		}
+		response.TotalCount = len(response.Instances.Instance)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode)
+
+	s.handle("StartInstance", s.changeInstanceStatus(ecs.Stopped, ecs.Running), EcsCode)
+	s.handle("StopInstance", s.changeInstanceStatus(ecs.Running, ecs.Stopped), EcsCode)
+
+	s.handle("ModifyInstanceSpec", s.modifyInstanceSpec(common.PostPaid), EcsCode)
+	s.handle("ModifyPrepayInstanceSpec", s.modifyInstanceSpec(common.PrePaid), EcsCode)
+
+	s.handle("ReplaceSystemDisk", func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		if instance.Status != ecs.Stopped {
+			return nil, fakeForbidden(InstanceIncorrectStatus, "The current status of the instance does not support this operation.")
+		}
+		for id, disk := range s.disks {
+			if disk.InstanceId != instance.InstanceId || disk.Type != ecs.DiskTypeAllSystem {
+				continue
+			}
+			delete(s.disks, id)
+			disk.DiskId, disk.ImageId = s.newId("d"), params.Get("ImageId")
+			if size, _ := strconv.Atoi(params.Get("SystemDisk.Size")); size > 0 {
+				disk.Size = size
+			}
+			s.disks[disk.DiskId] = disk
+			instance.ImageId = disk.ImageId
+			return ecs.ReplaceSystemDiskResponse{DiskId: disk.DiskId}, nil
+		}
+		return nil, fakeNotFound(SystemDiskNotFound, "The system disk of the instance does not exist.")
+	}, EcsCode)
+
+	s.handle("ModifyInstanceAttribute", func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := params["InstanceName"]; ok {
+			instance.InstanceName = v[0]
+		}
+		if v, ok := params["Description"]; ok {
+			instance.Description = v[0]
+		}
+		if v, ok := params["HostName"]; ok {
+			instance.HostName = v[0]
+		}
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("JoinSecurityGroup", func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		id := params.Get("SecurityGroupId")
+		if _, ok := s.securityGroups[id]; !ok {
+			return nil, fakeNotFound(InvalidSecurityGroupIdNotFound, "The specified security group does not exist.")
+		}
+		for _, joined := range instance.SecurityGroupIds.SecurityGroupId {
+			if joined == id {
+				return nil, fakeBadRequest(InvalidInstanceIdAlreadyExists, "The instance already joined the security group.")
+			}
+		}
+		instance.SecurityGroupIds.SecurityGroupId = append(instance.SecurityGroupIds.SecurityGroupId, id)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("LeaveSecurityGroup", func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		var groups []string
+		for _, joined := range instance.SecurityGroupIds.SecurityGroupId {
+			if joined != params.Get("SecurityGroupId") {
+				groups = append(groups, joined)
+			}
+		}
+		if len(groups) == len(instance.SecurityGroupIds.SecurityGroupId) {
+			return nil, fakeNotFound(InvalidSecurityGroupIdNotFound, "The instance isn't in the security group.")
+		}
+		instance.SecurityGroupIds.SecurityGroupId = groups
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("DescribeDisks", func(params url.Values) (interface{}, error) {
+		response := struct {
+			common.Response
+			common.PaginationResult
+			Disks struct {
+				Disk []fakeDisk
+			}
+		}{}
+		ids := fakeJSONListParam(params, "DiskIds")
+		for _, disk := range s.disks {
+			if id := params.Get("InstanceId"); id != "" && id != disk.InstanceId {
+				continue
+			}
+			if t := params.Get("DiskType"); t != "" && t != string(ecs.DiskTypeAll) && t != string(disk.Type) {
+				continue
+			}
+			if len(ids) > 0 && !fakeContains(ids, disk.DiskId) {
+				continue
+			}
+			policy := s.diskPolicies[disk.DiskId]
+			if id := params.Get("AutoSnapshotPolicyId"); id != "" && id != policy {
+				continue
+			}
+			response.Disks.Disk = append(response.Disks.Disk, fakeDisk{DiskItemType: *disk, AutoSnapshotPolicyId: policy})
+		}
+		response.TotalCount = len(response.Disks.Disk)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode)
+
+	s.handle("DeleteInstance", func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		if instance.Status != ecs.Stopped {
+			return nil, fakeForbidden(InstanceIncorrectStatus, "The current status of the instance does not support this operation.")
+		}
+		delete(s.instances, instance.InstanceId)
+		for id, disk := range s.disks {
+			if disk.InstanceId != instance.InstanceId {
+				continue
+			}
+			if disk.DeleteWithInstance {
+				delete(s.disks, id)
+			} else {
+				disk.InstanceId, disk.Device, disk.Status = "", "", ecs.DiskStatusAvailable
+			}
+		}
+		delete(s.tags, instance.InstanceId)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("DescribeTags", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeTagsResponse{}
+		for key, value := range s.tags[params.Get("ResourceId")] {
+			response.Tags.Tag = append(response.Tags.Tag, ecs.TagItemType{TagKey: key, TagValue: value})
+		}
+		response.TotalCount = len(response.Tags.Tag)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode)
+
+	s.handle("AddTags", func(params url.Values) (interface{}, error) {
+		s.tagResource(params.Get("ResourceId"), fakeListParams(params, "Tag.%d.Key"), fakeListParams(params, "Tag.%d.Value"))
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("RemoveTags", func(params url.Values) (interface{}, error) {
+		s.untagResource(params.Get("ResourceId"), fakeListParams(params, "Tag.%d.Key"))
+		return common.Response{}, nil
+	}, EcsCode)
+}
+
+func (s *fakeServer) instance(params url.Values) (*ecs.InstanceAttributesType, error) {
+	instance, ok := s.instances[params.Get("InstanceId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidInstanceId.NotFound", "The specified InstanceId does not exist.")
+	}
+	return instance, nil
+}
+
+func (s *fakeServer) createInstance(params url.Values) (interface{}, error) {
+	instanceType := params.Get("InstanceType")
+	found := false
+	for _, t := range s.instanceTypes {
+		found = found || t.InstanceTypeId == instanceType
+	}
+	if !found {
+		return nil, fakeBadRequest("InvalidInstanceType.ValueNotSupported", "The specified instance type %s is not supported.", instanceType)
+	}
+	if params.Get("ImageId") == "" {
+		return nil, fakeBadRequest("MissingImageId", "ImageId is mandatory for this action.")
+	}
+	group := params.Get("SecurityGroupId")
+	if _, ok := s.securityGroups[group]; !ok {
+		return nil, fakeNotFound(InvalidSecurityGroupIdNotFound, "The specified security group does not exist.")
+	}
+
+	// The data disks of the image are made from its snapshots ahead of the ones of the arguments.
+	var dataDisks []*ecs.DiskItemType
+	if image, ok := s.images[params.Get("ImageId")]; ok {
+		for _, mapping := range image.DiskDeviceMappings.DiskDeviceMapping[1:] {
+			size, _ := strconv.Atoi(mapping.Size)
+			dataDisks = append(dataDisks, &ecs.DiskItemType{
+				DiskId:             s.newId("d"),
+				RegionId:           common.Beijing,
+				Type:               ecs.DiskTypeAllData,
+				Category:           ecs.DiskCategoryCloudEfficiency,
+				Size:               size,
+				SourceSnapshotId:   mapping.SnapshotId,
+				Status:             ecs.DiskStatusInUse,
+				Device:             fmt.Sprintf("/dev/xvd%c", 'b'+len(dataDisks)),
+				DeleteWithInstance: true,
+			})
+		}
+	}
+	// The encryption of each data disk is always encoded, so it tells how many there are.
+	for i := range fakeListParams(params, "DataDisk.%d.Encrypted") {
+		prefix := fmt.Sprintf("DataDisk.%d.", i+1)
+		size, _ := strconv.Atoi(params.Get(prefix + "Size"))
+		if id := params.Get(prefix + "SnapshotId"); id != "" {
+			snapshot, err := s.snapshot(id)
+			if err != nil {
+				return nil, err
+			}
+			if size == 0 {
+				size = snapshot.SourceDiskSize
+			}
+		}
+		dataDisks = append(dataDisks, &ecs.DiskItemType{
+			DiskId:             s.newId("d"),
+			RegionId:           common.Beijing,
+			DiskName:           params.Get(prefix + "DiskName"),
+			Description:        params.Get(prefix + "Description"),
+			Type:               ecs.DiskTypeAllData,
+			Category:           ecs.DiskCategory(params.Get(prefix + "Category")),
+			Size:               size,
+			SourceSnapshotId:   params.Get(prefix + "SnapshotId"),
+			Status:             ecs.DiskStatusInUse,
+			Device:             fmt.Sprintf("/dev/xvd%c", 'b'+len(dataDisks)),
+			Encrypted:          params.Get(prefix+"Encrypted") == "true",
+			DeleteWithInstance: params.Get(prefix+"DeleteWithInstance") == "true",
+		})
+	}
+
+	instance := &ecs.InstanceAttributesType{
+		InstanceId:         s.newId("i"),
+		RegionId:           common.Beijing,
+		ZoneId:             fakeZoneId,
+		InstanceType:       instanceType,
+		ImageId:            params.Get("ImageId"),
+		InstanceName:       params.Get("InstanceName"),
+		Description:        params.Get("Description"),
+		HostName:           params.Get("HostName"),
+		Status:             ecs.Stopped,
+		InternetChargeType: common.PayByTraffic,
+		InstanceChargeType: common.PostPaid,
+		SpotStrategy:       ecs.NoSpot,
+		KeyPairName:        params.Get("KeyPairName"),
+	}
+	decodeFakeParams(params, instance)
+	instance.SecurityGroupIds.SecurityGroupId = []string{group}
+	if instance.ZoneId == "" {
+		instance.ZoneId = fakeZoneId
+	}
+	if instance.InstanceName == "" {
+		instance.InstanceName = instance.InstanceId
+	}
+	if instance.HostName == "" {
+		instance.HostName = "iZ" + strings.TrimPrefix(instance.InstanceId, "i-") + "Z"
+	}
+	if vswitch := params.Get("VSwitchId"); vswitch != "" {
+		instance.VpcAttributes.VSwitchId = vswitch
+		instance.VpcAttributes.PrivateIpAddress.IpAddress = []string{"172.16.0.10"}
+	} else {
+		instance.InnerIpAddress.IpAddress = []string{"10.0.0.10"}
+	}
+	s.instances[instance.InstanceId] = instance
+
+	category := ecs.DiskCategory(params.Get("SystemDisk.Category"))
+	if category == "" {
+		category = ecs.DiskCategoryCloudEfficiency
+	}
+	size, _ := strconv.Atoi(params.Get("SystemDisk.Size"))
+	if size == 0 {
+		size = 40
+	}
+	disk := &ecs.DiskItemType{
+		DiskId:             s.newId("d"),
+		RegionId:           common.Beijing,
+		ZoneId:             instance.ZoneId,
+		Type:               ecs.DiskTypeAllSystem,
+		Category:           category,
+		Size:               size,
+		ImageId:            instance.ImageId,
+		Status:             ecs.DiskStatusInUse,
+		InstanceId:         instance.InstanceId,
+		Device:             "/dev/xvda",
+		DeleteWithInstance: true,
+	}
+	s.disks[disk.DiskId] = disk
+
+	for _, disk := range dataDisks {
+		disk.ZoneId, disk.InstanceId = instance.ZoneId, instance.InstanceId
+		s.disks[disk.DiskId] = disk
+	}
+
+	return ecs.CreateInstanceResponse{InstanceId: instance.InstanceId}, nil
+}
+
+// changeInstanceStatus returns the handler which changes the status of the instance from the one to the other.
+func (s *fakeServer) changeInstanceStatus(from, to ecs.InstanceStatus) fakeHandler {
+	return func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		if instance.Status != from && instance.Status != to {
+			return nil, fakeForbidden(InstanceIncorrectStatus, "The current status of the instance does not support this operation.")
+		}
+		instance.Status = to
+		return common.Response{}, nil
+	}
+}
+
+// modifyInstanceSpec returns the handler which changes the instance type of a stopped instance of the charge type.
+func (s *fakeServer) modifyInstanceSpec(chargeType common.InstanceChargeType) fakeHandler {
+	return func(params url.Values) (interface{}, error) {
+		instance, err := s.instance(params)
+		if err != nil {
+			return nil, err
+		}
+		if instance.InstanceChargeType != chargeType {
+			return nil, fakeForbidden("InvalidInstanceChargeType.NotSupported", "The charge type of the instance does not support this operation.")
+		}
+		if instance.Status != ecs.Stopped {
+			return nil, fakeForbidden(InstanceIncorrectStatus, "The current status of the instance does not support this operation.")
+		}
+		instanceType := params.Get("InstanceType")
+		if !fakeContains(s.zones[0].AvailableInstanceTypes.InstanceTypes, instanceType) {
+			return nil, fakeBadRequest("InvalidInstanceType.ValueNotSupported", "The specified instance type %s is not supported.", instanceType)
+		}
+		instance.InstanceType = instanceType
+		return common.Response{}, nil
+	}
+}
+
+func (s *fakeServer) tagResource(id string, keys, values []string) {
+	if s.tags[id] == nil {
+		s.tags[id] = make(map[string]string)
+	}
+	for i, key := range keys {
+		if i < len(values) {
+			s.tags[id][key] = values[i]
+		}
+	}
+}
+
+func (s *fakeServer) untagResource(id string, keys []string) {
+	for _, key := range keys {
+		delete(s.tags[id], key)
+	}
+}
+
+// registerSnapshots registers the actions of the disks, the snapshots and the automatic snapshot policies.
+func (s *fakeServer) registerSnapshots() {
+	s.handle("CreateDisk", func(params url.Values) (interface{}, error) {
+		size, _ := strconv.Atoi(params.Get("Size"))
+		disk := &ecs.DiskItemType{
+			DiskId:      s.newId("d"),
+			RegionId:    common.Beijing,
+			ZoneId:      params.Get("ZoneId"),
+			DiskName:    params.Get("DiskName"),
+			Description: params.Get("Description"),
+			Type:        ecs.DiskTypeAllData,
+			Category:    ecs.DiskCategory(params.Get("DiskCategory")),
+			Size:        size,
+			Status:      ecs.DiskStatusAvailable,
+		}
+		if id := params.Get("SnapshotId"); id != "" {
+			snapshot, ok := s.snapshots[id]
+			if !ok {
+				return nil, fakeNotFound("InvalidSnapshotId.NotFound", "The specified snapshot does not exist.")
+			}
+			disk.SourceSnapshotId = id
+			if disk.Size == 0 {
+				disk.Size = snapshot.SourceDiskSize
+			}
+		}
+		s.disks[disk.DiskId] = disk
+		return ecs.CreateDisksResponse{DiskId: disk.DiskId}, nil
+	}, EcsCode)
+
+	s.handle("ModifyDiskAttribute", func(params url.Values) (interface{}, error) {
+		disk, err := s.disk(params.Get("DiskId"))
+		if err != nil {
+			return nil, err
+		}
+		decodeFakeParams(params, disk)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("ResizeDisk", func(params url.Values) (interface{}, error) {
+		disk, err := s.disk(params.Get("DiskId"))
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(params.Get("NewSize"))
+		if size <= disk.Size {
+			return nil, fakeBadRequest("InvalidDiskSize.TooSmall", "The new size %d must be larger than the current size %d.", size, disk.Size)
+		}
+		if ResizeDiskType(params.Get("Type")) == ResizeDiskOnline {
+			if disk.Category == ecs.DiskCategoryCloud {
+				return nil, fakeForbidden("InvalidDiskCategory.NotSupported", "The basic cloud disk can't be resized online.")
+			}
+			if instance, ok := s.instances[disk.InstanceId]; !ok || instance.Status != ecs.Running {
+				return nil, fakeForbidden(InstanceIncorrectStatus, "The disk can only be resized online when its instance is running.")
+			}
+		}
+		disk.Size = size
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("DeleteDisk", func(params url.Values) (interface{}, error) {
+		disk, err := s.disk(params.Get("DiskId"))
+		if err != nil {
+			return nil, err
+		}
+		if disk.Status != ecs.DiskStatusAvailable {
+			return nil, fakeForbidden(DiskIncorrectStatus, "The current status of the disk does not support this operation.")
+		}
+		delete(s.disks, disk.DiskId)
+		delete(s.diskPolicies, disk.DiskId)
+		delete(s.tags, disk.DiskId)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("CreateSnapshot", func(params url.Values) (interface{}, error) {
+		disk, err := s.disk(params.Get("DiskId"))
+		if err != nil {
+			return nil, err
+		}
+		if len(s.snapshotErrors) > 0 {
+			code := s.snapshotErrors[0]
+			s.snapshotErrors = s.snapshotErrors[1:]
+			return nil, fakeForbidden(code, "The disk %s can't take a snapshot now.", disk.DiskId)
+		}
+		snapshot := &ecs.SnapshotType{
+			SnapshotId:     s.newId("s"),
+			SnapshotName:   params.Get("SnapshotName"),
+			Description:    params.Get("Description"),
+			Progress:       "100%",
+			SourceDiskId:   disk.DiskId,
+			SourceDiskSize: disk.Size,
+			SourceDiskType: "Data",
+			Status:         "accomplished",
+		}
+		if disk.Type == ecs.DiskTypeAllSystem {
+			snapshot.SourceDiskType = "System"
+		}
+		s.snapshots[snapshot.SnapshotId] = snapshot
+		return ecs.CreateSnapshotResponse{SnapshotId: snapshot.SnapshotId}, nil
+	}, EcsCode)
+
+	s.handle("DescribeSnapshots", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeSnapshotsResponse{}
+		ids := fakeJSONListParam(params, "SnapshotIds")
+		for _, snapshot := range s.snapshots {
+			if len(ids) > 0 && !fakeContains(ids, snapshot.SnapshotId) {
+				continue
+			}
+			if id := params.Get("DiskId"); id != "" && id != snapshot.SourceDiskId {
+				continue
+			}
+			response.Snapshots.Snapshot = append(response.Snapshots.Snapshot, *snapshot)
+		}
+		response.TotalCount = len(response.Snapshots.Snapshot)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode)
+
+	s.handle("ModifySnapshotAttribute", func(params url.Values) (interface{}, error) {
+		snapshot, err := s.snapshot(params.Get("SnapshotId"))
+		if err != nil {
+			return nil, err
+		}
+		decodeFakeParams(params, snapshot)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("DeleteSnapshot", func(params url.Values) (interface{}, error) {
+		snapshot, err := s.snapshot(params.Get("SnapshotId"))
+		if err != nil {
+			return nil, err
+		}
+		for _, disk := range s.disks {
+			if disk.SourceSnapshotId == snapshot.SnapshotId {
+				return nil, fakeForbidden("SnapshotCreatedDisk", "The snapshot has been used to create disks.")
+			}
+		}
+		delete(s.snapshots, snapshot.SnapshotId)
+		delete(s.tags, snapshot.SnapshotId)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("CreateAutoSnapshotPolicy", func(params url.Values) (interface{}, error) {
+		policy := &AutoSnapshotPolicyType{
+			AutoSnapshotPolicyId: s.newId("sp"),
+			RegionId:             common.Beijing,
+			Status:               "Normal",
+		}
+		if err := s.setPolicy(policy, params); err != nil {
+			return nil, err
+		}
+		if policy.AutoSnapshotPolicyName == "" {
+			policy.AutoSnapshotPolicyName = policy.AutoSnapshotPolicyId
+		}
+		s.policies[policy.AutoSnapshotPolicyId] = policy
+		return CreateAutoSnapshotPolicyResponse{AutoSnapshotPolicyId: policy.AutoSnapshotPolicyId}, nil
+	}, EcsCode)
+
+	s.handle("DescribeAutoSnapshotPolicyEx", func(params url.Values) (interface{}, error) {
+		response := DescribeAutoSnapshotPolicyExResponse{}
+		for _, policy := range s.policies {
+			if id := params.Get("AutoSnapshotPolicyId"); id != "" && id != policy.AutoSnapshotPolicyId {
+				continue
+			}
+			p := *policy
+			for _, applied := range s.diskPolicies {
+				if applied == p.AutoSnapshotPolicyId {
+					p.DiskNums++
+				}
+			}
+			response.AutoSnapshotPolicies.AutoSnapshotPolicy = append(response.AutoSnapshotPolicies.AutoSnapshotPolicy, p)
+		}
+		response.TotalCount = len(response.AutoSnapshotPolicies.AutoSnapshotPolicy)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode)
+
+	s.handle("ModifyAutoSnapshotPolicyEx", func(params url.Values) (interface{}, error) {
+		policy, err := s.policy(params.Get("autoSnapshotPolicyId"))
+		if err != nil {
+			return nil, err
+		}
+		return common.Response{}, s.setPolicy(policy, params)
+	}, EcsCode)
+
+	s.handle("DeleteAutoSnapshotPolicy", func(params url.Values) (interface{}, error) {
+		policy, err := s.policy(params.Get("autoSnapshotPolicyId"))
+		if err != nil {
+			return nil, err
+		}
+		for _, applied := range s.diskPolicies {
+			if applied == policy.AutoSnapshotPolicyId {
+				return nil, fakeForbidden("IncorrectAutoSnapshotPolicyStatus", "The policy is still applied to disks.")
+			}
+		}
+		delete(s.policies, policy.AutoSnapshotPolicyId)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("ApplyAutoSnapshotPolicy", func(params url.Values) (interface{}, error) {
+		policy, err := s.policy(params.Get("autoSnapshotPolicyId"))
+		if err != nil {
+			return nil, err
+		}
+		ids := fakeJSONListParam(params, "diskIds")
+		for _, id := range ids {
+			if _, err := s.disk(id); err != nil {
+				return nil, err
+			}
+		}
+		for _, id := range ids {
+			s.diskPolicies[id] = policy.AutoSnapshotPolicyId
+		}
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("CancelAutoSnapshotPolicy", func(params url.Values) (interface{}, error) {
+		for _, id := range fakeJSONListParam(params, "diskIds") {
+			delete(s.diskPolicies, id)
+		}
+		return common.Response{}, nil
+	}, EcsCode)
+}
+
+func (s *fakeServer) disk(id string) (*ecs.DiskItemType, error) {
+	disk, ok := s.disks[id]
+	if !ok {
+		return nil, fakeNotFound("InvalidDiskId.NotFound", "The specified disk does not exist.")
+	}
+	return disk, nil
+}
+
+func (s *fakeServer) snapshot(id string) (*ecs.SnapshotType, error) {
+	snapshot, ok := s.snapshots[id]
+	if !ok {
+		return nil, fakeNotFound("InvalidSnapshotId.NotFound", "The specified snapshot does not exist.")
+	}
+	return snapshot, nil
+}
+
+func (s *fakeServer) policy(id string) (*AutoSnapshotPolicyType, error) {
+	policy, ok := s.policies[id]
+	if !ok {
+		return nil, fakeNotFound("InvalidAutoSnapshotPolicyId.NotFound", "The specified automatic snapshot policy does not exist.")
+	}
+	return policy, nil
+}
+
+// setPolicy sets the policy from the parameters, which start in lower case.
+func (s *fakeServer) setPolicy(policy *AutoSnapshotPolicyType, params url.Values) error {
+	if name := params.Get("autoSnapshotPolicyName"); name != "" {
+		policy.AutoSnapshotPolicyName = name
+	}
+	if len(fakeJSONListParam(params, "repeatWeekdays")) == 0 || len(fakeJSONListParam(params, "timePoints")) == 0 {
+		return fakeBadRequest("InvalidParameter", "The repeat weekdays and the time points are mandatory.")
+	}
+	policy.RepeatWeekdays = params.Get("repeatWeekdays")
+	policy.TimePoints = params.Get("timePoints")
+	policy.RetentionDays, _ = strconv.Atoi(params.Get("retentionDays"))
+	return nil
+}
+
+// fakeImage is a custom image with the region it's in and the accounts it's shared with.
+type fakeImage struct {
+	ecs.ImageType
+	RegionId common.Region `json:"-"`
+	accounts []string
+}
+
+// registerImages registers the actions of the custom images.
+func (s *fakeServer) registerImages() {
+	s.handle("CreateImage", func(params url.Values) (interface{}, error) {
+		image := s.newImage(common.Region(params.Get("RegionId")), params.Get("ImageName"), params.Get("Description"))
+		snapshots := fakeListParams(params, "DiskDeviceMapping.%d.SnapshotId")
+		if id := params.Get("SnapshotId"); id != "" {
+			snapshots = []string{id}
+		}
+		if id := params.Get("InstanceId"); id != "" {
+			if _, ok := s.instances[id]; !ok {
+				return nil, fakeNotFound("InvalidInstanceId.NotFound", "The specified InstanceId does not exist.")
+			}
+			for _, disk := range s.disks {
+				if disk.InstanceId == id {
+					image.addDisk("", disk.Size)
+				}
+			}
+		}
+		for _, id := range snapshots {
+			snapshot, err := s.snapshot(id)
+			if err != nil {
+				return nil, err
+			}
+			image.addDisk(id, snapshot.SourceDiskSize)
+		}
+		if len(image.DiskDeviceMappings.DiskDeviceMapping) == 0 {
+			return nil, fakeBadRequest("MissingParameter", "One of InstanceId, SnapshotId and DiskDeviceMapping is mandatory.")
+		}
+		if s.failImages {
+			image.Status = ecs.ImageStatusCreateFailed
+			image.Progress = "0%"
+		}
+		s.images[image.ImageId] = image
+		return ecs.CreateImageResponse{ImageId: image.ImageId}, nil
+	}, EcsCode)
+
+	s.handle("DescribeImages", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeImagesResponse{}
+		statuses := []string{string(ecs.ImageStatusAvailable)}
+		if status := params.Get("Status"); status != "" {
+			statuses = strings.Split(status, ",")
+		}
+		for _, image := range s.images {
+			if image.RegionId != common.Region(params.Get("RegionId")) || !fakeContains(statuses, string(image.Status)) {
+				continue
+			}
+			if id := params.Get("ImageId"); id != "" && id != image.ImageId {
+				continue
+			}
+			response.Images.Image = append(response.Images.Image, image.ImageType)
+		}
+		response.TotalCount = len(response.Images.Image)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode)
+
+	s.handle("ModifyImageAttribute", func(params url.Values) (interface{}, error) {
+		image, err := s.image(params)
+		if err != nil {
+			return nil, err
+		}
+		decodeFakeParams(params, &image.ImageType)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("CopyImage", func(params url.Values) (interface{}, error) {
+		source, err := s.image(params)
+		if err != nil {
+			return nil, err
+		}
+		region := common.Region(params.Get("DestinationRegionId"))
+		if region == source.RegionId {
+			return nil, fakeBadRequest("InvalidRegionId.Malformed", "The destination region is the same as the source one.")
+		}
+		image := s.newImage(region, params.Get("DestinationImageName"), params.Get("DestinationDescription"))
+		image.DiskDeviceMappings = source.DiskDeviceMappings
+		image.IsCopied = true
+		s.images[image.ImageId] = image
+		return ecs.CopyImageResponse{ImageId: image.ImageId}, nil
+	}, EcsCode)
+
+	s.handle("CancelCopyImage", func(params url.Values) (interface{}, error) {
+		image, err := s.image(params)
+		if err != nil {
+			return nil, err
+		}
+		if image.Status != ecs.ImageStatusCreating {
+			return nil, fakeForbidden("IncorrectImageStatus", "The image isn't being copied.")
+		}
+		image.Status = ecs.ImageStatusCreateFailed
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("DeleteImage", func(params url.Values) (interface{}, error) {
+		image, err := s.image(params)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.images, image.ImageId)
+		delete(s.tags, image.ImageId)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("ModifyImageSharePermission", func(params url.Values) (interface{}, error) {
+		image, err := s.image(params)
+		if err != nil {
+			return nil, err
+		}
+		add, remove := fakeListParams(params, "AddAccount.%d"), fakeListParams(params, "RemoveAccount.%d")
+		if len(add) > 10 || len(remove) > 10 {
+			return nil, fakeBadRequest("InvalidAccount.Malformed", "At most 10 accounts are allowed in a request.")
+		}
+		var accounts []string
+		for _, account := range image.accounts {
+			if !fakeContains(remove, account) && !fakeContains(add, account) {
+				accounts = append(accounts, account)
+			}
+		}
+		image.accounts = append(accounts, add...)
+		return common.Response{}, nil
+	}, EcsCode)
+
+	s.handle("DescribeImageSharePermission", func(params url.Values) (interface{}, error) {
+		image, err := s.image(params)
+		if err != nil {
+			return nil, err
+		}
+		response := ecs.ImageSharePermissionResponse{ImageId: image.ImageId, RegionId: string(image.RegionId)}
+		for _, account := range image.accounts {
+			response.Accounts.Account = append(response.Accounts.Account, ecs.AccountType{AliyunId: account})
+		}
+		response.TotalCount = len(image.accounts)
+		response.PageNumber = 1
+		response.PageSize = 50
+		return response, nil
+	}, EcsCode)
+}
+
+func (s *fakeServer) newImage(region common.Region, name, description string) *fakeImage {
+	image := &fakeImage{
+		ImageType: ecs.ImageType{
+			ImageId:         s.newId("m"),
+			ImageName:       name,
+			Description:     description,
+			ImageOwnerAlias: string(ecs.ImageOwnerSelf),
+			OSName:          "CentOS 7.4 64 bit",
+			Progress:        "100%",
+			Status:          ecs.ImageStatusAvailable,
+		},
+		RegionId: region,
+	}
+	if image.ImageName == "" {
+		image.ImageName = image.ImageId
+	}
+	return image
+}
+
+func (image *fakeImage) addDisk(snapshotId string, size int) {
+	mappings := &image.DiskDeviceMappings.DiskDeviceMapping
+	*mappings = append(*mappings, ecs.DiskDeviceMapping{
+		SnapshotId: snapshotId,
+		Size:       strconv.Itoa(size),
+		Device:     fmt.Sprintf("/dev/xvd%c", 'a'+len(*mappings)),
+	})
+	image.Size += size
+}
+
+// image returns the image of the ImageId in the region of the RegionId.
+func (s *fakeServer) image(params url.Values) (*fakeImage, error) {
+	image, ok := s.images[params.Get("ImageId")]
+	if !ok || image.RegionId != common.Region(params.Get("RegionId")) {
+		return nil, fakeNotFound("InvalidImageId.NotFound", "The specified ImageId does not exist.")
+	}
+	return image, nil
+}
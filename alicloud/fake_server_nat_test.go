@@ -0,0 +1,177 @@
+package alicloud
+
+import (
+	"net/url"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+)
+
+// The SNAT and forward tables of the NAT gateway the fake server starts with.
+const (
+	fakeSnatTableId    = "stb-fake"
+	fakeForwardTableId = "ftb-fake"
+)
+
+func (s *fakeServer) seedNat() {
+	s.snatEntries[fakeSnatTableId] = make(map[string]*ecs.SnatEntrySetType)
+	s.forwardEntries[fakeForwardTableId] = make(map[string]*ecs.ForwardTableEntrySetType)
+}
+
+// registerNat registers the VPC APIs of the entries of the SNAT and forward tables. Like the route entries,
+// the entries of one table can only be changed one by one.
+func (s *fakeServer) registerNat() {
+	s.handle("CreateSnatEntry", func(params url.Values) (interface{}, error) {
+		entries, err := s.snatTable(params)
+		if err != nil {
+			return nil, err
+		}
+		entry := &ecs.SnatEntrySetType{
+			SnatEntryId: s.newId("snat"),
+			RegionId:    common.Beijing,
+			Status:      "Available",
+		}
+		decodeFakeParams(params, entry)
+		for _, e := range entries {
+			if e.SourceVSwitchId == entry.SourceVSwitchId {
+				return nil, fakeBadRequest("Forbidden.SourceVSwitchId.Duplicated", "The SNAT entry of %s already exists.", e.SourceVSwitchId)
+			}
+		}
+		return ecs.CreateSnatEntryResponse{SnatEntryId: entry.SnatEntryId}, s.changeChild(entry.SnatTableId, func() {
+			entries[entry.SnatEntryId] = entry
+		})
+	}, VpcCode)
+
+	s.handle("DescribeSnatTableEntries", func(params url.Values) (interface{}, error) {
+		entries, err := s.snatTable(params)
+		if err != nil {
+			return nil, err
+		}
+		response := ecs.DescribeSnatTableEntriesResponse{}
+		for _, entry := range entries {
+			response.SnatTableEntries.SnatTableEntry = append(response.SnatTableEntries.SnatTableEntry, *entry)
+		}
+		response.TotalCount = len(entries)
+		response.PageNumber = 1
+		response.PageSize = len(entries)
+		return response, nil
+	}, VpcCode)
+
+	s.handle("ModifySnatEntry", func(params url.Values) (interface{}, error) {
+		entry, err := s.snatEntry(params)
+		if err != nil {
+			return nil, err
+		}
+		return ecs.ModifySnatEntryResponse{}, s.changeChild(entry.SnatTableId, func() {
+			entry.SnatIp = params.Get("SnatIp")
+		})
+	}, VpcCode)
+
+	s.handle("DeleteSnatEntry", func(params url.Values) (interface{}, error) {
+		entry, err := s.snatEntry(params)
+		if err != nil {
+			return nil, err
+		}
+		return ecs.DeleteSnatEntryResponse{}, s.changeChild(entry.SnatTableId, func() {
+			delete(s.snatEntries[entry.SnatTableId], entry.SnatEntryId)
+		})
+	}, VpcCode)
+
+	s.handle("CreateForwardEntry", func(params url.Values) (interface{}, error) {
+		entries, err := s.forwardTable(params)
+		if err != nil {
+			return nil, err
+		}
+		entry := &ecs.ForwardTableEntrySetType{
+			ForwardEntryId: s.newId("fwd"),
+			RegionId:       common.Beijing,
+			Status:         "Available",
+		}
+		decodeFakeParams(params, entry)
+		for _, e := range entries {
+			if e.ExternalIp == entry.ExternalIp && e.ExternalPort == entry.ExternalPort && e.IpProtocol == entry.IpProtocol {
+				return nil, fakeBadRequest("Forbidden.ForwardEntry.Duplicated", "The forward entry of %s:%s already exists.", e.ExternalIp, e.ExternalPort)
+			}
+		}
+		return ecs.CreateForwardEntryResponse{ForwardEntryId: entry.ForwardEntryId}, s.changeChild(entry.ForwardTableId, func() {
+			entries[entry.ForwardEntryId] = entry
+		})
+	}, VpcCode)
+
+	s.handle("DescribeForwardTableEntries", func(params url.Values) (interface{}, error) {
+		entries, err := s.forwardTable(params)
+		if err != nil {
+			return nil, err
+		}
+		response := ecs.DescribeForwardTableEntriesResponse{}
+		for _, entry := range entries {
+			response.ForwardTableEntries.ForwardTableEntry = append(response.ForwardTableEntries.ForwardTableEntry, *entry)
+		}
+		response.TotalCount = len(entries)
+		response.PageNumber = 1
+		response.PageSize = len(entries)
+		return response, nil
+	}, VpcCode)
+
+	s.handle("ModifyForwardEntry", func(params url.Values) (interface{}, error) {
+		entry, err := s.forwardEntry(params)
+		if err != nil {
+			return nil, err
+		}
+		return ecs.ModifyForwardEntryResponse{}, s.changeChild(entry.ForwardTableId, func() {
+			decodeFakeParams(params, entry)
+		})
+	}, VpcCode)
+
+	s.handle("DeleteForwardEntry", func(params url.Values) (interface{}, error) {
+		entry, err := s.forwardEntry(params)
+		if err != nil {
+			return nil, err
+		}
+		return ecs.DeleteForwardEntryResponse{}, s.changeChild(entry.ForwardTableId, func() {
+			delete(s.forwardEntries[entry.ForwardTableId], entry.ForwardEntryId)
+		})
+	}, VpcCode)
+}
+
+// snatTable returns the entries of the SNAT table of the SnatTableId parameter.
+func (s *fakeServer) snatTable(params url.Values) (map[string]*ecs.SnatEntrySetType, error) {
+	entries, ok := s.snatEntries[params.Get("SnatTableId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidSnatTableId.NotFound", "The SNAT table %s does not exist.", params.Get("SnatTableId"))
+	}
+	return entries, nil
+}
+
+func (s *fakeServer) snatEntry(params url.Values) (*ecs.SnatEntrySetType, error) {
+	entries, err := s.snatTable(params)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := entries[params.Get("SnatEntryId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidSnatEntryId.NotFound", "The SNAT entry %s does not exist.", params.Get("SnatEntryId"))
+	}
+	return entry, nil
+}
+
+// forwardTable returns the entries of the forward table of the ForwardTableId parameter.
+func (s *fakeServer) forwardTable(params url.Values) (map[string]*ecs.ForwardTableEntrySetType, error) {
+	entries, ok := s.forwardEntries[params.Get("ForwardTableId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidForwardTableId.NotFound", "The forward table %s does not exist.", params.Get("ForwardTableId"))
+	}
+	return entries, nil
+}
+
+func (s *fakeServer) forwardEntry(params url.Values) (*ecs.ForwardTableEntrySetType, error) {
+	entries, err := s.forwardTable(params)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := entries[params.Get("ForwardEntryId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidForwardEntryId.NotFound", "The forward entry %s does not exist.", params.Get("ForwardEntryId"))
+	}
+	return entry, nil
+}
@@ -0,0 +1,197 @@
+package alicloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+)
+
+// fakeBucket is an OSS bucket, which keeps the configurations of its subresources as they were put.
+type fakeBucket struct {
+	acl          string
+	created      time.Time
+	subresources map[string][]byte
+}
+
+// fakeOssSubresources are the subresources of a bucket the fake serves, with the error code of getting
+// the ones which haven't been put, or the document the real API returns for them.
+var fakeOssSubresources = map[string]struct {
+	notFound string
+	empty    string
+}{
+	"cors":      {notFound: "NoSuchCORSConfiguration"},
+	"website":   {notFound: "NoSuchWebsiteConfiguration"},
+	"lifecycle": {notFound: "NoSuchLifecycle"},
+	"logging":   {empty: "<BucketLoggingStatus></BucketLoggingStatus>"},
+	"referer":   {empty: "<RefererConfiguration><AllowEmptyReferer>true</AllowEmptyReferer><RefererList></RefererList></RefererConfiguration>"},
+	"tagging":   {empty: "<Tagging><TagSet></TagSet></Tagging>"},
+}
+
+// serveOss serves the OSS REST API, whose requests address the buckets by the path as the endpoint is an IP.
+func (s *fakeServer) serveOss(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	subresource := ""
+	for _, name := range []string{"acl", "bucketInfo", "cors", "website", "logging", "referer", "lifecycle", "tagging"} {
+		if _, ok := query[name]; ok {
+			subresource = name
+		}
+	}
+	request := "oss/" + r.Method
+	if subresource != "" {
+		request += "?" + subresource
+	}
+	s.requests = append(s.requests, request)
+
+	name := strings.Trim(r.URL.Path, "/")
+	if name == "" {
+		if r.Method != "GET" {
+			s.writeOssError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+			return
+		}
+		s.listBuckets(w, query.Get("prefix"), query.Get("max-keys"))
+		return
+	}
+	if strings.Contains(name, "/") {
+		s.writeOssError(w, http.StatusNotImplemented, "NotImplemented", "The fake OSS service doesn't support objects.")
+		return
+	}
+
+	bucket, ok := s.buckets[name]
+	if r.Method == "PUT" && subresource == "" {
+		// The SDK sets the ACL of a bucket by putting it again.
+		if ok {
+			if acl := r.Header.Get(oss.HTTPHeaderOssACL); acl != "" {
+				bucket.acl = acl
+			}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		acl := r.Header.Get(oss.HTTPHeaderOssACL)
+		if acl == "" {
+			acl = string(oss.ACLPrivate)
+		}
+		s.buckets[name] = &fakeBucket{
+			acl:          acl,
+			created:      time.Now().UTC().Truncate(time.Second),
+			subresources: make(map[string][]byte),
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if !ok {
+		s.writeOssError(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+		return
+	}
+
+	_, configurable := fakeOssSubresources[subresource]
+	switch {
+	case subresource == "" && r.Method == "DELETE":
+		delete(s.buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	case subresource == "acl" && r.Method == "PUT":
+		bucket.acl = r.Header.Get(oss.HTTPHeaderOssACL)
+		w.WriteHeader(http.StatusOK)
+	case subresource == "acl" && r.Method == "GET":
+		s.writeXML(w, oss.GetBucketACLResult{
+			ACL:   bucket.acl,
+			Owner: oss.Owner{ID: "fake-owner", DisplayName: "fake-owner"},
+		})
+	case subresource == "bucketInfo" && r.Method == "GET":
+		s.writeXML(w, oss.GetBucketInfoResult{BucketInfo: oss.BucketInfo{
+			Name:             name,
+			Location:         "oss-cn-beijing",
+			CreationDate:     bucket.created,
+			ExtranetEndpoint: "oss-cn-beijing.aliyuncs.com",
+			IntranetEndpoint: "oss-cn-beijing-internal.aliyuncs.com",
+			ACL:              bucket.acl,
+			Owner:            oss.Owner{ID: "fake-owner", DisplayName: "fake-owner"},
+			StorageClass:     string(oss.StorageStandard),
+		}})
+	case configurable:
+		s.serveOssSubresource(w, r, bucket, subresource)
+	default:
+		s.writeOssError(w, http.StatusNotImplemented, "NotImplemented", fmt.Sprintf("The fake OSS service doesn't support %s.", request))
+	}
+}
+
+func (s *fakeServer) serveOssSubresource(w http.ResponseWriter, r *http.Request, bucket *fakeBucket, subresource string) {
+	switch r.Method {
+	case "PUT":
+		body, _ := ioutil.ReadAll(r.Body)
+		bucket.subresources[subresource] = body
+		w.WriteHeader(http.StatusOK)
+	case "DELETE":
+		delete(bucket.subresources, subresource)
+		w.WriteHeader(http.StatusNoContent)
+	case "GET":
+		body, ok := bucket.subresources[subresource]
+		if !ok {
+			defaults := fakeOssSubresources[subresource]
+			if defaults.notFound != "" {
+				s.writeOssError(w, http.StatusNotFound, defaults.notFound, fmt.Sprintf("The %s configuration does not exist.", subresource))
+				return
+			}
+			body = []byte(defaults.empty)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	default:
+		s.writeOssError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+	}
+}
+
+func (s *fakeServer) listBuckets(w http.ResponseWriter, prefix, maxKeys string) {
+	max, err := strconv.Atoi(maxKeys)
+	if err != nil || max <= 0 {
+		max = 100
+	}
+	var names []string
+	for name := range s.buckets {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	result := oss.ListBucketsResult{Prefix: prefix, MaxKeys: max}
+	for i, name := range names {
+		if i == max {
+			result.IsTruncated = true
+			result.NextMarker = name
+			break
+		}
+		result.Buckets = append(result.Buckets, oss.BucketProperties{
+			Name:         name,
+			Location:     "oss-cn-beijing",
+			CreationDate: s.buckets[name].created,
+			StorageClass: string(oss.StorageStandard),
+		})
+	}
+	s.writeXML(w, result)
+}
+
+func (s *fakeServer) writeXML(w http.ResponseWriter, response interface{}) {
+	body, err := xml.Marshal(response)
+	if err != nil {
+		s.writeOssError(w, http.StatusInternalServerError, "InternalError", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (s *fakeServer) writeOssError(w http.ResponseWriter, status int, code, message string) {
+	body, _ := xml.Marshal(oss.ServiceError{Code: code, Message: message, RequestID: "fake-request", HostID: "fake-host"})
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write(body)
+}
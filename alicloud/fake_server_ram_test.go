@@ -0,0 +1,82 @@
+package alicloud
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ram"
+)
+
+// registerRam registers the RAM APIs of the users, whose names are their IDs.
+func (s *fakeServer) registerRam() {
+	s.handle("CreateUser", func(params url.Values) (interface{}, error) {
+		user := &ram.User{}
+		decodeFakeParams(params, user)
+		if _, ok := s.ramUsers[user.UserName]; ok {
+			return nil, fakeConflict("EntityAlreadyExists.User", "The user %s already exists.", user.UserName)
+		}
+		user.UserId = s.newId("user")
+		user.CreateDate = time.Now().UTC().Format(time.RFC3339)
+		user.UpdateDate = user.CreateDate
+		s.ramUsers[user.UserName] = user
+		return ram.UserResponse{User: *user}, nil
+	}, RamCode)
+
+	s.handle("GetUser", func(params url.Values) (interface{}, error) {
+		user, err := s.ramUser(params.Get("UserName"))
+		if err != nil {
+			return nil, err
+		}
+		return ram.UserResponse{User: *user}, nil
+	}, RamCode)
+
+	s.handle("UpdateUser", func(params url.Values) (interface{}, error) {
+		user, err := s.ramUser(params.Get("UserName"))
+		if err != nil {
+			return nil, err
+		}
+		update := ram.UpdateUserRequest{}
+		decodeFakeParams(params, &update)
+		if update.NewUserName != "" && update.NewUserName != user.UserName {
+			if _, ok := s.ramUsers[update.NewUserName]; ok {
+				return nil, fakeConflict("EntityAlreadyExists.User", "The user %s already exists.", update.NewUserName)
+			}
+			delete(s.ramUsers, user.UserName)
+			user.UserName = update.NewUserName
+			s.ramUsers[user.UserName] = user
+		}
+		if _, ok := params["NewDisplayName"]; ok {
+			user.DisplayName = update.NewDisplayName
+		}
+		if _, ok := params["NewMobilePhone"]; ok {
+			user.MobilePhone = update.NewMobilePhone
+		}
+		if _, ok := params["NewEmail"]; ok {
+			user.Email = update.NewEmail
+		}
+		if _, ok := params["NewComments"]; ok {
+			user.Comments = update.NewComments
+		}
+		user.UpdateDate = time.Now().UTC().Format(time.RFC3339)
+		return ram.UserResponse{User: *user}, nil
+	}, RamCode)
+
+	s.handle("DeleteUser", func(params url.Values) (interface{}, error) {
+		user, err := s.ramUser(params.Get("UserName"))
+		if err != nil {
+			return nil, err
+		}
+		delete(s.ramUsers, user.UserName)
+		return common.Response{}, nil
+	}, RamCode)
+}
+
+// ramUser returns the RAM user of the name, or the error of the real API if it doesn't exist.
+func (s *fakeServer) ramUser(name string) (*ram.User, error) {
+	user, ok := s.ramUsers[name]
+	if !ok {
+		return nil, fakeNotFound("EntityNotExist.User", "The user %s does not exist.", name)
+	}
+	return user, nil
+}
@@ -0,0 +1,166 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/rds"
+)
+
+// registerRds registers the RDS APIs of the DB instances and their tags. An instance is running as soon as
+// it is ordered, and is gone as soon as it is deleted.
+func (s *fakeServer) registerRds() {
+	s.handle("CreateOrder", func(params url.Values) (interface{}, error) {
+		args := rds.CreateOrderArgs{}
+		decodeFakeParams(params, &args)
+		if args.DBInstanceClass == "" || args.DBInstanceStorage <= 0 {
+			return nil, fakeBadRequest("MissingParameter", "The DBInstanceClass and DBInstanceStorage are required.")
+		}
+		zoneId := args.ZoneId
+		if zoneId == "" {
+			zoneId = fakeZoneId
+		}
+
+		instance := &rds.DBInstanceAttribute{
+			DBInstanceId:        s.newId("rm"),
+			RegionId:            string(common.Beijing),
+			ZoneId:              zoneId,
+			PayType:             args.PayType,
+			Engine:              args.Engine,
+			EngineVersion:       args.EngineVersion,
+			DBInstanceClass:     args.DBInstanceClass,
+			DBInstanceStorage:   args.DBInstanceStorage,
+			DBInstanceNetType:   string(args.DBInstanceNetType),
+			InstanceNetworkType: string(args.InstanceNetworkType),
+			DBInstanceStatus:    rds.Running,
+			VpcId:               args.VPCId,
+			VSwitchId:           args.VSwitchId,
+			SecurityIPList:      LOCAL_HOST_IP,
+		}
+		// Like the real API, an instance is described by its ID until it is given a description.
+		instance.DBInstanceDescription = instance.DBInstanceId
+		instance.ConnectionString = instance.DBInstanceId + ".mysql.rds.aliyuncs.com"
+		instance.Port = "3306"
+		s.dbInstances[instance.DBInstanceId] = instance
+		return rds.CreateOrderResponse{DBInstanceId: instance.DBInstanceId}, nil
+	}, RdsCode)
+
+	s.handle("DescribeDBInstanceAttribute", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		response := rds.DescribeDBInstanceAttributeResponse{}
+		response.Items.DBInstanceAttribute = []rds.DBInstanceAttribute{*instance}
+		return response, nil
+	}, RdsCode)
+
+	s.handle("DescribeDBInstanceIPArrayList", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		response := rds.DescribeDBInstanceIPsResponse{}
+		response.Items.DBInstanceIPArray = []rds.DBInstanceIPList{
+			{DBInstanceIPArrayName: "default", SecurityIPList: instance.SecurityIPList},
+		}
+		return response, nil
+	}, RdsCode)
+
+	s.handle("ModifySecurityIps", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		instance.SecurityIPList = params.Get("SecurityIps")
+		return common.Response{}, nil
+	}, RdsCode)
+
+	s.handle("ModifyDBInstanceSpec", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		if class := params.Get("DBInstanceClass"); class != "" {
+			instance.DBInstanceClass = class
+		}
+		if storage := params.Get("DBInstanceStorage"); storage != "" {
+			instance.DBInstanceStorage, _ = strconv.Atoi(storage)
+		}
+		return common.Response{}, nil
+	}, RdsCode)
+
+	s.handle("ModifyDBInstanceDescription", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		instance.DBInstanceDescription = params.Get("DBInstanceDescription")
+		return common.Response{}, nil
+	}, RdsCode)
+
+	s.handle("DeleteDBInstance", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		delete(s.dbInstances, instance.DBInstanceId)
+		delete(s.tags, instance.DBInstanceId)
+		return common.Response{}, nil
+	}, RdsCode)
+
+	s.handle("DescribeTags", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		response := DescribeDBTagsResponse{}
+		for key, value := range s.tags[instance.DBInstanceId] {
+			response.Items.TagInfos = append(response.Items.TagInfos, struct {
+				TagKey   string
+				TagValue string
+			}{key, value})
+		}
+		return response, nil
+	}, RdsCode)
+
+	s.handle("AddTagsToResource", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		var tags map[string]string
+		if err := json.Unmarshal([]byte(params.Get("Tags")), &tags); err != nil {
+			return nil, fakeBadRequest("InvalidTags.Format", "The tags %s are not a JSON object.", params.Get("Tags"))
+		}
+		if s.tags[instance.DBInstanceId] == nil {
+			s.tags[instance.DBInstanceId] = make(map[string]string)
+		}
+		for key, value := range tags {
+			s.tags[instance.DBInstanceId][key] = value
+		}
+		return common.Response{}, nil
+	}, RdsCode)
+
+	s.handle("RemoveTagsFromResource", func(params url.Values) (interface{}, error) {
+		instance, err := s.dbInstance(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range fakeListParams(params, "Tag.%d.key") {
+			delete(s.tags[instance.DBInstanceId], key)
+		}
+		return common.Response{}, nil
+	}, RdsCode)
+}
+
+// dbInstance returns the DB instance of the DBInstanceId parameter, or the error of the real API if it doesn't exist.
+func (s *fakeServer) dbInstance(params url.Values) (*rds.DBInstanceAttribute, error) {
+	instance, ok := s.dbInstances[params.Get("DBInstanceId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidDBInstanceId.NotFound", "The DB instance %s does not exist.", params.Get("DBInstanceId"))
+	}
+	return instance, nil
+}
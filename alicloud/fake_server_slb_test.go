@@ -0,0 +1,347 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/slb"
+	"github.com/denverdino/aliyungo/util"
+)
+
+const fakeLoadBalancerId = "lb-fake"
+
+// fakeListener is a listener of a load balancer, which keeps the parameters it was created and set with.
+type fakeListener struct {
+	protocol string
+	status   slb.ListenerStatus
+	params   url.Values
+}
+
+// fakeListenerDefaults are the attributes a listener gets when they aren't specified, as the real API does.
+var fakeListenerDefaults = map[string]string{
+	"Scheduler":                 string(slb.WRRScheduler),
+	"StickySession":             string(slb.OffFlag),
+	"HealthCheck":               string(slb.OnFlag),
+	"HealthCheckType":           string(slb.TCPHealthCheckType),
+	"HealthCheckURI":            "/",
+	"HealthyThreshold":          "3",
+	"UnhealthyThreshold":        "3",
+	"HealthCheckTimeout":        "5",
+	"HealthCheckConnectTimeout": "5",
+	"HealthCheckInterval":       "2",
+	"HealthCheckHttpCode":       string(slb.HTTP_2XX),
+}
+
+func (s *fakeServer) seedSlb() {
+	s.loadBalancers[fakeLoadBalancerId] = &slb.LoadBalancerType{
+		LoadBalancerId:     fakeLoadBalancerId,
+		LoadBalancerName:   "tf-testAccSlbFake",
+		LoadBalancerStatus: "active",
+		Address:            "10.0.0.100",
+		RegionId:           common.Beijing,
+		AddressType:        slb.IntranetAddressType,
+		NetworkType:        "classic",
+		InternetChargeType: slb.PayByTraffic,
+		CreateTimeStamp:    util.NewISO6801Time(time.Now().UTC()),
+		MasterZoneId:       fakeZoneId,
+	}
+}
+
+func (s *fakeServer) registerSlb() {
+	s.handle("DescribeLoadBalancerAttribute", func(params url.Values) (interface{}, error) {
+		lb, err := s.loadBalancer(params)
+		if err != nil {
+			return nil, err
+		}
+		response := slb.DescribeLoadBalancerAttributeResponse{LoadBalancerType: *lb}
+		for _, key := range s.listenerKeys(lb.LoadBalancerId) {
+			listener := s.listeners[key]
+			port, _ := strconv.Atoi(strings.TrimPrefix(key, lb.LoadBalancerId+":"))
+			response.ListenerPortsAndProtocol.ListenerPortAndProtocol = append(response.ListenerPortsAndProtocol.ListenerPortAndProtocol,
+				slb.ListenerPortAndProtocolType{ListenerPort: port, ListenerProtocol: listener.protocol})
+			response.ListenerPorts.ListenerPort = append(response.ListenerPorts.ListenerPort, port)
+		}
+		return response, nil
+	}, SlbCode)
+
+	for _, protocol := range []string{"HTTP", "HTTPS", "TCP", "UDP"} {
+		protocol := protocol
+		s.handle("CreateLoadBalancer"+protocol+"Listener", func(params url.Values) (interface{}, error) {
+			lb, err := s.loadBalancer(params)
+			if err != nil {
+				return nil, err
+			}
+			key := lb.LoadBalancerId + ":" + params.Get("ListenerPort")
+			if _, ok := s.listeners[key]; ok {
+				return nil, fakeBadRequest("ListenerAlreadyExists", "The specified port of listener already exists.")
+			}
+			listener := &fakeListener{
+				protocol: strings.ToLower(protocol),
+				status:   slb.Stopped,
+				params:   url.Values{},
+			}
+			for name, value := range fakeListenerDefaults {
+				listener.params.Set(name, value)
+			}
+			mergeFakeParams(listener.params, params)
+			s.listeners[key] = listener
+			return common.Response{}, nil
+		}, SlbCode)
+
+		s.handle("SetLoadBalancer"+protocol+"ListenerAttribute", func(params url.Values) (interface{}, error) {
+			listener, err := s.listener(params, protocol)
+			if err != nil {
+				return nil, err
+			}
+			mergeFakeParams(listener.params, params)
+			return common.Response{}, nil
+		}, SlbCode)
+
+		s.handle("DescribeLoadBalancer"+protocol+"ListenerAttribute", func(params url.Values) (interface{}, error) {
+			listener, err := s.listener(params, protocol)
+			if err != nil {
+				return nil, err
+			}
+			var response interface{}
+			switch protocol {
+			case "HTTPS":
+				r := &slb.DescribeLoadBalancerHTTPSListenerAttributeResponse{}
+				decodeFakeParams(listener.params, r)
+				r.Status = listener.status
+				response = r
+			case "TCP":
+				r := &slb.DescribeLoadBalancerTCPListenerAttributeResponse{}
+				decodeFakeParams(listener.params, r)
+				r.Status = listener.status
+				response = r
+			case "UDP":
+				r := &slb.DescribeLoadBalancerUDPListenerAttributeResponse{}
+				decodeFakeParams(listener.params, r)
+				r.Status = listener.status
+				response = r
+			default:
+				r := &slb.DescribeLoadBalancerHTTPListenerAttributeResponse{}
+				decodeFakeParams(listener.params, r)
+				r.Status = listener.status
+				response = r
+			}
+			return response, nil
+		}, SlbCode)
+	}
+
+	s.handle("StartLoadBalancerListener", func(params url.Values) (interface{}, error) {
+		listener, err := s.listener(params, "")
+		if err != nil {
+			return nil, err
+		}
+		listener.status = slb.Running
+		return common.Response{}, nil
+	}, SlbCode)
+
+	s.handle("StopLoadBalancerListener", func(params url.Values) (interface{}, error) {
+		listener, err := s.listener(params, "")
+		if err != nil {
+			return nil, err
+		}
+		listener.status = slb.Stopped
+		return common.Response{}, nil
+	}, SlbCode)
+
+	s.handle("DeleteLoadBalancerListener", func(params url.Values) (interface{}, error) {
+		if _, err := s.listener(params, ""); err != nil {
+			return nil, err
+		}
+		delete(s.listeners, params.Get("LoadBalancerId")+":"+params.Get("ListenerPort"))
+		return common.Response{}, nil
+	}, SlbCode)
+}
+
+func (s *fakeServer) loadBalancer(params url.Values) (*slb.LoadBalancerType, error) {
+	lb, ok := s.loadBalancers[params.Get("LoadBalancerId")]
+	if !ok {
+		return nil, fakeNotFound("InvalidLoadBalancerId.NotFound", "The specified LoadBalancerId does not exist.")
+	}
+	return lb, nil
+}
+
+// listener returns the listener of the parameters, whose protocol has to match if it isn't empty.
+func (s *fakeServer) listener(params url.Values, protocol string) (*fakeListener, error) {
+	if _, err := s.loadBalancer(params); err != nil {
+		return nil, err
+	}
+	listener, ok := s.listeners[params.Get("LoadBalancerId")+":"+params.Get("ListenerPort")]
+	if !ok || (protocol != "" && !strings.EqualFold(listener.protocol, protocol)) {
+		return nil, fakeBadRequest("InvalidParameter", "The specified resource does not exist.")
+	}
+	return listener, nil
+}
+
+// listenerKeys returns the keys of the listeners of the load balancer in order.
+func (s *fakeServer) listenerKeys(loadBalancerId string) []string {
+	var keys []string
+	for key := range s.listeners {
+		if strings.HasPrefix(key, loadBalancerId+":") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// mergeFakeParams overwrites the attributes with the parameters, except for the common ones of the requests.
+func mergeFakeParams(attributes, params url.Values) {
+	for name, value := range params {
+		switch name {
+		case "Action", "Format", "Version", "AccessKeyId", "Signature", "SignatureMethod", "SignatureVersion",
+			"SignatureNonce", "Timestamp", "RegionId":
+			continue
+		}
+		attributes[name] = value
+	}
+}
+
+// fakeServerGroup is a VServer group of a load balancer.
+type fakeServerGroup struct {
+	loadBalancerId string
+	name           string
+	servers        []slb.VBackendServerType
+}
+
+// registerSlbServerGroups registers the SLB APIs of the VServer groups, which are changed one by one
+// with the other changes of their load balancers.
+func (s *fakeServer) registerSlbServerGroups() {
+	s.handle("CreateVServerGroup", func(params url.Values) (interface{}, error) {
+		lb, err := s.loadBalancer(params)
+		if err != nil {
+			return nil, err
+		}
+		servers, err := decodeFakeBackendServers(params.Get("BackendServers"))
+		if err != nil {
+			return nil, err
+		}
+		id := s.newId("rsp")
+		group := &fakeServerGroup{loadBalancerId: lb.LoadBalancerId, name: params.Get("VServerGroupName"), servers: servers}
+		return group.response(id), s.changeChild(lb.LoadBalancerId, func() {
+			s.serverGroups[id] = group
+		})
+	}, SlbCode)
+
+	s.handle("DescribeVServerGroupAttribute", func(params url.Values) (interface{}, error) {
+		group, err := s.serverGroup(params)
+		if err != nil {
+			return nil, err
+		}
+		return group.response(params.Get("VServerGroupId")), nil
+	}, SlbCode)
+
+	s.handle("SetVServerGroupAttribute", func(params url.Values) (interface{}, error) {
+		group, err := s.serverGroup(params)
+		if err != nil {
+			return nil, err
+		}
+		servers, err := decodeFakeBackendServers(params.Get("BackendServers"))
+		if err != nil {
+			return nil, err
+		}
+		return group.response(params.Get("VServerGroupId")), s.changeChild(group.loadBalancerId, func() {
+			if name := params.Get("VServerGroupName"); name != "" {
+				group.name = name
+			}
+			if len(servers) > 0 {
+				group.servers = servers
+			}
+		})
+	}, SlbCode)
+
+	s.handle("AddVServerGroupBackendServers", func(params url.Values) (interface{}, error) {
+		group, err := s.serverGroup(params)
+		if err != nil {
+			return nil, err
+		}
+		servers, err := decodeFakeBackendServers(params.Get("BackendServers"))
+		if err != nil {
+			return nil, err
+		}
+		return group.response(params.Get("VServerGroupId")), s.changeChild(group.loadBalancerId, func() {
+			group.servers = append(group.servers, servers...)
+		})
+	}, SlbCode)
+
+	s.handle("RemoveVServerGroupBackendServers", func(params url.Values) (interface{}, error) {
+		group, err := s.serverGroup(params)
+		if err != nil {
+			return nil, err
+		}
+		servers, err := decodeFakeBackendServers(params.Get("BackendServers"))
+		if err != nil {
+			return nil, err
+		}
+		return group.response(params.Get("VServerGroupId")), s.changeChild(group.loadBalancerId, func() {
+			var kept []slb.VBackendServerType
+			for _, server := range group.servers {
+				removed := false
+				for _, r := range servers {
+					removed = removed || r.ServerId == server.ServerId && r.Port == server.Port
+				}
+				if !removed {
+					kept = append(kept, server)
+				}
+			}
+			group.servers = kept
+		})
+	}, SlbCode)
+
+	s.handle("DeleteVServerGroup", func(params url.Values) (interface{}, error) {
+		group, err := s.serverGroup(params)
+		if err != nil {
+			return nil, err
+		}
+		return common.Response{}, s.changeChild(group.loadBalancerId, func() {
+			delete(s.serverGroups, params.Get("VServerGroupId"))
+		})
+	}, SlbCode)
+}
+
+func (g *fakeServerGroup) response(id string) slb.CreateVServerGroupResponse {
+	response := slb.CreateVServerGroupResponse{VServerGroupId: id, VServerGroupName: g.name}
+	response.BackendServers.BackendServer = append([]slb.VBackendServerType{}, g.servers...)
+	return response
+}
+
+// serverGroup returns the VServer group of the VServerGroupId parameter, or the error of the real API,
+// which doesn't tell a missing group by the code, if it doesn't exist.
+func (s *fakeServer) serverGroup(params url.Values) (*fakeServerGroup, error) {
+	group, ok := s.serverGroups[params.Get("VServerGroupId")]
+	if !ok {
+		return nil, fakeBadRequest(InvalidParameter, "The specified VServerGroupId does not exist.")
+	}
+	return group, nil
+}
+
+// decodeFakeBackendServers decodes the backend servers of a VServer group, which the resource passes
+// as a list of objects quoted by single quotes, e.g. [{'ServerId':'i-fake','Port': '80','Weight':'100'}].
+func decodeFakeBackendServers(param string) ([]slb.VBackendServerType, error) {
+	if param == "" {
+		return nil, nil
+	}
+	var servers []struct {
+		ServerId string
+		Port     string
+		Weight   string
+	}
+	if err := json.Unmarshal([]byte(strings.Replace(param, "'", `"`, -1)), &servers); err != nil {
+		return nil, fakeBadRequest("InvalidParameter.BackendServers", "The backend servers %s are malformed.", param)
+	}
+	var result []slb.VBackendServerType
+	for _, server := range servers {
+		port, _ := strconv.Atoi(server.Port)
+		weight, _ := strconv.Atoi(server.Weight)
+		result = append(result, slb.VBackendServerType{ServerId: server.ServerId, Port: port, Weight: weight, Type: "ecs"})
+	}
+	return result, nil
+}
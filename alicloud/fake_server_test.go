@@ -0,0 +1,496 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/denverdino/aliyungo/ram"
+	"github.com/denverdino/aliyungo/rds"
+	"github.com/denverdino/aliyungo/slb"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// fakeServer is an in-process stand-in of the Alibaba Cloud APIs, which the unit tests run the resources
+// against by the custom endpoints of the provider.
+//
+// The RPC style APIs of a service are served under the path of its service code, e.g. /ecs, and dispatched
+// by the action, case-insensitively like the real APIs, to the handler registered for the service.
+// The OSS REST API, whose requests have no action, is served at the root. The resources live in memory,
+// and a call to an action without a handler fails, so a test can't pass by an API the fake doesn't speak.
+type fakeServer struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	handlers map[string]fakeHandler
+	requests []string
+	lastId   int
+
+	// Restores the default transport which redirectDefaultEndpoints replaced, if any.
+	restoreTransport func()
+
+	// ECS and VPC
+	regions        []ecs.RegionType
+	zones          []ecs.ZoneType
+	families       []ecs.InstanceTypeFamily
+	instanceTypes  []ecs.InstanceTypeItemType
+	securityGroups map[string]*ecs.DescribeSecurityGroupAttributeResponse
+	instances      map[string]*ecs.InstanceAttributesType
+	disks          map[string]*ecs.DiskItemType
+	diskPolicies   map[string]string
+	snapshots      map[string]*ecs.SnapshotType
+	policies       map[string]*AutoSnapshotPolicyType
+	images         map[string]*fakeImage
+	vpcs           map[string]*ecs.VpcSetType
+	vrouters       map[string]*ecs.VRouterSetType
+	routeTables    map[string]*ecs.RouteTableSetType
+	tags           map[string]map[string]string
+
+	// failImages fails the images being created, as the real API does when e.g. their snapshots are gone.
+	failImages bool
+	// snapshotErrors are the errors of the next calls to CreateSnapshot, e.g. while the disk is busy.
+	snapshotErrors []string
+
+	// SLB
+	loadBalancers map[string]*slb.LoadBalancerType
+	listeners     map[string]*fakeListener
+	serverGroups  map[string]*fakeServerGroup
+
+	// NAT gateways by the IDs of their tables
+	snatEntries    map[string]map[string]*ecs.SnatEntrySetType
+	forwardEntries map[string]map[string]*ecs.ForwardTableEntrySetType
+
+	// OSS
+	buckets map[string]*fakeBucket
+
+	// RDS
+	dbInstances map[string]*rds.DBInstanceAttribute
+
+	// RAM users by their names
+	ramUsers map[string]*ram.User
+
+	// The parents whose children are being changed, and the number of the changes rejected by them.
+	busyParents map[string]bool
+	conflicts   int
+}
+
+// fakeHandler handles a call to an RPC style API by its parameters,
+// and returns the response which is encoded as JSON, or an error.
+type fakeHandler func(params url.Values) (interface{}, error)
+
+// fakeError is the error response of an API.
+type fakeError struct {
+	status  int
+	Code    string
+	Message string
+}
+
+func (e *fakeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func fakeNotFound(code, format string, a ...interface{}) *fakeError {
+	return &fakeError{status: http.StatusNotFound, Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+func fakeBadRequest(code, format string, a ...interface{}) *fakeError {
+	return &fakeError{status: http.StatusBadRequest, Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+func fakeForbidden(code, format string, a ...interface{}) *fakeError {
+	return &fakeError{status: http.StatusForbidden, Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+func fakeConflict(code, format string, a ...interface{}) *fakeError {
+	return &fakeError{status: http.StatusConflict, Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// newFakeServer starts a fake server, which has a zone, a security group, a load balancer and the tables
+// of a NAT gateway to start with. Close it when the test finishes.
+func newFakeServer() *fakeServer {
+	s := &fakeServer{
+		handlers:       make(map[string]fakeHandler),
+		securityGroups: make(map[string]*ecs.DescribeSecurityGroupAttributeResponse),
+		instances:      make(map[string]*ecs.InstanceAttributesType),
+		disks:          make(map[string]*ecs.DiskItemType),
+		diskPolicies:   make(map[string]string),
+		snapshots:      make(map[string]*ecs.SnapshotType),
+		policies:       make(map[string]*AutoSnapshotPolicyType),
+		images:         make(map[string]*fakeImage),
+		vpcs:           make(map[string]*ecs.VpcSetType),
+		vrouters:       make(map[string]*ecs.VRouterSetType),
+		routeTables:    make(map[string]*ecs.RouteTableSetType),
+		tags:           make(map[string]map[string]string),
+		loadBalancers:  make(map[string]*slb.LoadBalancerType),
+		listeners:      make(map[string]*fakeListener),
+		serverGroups:   make(map[string]*fakeServerGroup),
+		snatEntries:    make(map[string]map[string]*ecs.SnatEntrySetType),
+		forwardEntries: make(map[string]map[string]*ecs.ForwardTableEntrySetType),
+		buckets:        make(map[string]*fakeBucket),
+		dbInstances:    make(map[string]*rds.DBInstanceAttribute),
+		ramUsers:       make(map[string]*ram.User),
+		busyParents:    make(map[string]bool),
+	}
+	s.seedEcs()
+	s.seedSlb()
+	s.seedNat()
+	s.registerEcs()
+	s.registerSnapshots()
+	s.registerImages()
+	s.registerVpc()
+	s.registerSlb()
+	s.registerSlbServerGroups()
+	s.registerNat()
+	s.registerRds()
+	s.registerRam()
+	s.registerLocation()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Close restores the default transport if the default endpoints were redirected, and shuts down the server.
+func (s *fakeServer) Close() {
+	if s.restoreTransport != nil {
+		s.restoreTransport()
+		s.restoreTransport = nil
+	}
+	s.Server.Close()
+}
+
+// redirectDefaultEndpoints sends the calls to the default endpoints of the services to the fake server until it's
+// closed. They are made by the clients of the regions other than the provider's one, which don't take its custom
+// endpoints. The fake location service tells the endpoint of a service in each region.
+func (s *fakeServer) redirectDefaultEndpoints() {
+	installApiRetries()
+	transport := http.DefaultTransport
+	http.DefaultTransport = &fakeRedirectTransport{server: s, transport: transport}
+	s.restoreTransport = func() {
+		http.DefaultTransport = transport
+	}
+}
+
+// fakeRedirectTransport sends the calls to a default endpoint, e.g. ecs.cn-shanghai.aliyuncs.com,
+// to the path of its service on the fake server.
+type fakeRedirectTransport struct {
+	server    *fakeServer
+	transport http.RoundTripper
+}
+
+func (t *fakeRedirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.TrimSuffix(req.URL.Host, ".aliyuncs.com")
+	if host == req.URL.Host {
+		return t.transport.RoundTrip(req)
+	}
+	name := strings.FieldsFunc(host, func(r rune) bool { return r == '.' || r == '-' })[0]
+	code, ok := apiHostServices[name]
+	if !ok && name != "location" {
+		return nil, fmt.Errorf("The fake server doesn't serve the default endpoint %s.", req.URL.Host)
+	}
+	if !ok {
+		code = ServiceCode(name)
+	}
+
+	target, err := url.Parse(t.server.URL)
+	if err != nil {
+		return nil, err
+	}
+	redirected := req.WithContext(req.Context())
+	u := *req.URL
+	u.Scheme = target.Scheme
+	u.Host = target.Host
+	u.Path = "/" + string(code)
+	redirected.URL = &u
+	redirected.Host = ""
+	return t.transport.RoundTrip(redirected)
+}
+
+// registerLocation registers the location service, which tells the default endpoints of the services.
+func (s *fakeServer) registerLocation() {
+	s.handle("DescribeEndpoints", func(params url.Values) (interface{}, error) {
+		endpoint := common.EndpointItem{
+			Id:          common.Region(params.Get("Id")),
+			SerivceCode: params.Get("ServiceCode"),
+			Type:        params.Get("Type"),
+			Endpoint:    fmt.Sprintf("%s.%s.aliyuncs.com", params.Get("ServiceCode"), params.Get("Id")),
+		}
+		endpoint.Protocols.Protocols = []string{"HTTPS"}
+		response := common.DescribeEndpointsResponse{Success: true}
+		response.Endpoints.Endpoint = []common.EndpointItem{endpoint}
+		return response, nil
+	}, ServiceCode("location"))
+}
+
+// handle registers the handler of the action for the services.
+func (s *fakeServer) handle(action string, handler fakeHandler, codes ...ServiceCode) {
+	for _, code := range codes {
+		s.handlers[string(code)+"/"+strings.ToLower(action)] = handler
+	}
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	action := r.Form.Get("Action")
+	if action == "" {
+		s.serveOss(w, r)
+		return
+	}
+
+	code := strings.Trim(r.URL.Path, "/")
+	s.requests = append(s.requests, code+"/"+action)
+
+	handler, ok := s.handlers[code+"/"+strings.ToLower(action)]
+	if !ok {
+		s.writeJSON(w, fakeBadRequest("InvalidAction.NotFound", "The fake %s service doesn't support the action %s.", code, action))
+		return
+	}
+	response, err := handler(r.Form)
+	if err != nil {
+		s.writeJSON(w, err)
+		return
+	}
+	s.writeJSON(w, response)
+}
+
+func (s *fakeServer) writeJSON(w http.ResponseWriter, response interface{}) {
+	status := http.StatusOK
+	if e, ok := response.(*fakeError); ok {
+		status = e.status
+	}
+	body, err := json.Marshal(response)
+	if err != nil {
+		status = http.StatusInternalServerError
+		body = []byte(fmt.Sprintf(`{"Code": "InternalError", "Message": %q}`, err.Error()))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// fakeChangeLatency is how long a change of a child keeps its parent busy.
+const fakeChangeLatency = 20 * time.Millisecond
+
+// changeChild applies the change of a child of the parent, e.g. a rule of a security group, and keeps the parent
+// busy for a while like the real APIs. A concurrent change of the same parent fails with OperationConflict,
+// which isn't retried, since the resources are expected to serialize them. It's called by a handler,
+// which holds the lock of the server.
+func (s *fakeServer) changeChild(parent string, change func()) error {
+	if s.busyParents[parent] {
+		s.conflicts++
+		return fakeForbidden("OperationConflict", "The request conflicts with another change of %s in progress.", parent)
+	}
+	s.busyParents[parent] = true
+	change()
+
+	s.lock.Unlock()
+	time.Sleep(fakeChangeLatency)
+	s.lock.Lock()
+	delete(s.busyParents, parent)
+	return nil
+}
+
+// conflicted returns the number of the changes which conflicted with another change of the same parent.
+func (s *fakeServer) conflicted() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.conflicts
+}
+
+// newId returns a new resource ID with the prefix, e.g. i-fake1.
+func (s *fakeServer) newId(prefix string) string {
+	s.lastId++
+	return fmt.Sprintf("%s-fake%d", prefix, s.lastId)
+}
+
+// calls returns the number of the requests to the action of the service, e.g. "ecs/CreateInstance",
+// or to the method and subresource of OSS, e.g. "oss/GET?bucketInfo".
+func (s *fakeServer) calls(request string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	count := 0
+	for _, r := range s.requests {
+		if strings.EqualFold(r, request) {
+			count++
+		}
+	}
+	return count
+}
+
+// providerConfig returns the provider block which points all of the services to the fake server.
+func (s *fakeServer) providerConfig() string {
+	var endpoints []string
+	for _, code := range SupportedServiceCodes {
+		endpoint := s.URL + "/" + string(code)
+		if code == OssCode {
+			endpoint = s.URL
+		}
+		endpoints = append(endpoints, fmt.Sprintf("    %s = %q", code, endpoint))
+	}
+	return fmt.Sprintf(`
+provider "alicloud" {
+  access_key = "AccessKey"
+  secret_key = "SecretKey"
+  region = "cn-beijing"
+  endpoints {
+%s
+  }
+}
+`, strings.Join(endpoints, "\n"))
+}
+
+// testUnitProviders returns a fresh provider for a unit test, since the configured client can't be shared
+// by the tests against different fake servers.
+func testUnitProviders() map[string]terraform.ResourceProvider {
+	return map[string]terraform.ResourceProvider{
+		"alicloud": Provider().(*schema.Provider),
+	}
+}
+
+// fakeInvalidPlan is a configuration which fails to plan with the error.
+type fakeInvalidPlan struct {
+	config string
+	error  string
+}
+
+// testUnitInvalidPlans plans each configuration, formatted by the format, against a fake server and checks
+// it fails with the error before any API which changes the resources is called.
+func testUnitInvalidPlans(t *testing.T, format string, plans []fakeInvalidPlan) {
+	server := newFakeServer()
+	defer server.Close()
+
+	for _, p := range plans {
+		resource.UnitTest(t, resource.TestCase{
+			Providers: testUnitProviders(),
+			Steps: []resource.TestStep{
+				resource.TestStep{
+					Config:      server.providerConfig() + fmt.Sprintf(format, p.config),
+					PlanOnly:    true,
+					ExpectError: regexp.MustCompile(regexp.QuoteMeta(p.error)),
+				},
+			},
+		})
+	}
+
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	for _, r := range server.requests {
+		if !fakeReadOnlyRequest.MatchString(r) {
+			t.Fatalf("The invalid configurations should fail at plan time, but %s was called.", r)
+		}
+	}
+}
+
+// fakeReadOnlyRequest matches the requests recorded by the fake server which don't change anything.
+var fakeReadOnlyRequest = regexp.MustCompile(`^(oss/(GET|HEAD)|\w+/(Describe|List|Get|Query))`)
+
+// decodeFakeParams sets the fields of the struct pointed by v from the parameters of the same names.
+func decodeFakeParams(params url.Values, v interface{}) {
+	decodeFakeValue(params, reflect.ValueOf(v).Elem())
+}
+
+func decodeFakeValue(params url.Values, v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field, value := t.Field(i), v.Field(i)
+		if field.Anonymous && value.Kind() == reflect.Struct {
+			decodeFakeValue(params, value)
+			continue
+		}
+		raw, ok := params[field.Name]
+		if !ok || field.PkgPath != "" {
+			continue
+		}
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString(raw[0])
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			n, _ := strconv.ParseInt(raw[0], 10, 64)
+			value.SetInt(n)
+		case reflect.Float32, reflect.Float64:
+			f, _ := strconv.ParseFloat(raw[0], 64)
+			value.SetFloat(f)
+		case reflect.Bool:
+			value.SetBool(raw[0] == "true")
+		}
+	}
+}
+
+// fakeListParams returns the values of the list parameter which the SDK flattens by the format,
+// e.g. Tag.%d.Key for Tag.1.Key, Tag.2.Key and so on.
+func fakeListParams(params url.Values, format string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		value, ok := params[fmt.Sprintf(format, i)]
+		if !ok {
+			return values
+		}
+		values = append(values, value[0])
+	}
+}
+
+// fakeJSONListParam returns the values of the list parameter which is encoded as a JSON array, e.g. InstanceIds.
+func fakeJSONListParam(params url.Values, name string) []string {
+	var values []string
+	json.Unmarshal([]byte(params.Get(name)), &values)
+	return values
+}
+
+func fakeContains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestFakeServer_dispatch(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+		Endpoints: make(map[ServiceCode]string),
+	}
+	for _, code := range SupportedServiceCodes {
+		config.Endpoints[code] = server.URL + "/" + string(code)
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client with the fake server got an error: %#v", err)
+	}
+
+	if _, err := client.ecsConn().DescribeZones(common.Beijing); err != nil {
+		t.Fatalf("describing zones got an error: %#v", err)
+	}
+	if _, err := client.rdsConn().DescribeRegions(); !IsExceptedError(err, "InvalidAction.NotFound") {
+		t.Fatalf("expected an InvalidAction.NotFound error of the action without a handler, got %#v", err)
+	}
+	if _, err := client.ramConn().ListUsers(ram.ListUserRequest{}); !IsExceptedError(err, "InvalidAction.NotFound") {
+		t.Fatalf("expected an InvalidAction.NotFound error of the action without a handler, got %#v", err)
+	}
+
+	for _, request := range []string{"ecs/DescribeZones", "rds/DescribeRegions", "ram/ListUsers"} {
+		if n := server.calls(request); n != 1 {
+			t.Fatalf("expected 1 request of %s, got %d", request, n)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package alicloud
+
+import (
+	"net/url"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+)
+
+// registerVpc registers the VPC APIs, which the ECS endpoint serves as well.
+func (s *fakeServer) registerVpc() {
+	s.handle("CreateVpc", func(params url.Values) (interface{}, error) {
+		vpc := &ecs.VpcSetType{
+			VpcId:     s.newId("vpc"),
+			RegionId:  common.Beijing,
+			Status:    ecs.VpcStatusAvailable,
+			VRouterId: s.newId("vrt"),
+		}
+		decodeFakeParams(params, vpc)
+		s.vpcs[vpc.VpcId] = vpc
+
+		vrouter := &ecs.VRouterSetType{
+			VRouterId: vpc.VRouterId,
+			RegionId:  common.Beijing,
+			VpcId:     vpc.VpcId,
+		}
+		vrouter.RouteTableIds.RouteTableId = []string{s.newId("vtb")}
+		s.vrouters[vrouter.VRouterId] = vrouter
+		s.routeTables[vrouter.RouteTableIds.RouteTableId[0]] = &ecs.RouteTableSetType{
+			VRouterId:      vrouter.VRouterId,
+			RouteTableId:   vrouter.RouteTableIds.RouteTableId[0],
+			RouteTableType: ecs.RouteTableSystem,
+		}
+
+		return ecs.CreateVpcResponse{
+			VpcId:        vpc.VpcId,
+			VRouterId:    vrouter.VRouterId,
+			RouteTableId: vrouter.RouteTableIds.RouteTableId[0],
+		}, nil
+	}, EcsCode, VpcCode)
+
+	s.handle("DescribeVpcs", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeVpcsResponse{}
+		for _, vpc := range s.vpcs {
+			if id := params.Get("VpcId"); id == "" || id == vpc.VpcId {
+				response.Vpcs.Vpc = append(response.Vpcs.Vpc, *vpc)
+			}
+		}
+		response.TotalCount = len(response.Vpcs.Vpc)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode, VpcCode)
+
+	s.handle("ModifyVpcAttribute", func(params url.Values) (interface{}, error) {
+		vpc, ok := s.vpcs[params.Get("VpcId")]
+		if !ok {
+			return nil, fakeNotFound("InvalidVpcId.NotFound", "Specified VPC does not exist.")
+		}
+		decodeFakeParams(params, vpc)
+		return common.Response{}, nil
+	}, EcsCode, VpcCode)
+
+	s.handle("DeleteVpc", func(params url.Values) (interface{}, error) {
+		vpc, ok := s.vpcs[params.Get("VpcId")]
+		if !ok {
+			return nil, fakeNotFound("InvalidVpcId.NotFound", "Specified VPC does not exist.")
+		}
+		delete(s.vpcs, vpc.VpcId)
+		if vrouter, ok := s.vrouters[vpc.VRouterId]; ok {
+			for _, id := range vrouter.RouteTableIds.RouteTableId {
+				delete(s.routeTables, id)
+			}
+		}
+		delete(s.vrouters, vpc.VRouterId)
+		delete(s.tags, vpc.VpcId)
+		return common.Response{}, nil
+	}, EcsCode, VpcCode)
+
+	s.handle("DescribeVRouters", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeVRoutersResponse{}
+		for _, vrouter := range s.vrouters {
+			if id := params.Get("VRouterId"); id == "" || id == vrouter.VRouterId {
+				response.VRouters.VRouter = append(response.VRouters.VRouter, *vrouter)
+			}
+		}
+		response.TotalCount = len(response.VRouters.VRouter)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode, VpcCode)
+
+	s.handle("DescribeRouteTables", func(params url.Values) (interface{}, error) {
+		response := ecs.DescribeRouteTablesResponse{}
+		for _, table := range s.routeTables {
+			if id := params.Get("RouteTableId"); id != "" && id != table.RouteTableId {
+				continue
+			}
+			if id := params.Get("VRouterId"); id != "" && id != table.VRouterId {
+				continue
+			}
+			response.RouteTables.RouteTable = append(response.RouteTables.RouteTable, *table)
+		}
+		response.TotalCount = len(response.RouteTables.RouteTable)
+		response.PageNumber = 1
+		response.PageSize = 10
+		return response, nil
+	}, EcsCode, VpcCode)
+
+	s.handle("CreateRouteEntry", func(params url.Values) (interface{}, error) {
+		table, ok := s.routeTables[params.Get("RouteTableId")]
+		if !ok {
+			return nil, fakeNotFound("InvalidRouteTableId.NotFound", "Specified route table does not exist.")
+		}
+		entry := ecs.RouteEntrySetType{
+			Type:   ecs.RouteTableCustom,
+			Status: ecs.RouteEntryStatusAvailable,
+		}
+		decodeFakeParams(params, &entry)
+		entry.InstanceId = entry.NextHopId
+		for _, e := range table.RouteEntrys.RouteEntry {
+			if e.DestinationCidrBlock == entry.DestinationCidrBlock {
+				return nil, fakeBadRequest(RouterEntryConflictDuplicated, "The route entry of %s already exists.", e.DestinationCidrBlock)
+			}
+		}
+		return ecs.CreateRouteEntryResponse{}, s.changeChild(table.RouteTableId, func() {
+			table.RouteEntrys.RouteEntry = append(table.RouteEntrys.RouteEntry, entry)
+		})
+	}, EcsCode, VpcCode)
+
+	s.handle("DeleteRouteEntry", func(params url.Values) (interface{}, error) {
+		table, ok := s.routeTables[params.Get("RouteTableId")]
+		if !ok {
+			return nil, fakeNotFound("InvalidRouteTableId.NotFound", "Specified route table does not exist.")
+		}
+		return ecs.DeleteRouteEntryResponse{}, s.changeChild(table.RouteTableId, func() {
+			var entries []ecs.RouteEntrySetType
+			for _, e := range table.RouteEntrys.RouteEntry {
+				if e.DestinationCidrBlock != params.Get("DestinationCidrBlock") {
+					entries = append(entries, e)
+				}
+			}
+			table.RouteEntrys.RouteEntry = entries
+		})
+	}, EcsCode, VpcCode)
+
+	s.handle("TagResources", func(params url.Values) (interface{}, error) {
+		for _, id := range fakeListParams(params, "ResourceId.%d") {
+			s.tagResource(id, fakeListParams(params, "Tag.%d.Key"), fakeListParams(params, "Tag.%d.Value"))
+		}
+		return common.Response{}, nil
+	}, VpcCode)
+
+	s.handle("UntagResources", func(params url.Values) (interface{}, error) {
+		for _, id := range fakeListParams(params, "ResourceId.%d") {
+			s.untagResource(id, fakeListParams(params, "TagKey.%d"))
+		}
+		return common.Response{}, nil
+	}, VpcCode)
+
+	s.handle("ListTagResources", func(params url.Values) (interface{}, error) {
+		response := ListTagResourcesResponse{}
+		for _, id := range fakeListParams(params, "ResourceId.%d") {
+			for key, value := range s.tags[id] {
+				response.TagResources.TagResource = append(response.TagResources.TagResource, TagResourceType{
+					ResourceId:   id,
+					ResourceType: params.Get("ResourceType"),
+					TagKey:       key,
+					TagValue:     value,
+				})
+			}
+		}
+		return response, nil
+	}, VpcCode)
+}
@@ -0,0 +1,29 @@
+package alicloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAlicloudForwardEntry_import(t *testing.T) {
+	resourceName := "alicloud_forward_entry.foo"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckForwardEntryDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccForwardEntryConfig,
+			},
+
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName, "forward_table_id", "id"),
+			},
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAlicloudOssBucketObject_import(t *testing.T) {
+	resourceName := "alicloud_oss_bucket_object.content"
+	bucket := fmt.Sprintf("tf-object-test-object-import-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAlicloudOssBucketObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(`
+						resource "alicloud_oss_bucket" "bucket" {
+						    bucket = "%s"
+						}
+						resource "alicloud_oss_bucket_object" "content" {
+							bucket = "${alicloud_oss_bucket.bucket.bucket}"
+							key = "test-object-content-key"
+							content = "some words for test oss object content"
+						}`, bucket),
+			},
+
+			resource.TestStep{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccImportStateIdFunc(resourceName, "bucket", "key"),
+				ImportStateVerifyIgnore: []string{"content"},
+			},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package alicloud
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+)
+
+// metadataCache caches the regions and the zones of each region, which hardly change during a run. The data sources,
+// the validations and the resources share them instead of calling DescribeRegions and DescribeZones again and again.
+type metadataCache struct {
+	lock    sync.Mutex
+	regions []ecs.RegionType
+	zones   map[common.Region][]ecs.ZoneType
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{
+		zones: make(map[common.Region][]ecs.ZoneType),
+	}
+}
+
+// describeRegions returns the regions available to the account. They are described on first use and cached.
+func (client *AliyunClient) describeRegions() ([]ecs.RegionType, error) {
+	cache := client.pool.metadata
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.regions == nil {
+		regions, err := client.ecsConn().DescribeRegions()
+		if err != nil {
+			return nil, err
+		}
+		if regions == nil {
+			regions = []ecs.RegionType{}
+		}
+		cache.regions = regions
+	}
+	return append([]ecs.RegionType{}, cache.regions...), nil
+}
+
+// describeZones returns the zones of the region. They are described on first use and cached.
+func (client *AliyunClient) describeZones(region common.Region) ([]ecs.ZoneType, error) {
+	cache := client.pool.metadata
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	zones, ok := cache.zones[region]
+	if !ok {
+		var err error
+		zones, err = client.ecsConn().DescribeZones(region)
+		if err != nil {
+			return nil, err
+		}
+		cache.zones[region] = zones
+	}
+	return append([]ecs.ZoneType{}, zones...), nil
+}
+
+// validateCredentials calls DescribeRegions to make sure the credentials work, which warms the cache of regions as well.
+func (client *AliyunClient) validateCredentials() error {
+	if _, err := client.describeRegions(); err != nil {
+		return fmt.Errorf("Validating the credentials by DescribeRegions got an error: %#v. "+
+			"Set skip_credentials_validation to skip it.", err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestMetadataCache(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+	server.redirectDefaultEndpoints()
+
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+		Endpoints: map[ServiceCode]string{EcsCode: server.URL + "/ecs"},
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client with the fake server got an error: %#v", err)
+	}
+	shanghai, err := client.withRegion(common.Shanghai)
+	if err != nil {
+		t.Fatalf("getting client of region %s got an error: %#v", common.Shanghai, err)
+	}
+
+	for _, c := range []*AliyunClient{client, shanghai} {
+		regions, err := c.describeRegions()
+		if err != nil {
+			t.Fatalf("describing regions got an error: %#v", err)
+		}
+		if len(regions) != 2 || regions[0].RegionId != common.Beijing {
+			t.Fatalf("expected the regions of the fake server, got %#v", regions)
+		}
+		// The callers can't change the cache.
+		regions[0].RegionId = common.Hangzhou
+	}
+	if err := shanghai.JudgeRegionValidation("opposite_region", common.Beijing); err != nil {
+		t.Fatalf("validating region %s got an error: %#v", common.Beijing, err)
+	}
+	if err := client.JudgeRegionValidation("opposite_region", common.Hangzhou); err == nil {
+		t.Fatalf("expected an error of validating the region unavailable to the account")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.DescribeZone(fakeZoneId); err != nil {
+			t.Fatalf("describing zone %s got an error: %#v", fakeZoneId, err)
+		}
+	}
+	zones, err := shanghai.describeZones(common.Shanghai)
+	if err != nil {
+		t.Fatalf("describing zones of region %s got an error: %#v", common.Shanghai, err)
+	}
+	if len(zones) != 1 {
+		t.Fatalf("expected the zones of the fake server, got %#v", zones)
+	}
+
+	if n := server.calls("ecs/DescribeRegions"); n != 1 {
+		t.Fatalf("expected the regions described once, got %d", n)
+	}
+	// Once for each region.
+	if n := server.calls("ecs/DescribeZones"); n != 2 {
+		t.Fatalf("expected the zones described once for each region, got %d", n)
+	}
+}
+
+func TestProviderConfigure_validations(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	invalid := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"RequestId": "request-1", "Code": "InvalidAccessKeyId.NotFound", "Message": "Specified access key is not found."}`)
+	}))
+	defer invalid.Close()
+
+	cases := []struct {
+		region          string
+		endpoint        string
+		skipRegion      bool
+		skipCredentials bool
+		err             string
+		calls           int
+	}{
+		{region: "cn-beijing", endpoint: server.URL + "/ecs", calls: 1},
+		{region: "cn-beijing", endpoint: invalid.URL, err: "InvalidAccessKeyId.NotFound"},
+		{region: "cn-beijing", endpoint: invalid.URL, skipCredentials: true},
+		{region: "cn-new-1", endpoint: server.URL + "/ecs", skipCredentials: true, err: "Not a valid region: cn-new-1"},
+		{region: "cn-new-1", endpoint: server.URL + "/ecs", skipRegion: true, calls: 1},
+		{region: "cn-new-1", skipRegion: true, skipCredentials: true},
+	}
+
+	for i, c := range cases {
+		before := server.calls("ecs/DescribeRegions")
+		raw := map[string]interface{}{
+			"access_key":                  "AccessKey",
+			"secret_key":                  "SecretKey",
+			"region":                      c.region,
+			"skip_region_validation":      c.skipRegion,
+			"skip_credentials_validation": c.skipCredentials,
+		}
+		if c.endpoint != "" {
+			raw["endpoints"] = []interface{}{map[string]interface{}{"ecs": c.endpoint}}
+		}
+		d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, raw)
+
+		meta, err := providerConfigure(d, context.Background())
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Fatalf("%d: expected an error containing %q, got %#v", i, c.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: configuring the provider got an error: %#v", i, err)
+		}
+		if region := meta.(*AliyunClient).Region; region != common.Region(c.region) {
+			t.Fatalf("%d: expected the client of region %s, got %s", i, c.region, region)
+		}
+		if n := server.calls("ecs/DescribeRegions") - before; n != c.calls {
+			t.Fatalf("%d: expected %d calls of DescribeRegions, got %d", i, c.calls, n)
+		}
+	}
+}
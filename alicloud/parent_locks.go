@@ -0,0 +1,75 @@
+package alicloud
+
+import (
+	"log"
+	"sync"
+)
+
+// The parent resources whose children are locked while they are created, updated or deleted.
+// The APIs reject, or even lose, the concurrent changes of the children of one parent, e.g. the route entries
+// of a route table fail with TaskConflict, so the resources serialize their changes by the ID of the parent.
+// The locks are shared by all of the provider configurations, since they may manage the same parent.
+type parentKind string
+
+const (
+	RouteTableLock    = parentKind("route_table")
+	SecurityGroupLock = parentKind("security_group")
+	LoadBalancerLock  = parentKind("load_balancer")
+	SnatTableLock     = parentKind("snat_table")
+	ForwardTableLock  = parentKind("forward_table")
+)
+
+var parentLocks = newParentLockSet()
+
+// lockParent locks the parent resource of the kind and the ID, and returns the function to unlock it.
+// Call it before changing a child of the parent, e.g.
+//
+//	unlock := lockParent(RouteTableLock, tableId)
+//	defer unlock()
+func lockParent(kind parentKind, id string) func() {
+	return parentLocks.acquire(string(kind) + ":" + id)
+}
+
+// parentLockSet holds the lock of a parent only while it is locked or waited for,
+// so the locks of the deleted parents don't pile up.
+type parentLockSet struct {
+	lock  sync.Mutex
+	locks map[string]*parentLock
+}
+
+type parentLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newParentLockSet() *parentLockSet {
+	return &parentLockSet{
+		locks: make(map[string]*parentLock),
+	}
+}
+
+func (s *parentLockSet) acquire(key string) func() {
+	s.lock.Lock()
+	l, ok := s.locks[key]
+	if !ok {
+		l = &parentLock{}
+		s.locks[key] = l
+	}
+	l.refs++
+	s.lock.Unlock()
+
+	log.Printf("[DEBUG] Locking %q", key)
+	l.Lock()
+	log.Printf("[DEBUG] Locked %q", key)
+
+	return func() {
+		l.Unlock()
+		log.Printf("[DEBUG] Unlocked %q", key)
+
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(s.locks, key)
+		}
+	}
+}
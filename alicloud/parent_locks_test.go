@@ -0,0 +1,64 @@
+package alicloud
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockParent(t *testing.T) {
+	const (
+		parents = 3
+		changes = 50
+	)
+
+	var lock sync.Mutex
+	inProgress := make(map[string]int)
+	maxInProgress := make(map[string]int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parents*changes; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			unlock := lockParent(RouteTableLock, id)
+			defer unlock()
+
+			lock.Lock()
+			inProgress[id]++
+			if inProgress[id] > maxInProgress[id] {
+				maxInProgress[id] = inProgress[id]
+			}
+			lock.Unlock()
+
+			// Let the other changes run while this one holds the parent, so they would overlap without the lock.
+			time.Sleep(time.Millisecond)
+
+			lock.Lock()
+			inProgress[id]--
+			lock.Unlock()
+		}(string(rune('a' + i%parents)))
+	}
+	wg.Wait()
+
+	for id, n := range maxInProgress {
+		if n != 1 {
+			t.Fatalf("expected the children of %s changed one by one, got %d changes at once", id, n)
+		}
+	}
+
+	parentLocks.lock.Lock()
+	defer parentLocks.lock.Unlock()
+	if n := len(parentLocks.locks); n != 0 {
+		t.Fatalf("expected the locks released once they were unlocked, got %d", n)
+	}
+}
+
+func TestLockParent_independent(t *testing.T) {
+	unlock := lockParent(SecurityGroupLock, "sg-1")
+	defer unlock()
+
+	// Neither another parent nor a parent of another kind with the same ID is blocked.
+	lockParent(SecurityGroupLock, "sg-2")()
+	lockParent(LoadBalancerLock, "sg-1")()
+}
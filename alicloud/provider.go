@@ -1,33 +1,34 @@
 package alicloud
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/denverdino/aliyungo/common"
-	"github.com/hashicorp/terraform/helper/mutexkv"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 // Provider returns a schema.Provider for alicloud
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"access_key": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ACCESS_KEY", os.Getenv("ALICLOUD_ACCESS_KEY")),
 				Description: descriptions["access_key"],
 			},
 			"secret_key": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_SECRET_KEY", os.Getenv("ALICLOUD_SECRET_KEY")),
 				Description: descriptions["secret_key"],
 			},
 			"region": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_REGION", os.Getenv("ALICLOUD_REGION")),
 				Description: descriptions["region"],
 			},
@@ -37,6 +38,57 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_SECURITY_TOKEN", os.Getenv("SECURITY_TOKEN")),
 				Description: descriptions["security_token"],
 			},
+			"ecs_role_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ECS_ROLE_NAME", ""),
+				Description: descriptions["ecs_role_name"],
+			},
+			"ecs_metadata_endpoint": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ECS_METADATA_ENDPOINT", DefaultEcsMetadataEndpoint),
+				Description: descriptions["ecs_metadata_endpoint"],
+			},
+			"assume_role":     assumeRoleSchema(),
+			"endpoints":       endpointsSchema(),
+			"api_rate_limits": apiRateLimitsSchema(),
+			"profile": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+			"shared_credentials_file": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_SHARED_CREDENTIALS_FILE", ""),
+				Description: descriptions["shared_credentials_file"],
+			},
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultMaxRetries,
+				ValidateFunc: validateIntegerInRange(0, 100),
+				Description:  descriptions["max_retries"],
+			},
+			"default_tags": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["default_tags"],
+			},
+			"skip_region_validation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_region_validation"],
+			},
+			"skip_credentials_validation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["skip_credentials_validation"],
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 
@@ -61,43 +113,49 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_ram_policies":        dataSourceAlicloudRamPolicies(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"alicloud_instance":                  resourceAliyunInstance(),
-			"alicloud_ram_role_attachment":       resourceAlicloudRamRoleAttachment(),
-			"alicloud_disk":                      resourceAliyunDisk(),
-			"alicloud_disk_attachment":           resourceAliyunDiskAttachment(),
-			"alicloud_security_group":            resourceAliyunSecurityGroup(),
-			"alicloud_security_group_rule":       resourceAliyunSecurityGroupRule(),
-			"alicloud_db_database":               resourceAlicloudDBDatabase(),
-			"alicloud_db_account":                resourceAlicloudDBAccount(),
-			"alicloud_db_account_privilege":      resourceAlicloudDBAccountPrivilege(),
-			"alicloud_db_backup_policy":          resourceAlicloudDBBackupPolicy(),
-			"alicloud_db_connection":             resourceAlicloudDBConnection(),
-			"alicloud_db_instance":               resourceAlicloudDBInstance(),
-			"alicloud_ess_scaling_group":         resourceAlicloudEssScalingGroup(),
-			"alicloud_ess_scaling_configuration": resourceAlicloudEssScalingConfiguration(),
-			"alicloud_ess_scaling_rule":          resourceAlicloudEssScalingRule(),
-			"alicloud_ess_schedule":              resourceAlicloudEssSchedule(),
-			"alicloud_vpc":                       resourceAliyunVpc(),
-			"alicloud_nat_gateway":               resourceAliyunNatGateway(),
+			"alicloud_instance":                   regionalResource(resourceAliyunInstance()),
+			"alicloud_ram_role_attachment":        regionalResource(resourceAlicloudRamRoleAttachment()),
+			"alicloud_disk":                       regionalResource(resourceAliyunDisk()),
+			"alicloud_disk_attachment":            regionalResource(resourceAliyunDiskAttachment()),
+			"alicloud_snapshot":                   regionalResource(resourceAlicloudSnapshot()),
+			"alicloud_snapshot_policy":            regionalResource(resourceAlicloudSnapshotPolicy()),
+			"alicloud_snapshot_policy_attachment": regionalResource(resourceAlicloudSnapshotPolicyAttachment()),
+			"alicloud_image":                      regionalResource(resourceAlicloudImage()),
+			"alicloud_image_copy":                 regionalResource(resourceAlicloudImageCopy()),
+			"alicloud_image_share_permission":     regionalResource(resourceAlicloudImageSharePermission()),
+			"alicloud_security_group":             regionalResource(resourceAliyunSecurityGroup()),
+			"alicloud_security_group_rule":        regionalResource(resourceAliyunSecurityGroupRule()),
+			"alicloud_db_database":                regionalResource(resourceAlicloudDBDatabase()),
+			"alicloud_db_account":                 regionalResource(resourceAlicloudDBAccount()),
+			"alicloud_db_account_privilege":       regionalResource(resourceAlicloudDBAccountPrivilege()),
+			"alicloud_db_backup_policy":           regionalResource(resourceAlicloudDBBackupPolicy()),
+			"alicloud_db_connection":              regionalResource(resourceAlicloudDBConnection()),
+			"alicloud_db_instance":                regionalResource(resourceAlicloudDBInstance()),
+			"alicloud_ess_scaling_group":          regionalResource(resourceAlicloudEssScalingGroup()),
+			"alicloud_ess_scaling_configuration":  regionalResource(resourceAlicloudEssScalingConfiguration()),
+			"alicloud_ess_scaling_rule":           regionalResource(resourceAlicloudEssScalingRule()),
+			"alicloud_ess_schedule":               regionalResource(resourceAlicloudEssSchedule()),
+			"alicloud_vpc":                        regionalResource(resourceAliyunVpc()),
+			"alicloud_nat_gateway":                regionalResource(resourceAliyunNatGateway()),
 			//both subnet and vswith exists,cause compatible old version, and compatible aws habit.
-			"alicloud_subnet":              resourceAliyunSubnet(),
-			"alicloud_vswitch":             resourceAliyunSubnet(),
-			"alicloud_route_entry":         resourceAliyunRouteEntry(),
-			"alicloud_snat_entry":          resourceAliyunSnatEntry(),
-			"alicloud_forward_entry":       resourceAliyunForwardEntry(),
-			"alicloud_eip":                 resourceAliyunEip(),
-			"alicloud_eip_association":     resourceAliyunEipAssociation(),
-			"alicloud_slb":                 resourceAliyunSlb(),
-			"alicloud_slb_listener":        resourceAliyunSlbListener(),
-			"alicloud_slb_attachment":      resourceAliyunSlbAttachment(),
-			"alicloud_slb_server_group":    resourceAliyunSlbServerGroup(),
-			"alicloud_oss_bucket":          resourceAlicloudOssBucket(),
-			"alicloud_oss_bucket_object":   resourceAlicloudOssBucketObject(),
+			"alicloud_subnet":              regionalResource(resourceAliyunSubnet()),
+			"alicloud_vswitch":             regionalResource(resourceAliyunSubnet()),
+			"alicloud_route_entry":         regionalResource(resourceAliyunRouteEntry()),
+			"alicloud_snat_entry":          regionalResource(resourceAliyunSnatEntry()),
+			"alicloud_forward_entry":       regionalResource(resourceAliyunForwardEntry()),
+			"alicloud_eip":                 regionalResource(resourceAliyunEip()),
+			"alicloud_eip_association":     regionalResource(resourceAliyunEipAssociation()),
+			"alicloud_slb":                 regionalResource(resourceAliyunSlb()),
+			"alicloud_slb_listener":        regionalResource(resourceAliyunSlbListener()),
+			"alicloud_slb_attachment":      regionalResource(resourceAliyunSlbAttachment()),
+			"alicloud_slb_server_group":    regionalResource(resourceAliyunSlbServerGroup()),
+			"alicloud_oss_bucket":          regionalResource(resourceAlicloudOssBucket()),
+			"alicloud_oss_bucket_object":   regionalResource(resourceAlicloudOssBucketObject()),
 			"alicloud_dns_record":          resourceAlicloudDnsRecord(),
 			"alicloud_dns":                 resourceAlicloudDns(),
 			"alicloud_dns_group":           resourceAlicloudDnsGroup(),
-			"alicloud_key_pair":            resourceAlicloudKeyPair(),
-			"alicloud_key_pair_attachment": resourceAlicloudKeyPairAttachment(),
+			"alicloud_key_pair":            regionalResource(resourceAlicloudKeyPair()),
+			"alicloud_key_pair_attachment": regionalResource(resourceAlicloudKeyPairAttachment()),
 			"alicloud_ram_user":            resourceAlicloudRamUser(),
 			"alicloud_ram_access_key":      resourceAlicloudRamAccessKey(),
 			"alicloud_ram_login_profile":   resourceAlicloudRamLoginProfile(),
@@ -111,43 +169,107 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_ram_user_policy_attachment":  resourceAlicloudRamUserPolicyAtatchment(),
 			"alicloud_ram_role_policy_attachment":  resourceAlicloudRamRolePolicyAttachment(),
 			"alicloud_ram_group_policy_attachment": resourceAlicloudRamGroupPolicyAtatchment(),
-			"alicloud_container_cluster":           resourceAlicloudContainerCluster(),
+			"alicloud_container_cluster":           regionalResource(resourceAlicloudContainerCluster()),
 			"alicloud_cdn_domain":                  resourceAlicloudCdnDomain(),
-			"alicloud_router_interface":            resourceAlicloudRouterInterface(),
+			"alicloud_router_interface":            regionalResource(resourceAlicloudRouterInterface()),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider.StopContext())
 	}
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	region, ok := d.GetOk("region")
-	if !ok {
-		if region == "" {
-			region = DEFAULT_REGION
-		}
-	}
+func providerConfigure(d *schema.ResourceData, stopContext context.Context) (interface{}, error) {
 	config := Config{
-		AccessKey: d.Get("access_key").(string),
-		SecretKey: d.Get("secret_key").(string),
-		Region:    common.Region(region.(string)),
+		AccessKey:             d.Get("access_key").(string),
+		SecretKey:             d.Get("secret_key").(string),
+		Region:                common.Region(d.Get("region").(string)),
+		Profile:               d.Get("profile").(string),
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+		EcsRoleName:           d.Get("ecs_role_name").(string),
+		EcsMetadataEndpoint:   d.Get("ecs_metadata_endpoint").(string),
+		MaxRetries:            d.Get("max_retries").(int),
+
+		SkipRegionValidation:      d.Get("skip_region_validation").(bool),
+		SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
+
+		stopContext: stopContext,
 	}
 
 	if token, ok := d.GetOk("security_token"); ok && token.(string) != "" {
 		config.SecurityToken = token.(string)
 	}
 
+	if v, ok := d.GetOk("assume_role"); ok {
+		for _, raw := range v.([]interface{}) {
+			role := raw.(map[string]interface{})
+			config.RoleArn = role["role_arn"].(string)
+			config.RoleSessionName = role["session_name"].(string)
+			config.RoleSessionExpiration = role["session_expiration"].(int)
+			config.RolePolicy = role["policy"].(string)
+		}
+	}
+
+	if v, ok := d.GetOk("default_tags"); ok {
+		config.DefaultTags = make(map[string]string)
+		for key, value := range v.(map[string]interface{}) {
+			config.DefaultTags[key] = value.(string)
+		}
+	}
+
+	if v, ok := d.GetOk("api_rate_limits"); ok {
+		config.ApiRateLimits = make(map[ServiceCode]int)
+		for _, raw := range v.([]interface{}) {
+			limits := raw.(map[string]interface{})
+			for _, code := range SupportedServiceCodes {
+				if limit := limits[string(code)].(int); limit > 0 {
+					config.ApiRateLimits[code] = limit
+				}
+			}
+		}
+	}
+
+	if v, ok := d.GetOk("endpoints"); ok {
+		config.Endpoints = make(map[ServiceCode]string)
+		for _, raw := range v.([]interface{}) {
+			endpoints := raw.(map[string]interface{})
+			for _, code := range SupportedServiceCodes {
+				if endpoint := endpoints[string(code)].(string); endpoint != "" {
+					config.Endpoints[code] = endpoint
+				}
+			}
+		}
+	}
+
+	if err := config.loadSharedCredentials(); err != nil {
+		return nil, err
+	}
+
+	if !config.hasCredentials() {
+		return nil, fmt.Errorf("Both 'access_key' and 'secret_key' are required unless 'ecs_role_name' is set. They can be set in the provider block, " +
+			"by the environment variables ALICLOUD_ACCESS_KEY and ALICLOUD_SECRET_KEY, or in a profile of the shared credentials file.")
+	}
+
+	if config.Region == "" {
+		config.Region = DEFAULT_REGION
+	}
+
 	client, err := config.Client()
 	if err != nil {
 		return nil, err
 	}
 
+	if !config.SkipCredentialsValidation {
+		if err := client.validateCredentials(); err != nil {
+			return nil, err
+		}
+	}
+
 	return client, nil
 }
 
-// This is a global MutexKV for use within this plugin.
-var alicloudMutexKV = mutexkv.NewMutexKV()
-
 var descriptions map[string]string
 
 func init() {
@@ -156,5 +278,165 @@ func init() {
 		"secret_key":     "Secret key of alicloud",
 		"region":         "Region of alicloud",
 		"security_token": "Alibaba Cloud Security Token",
+
+		"profile": "The profile of the shared credentials file. Default to the file's current profile, or 'default' if it is not set. " +
+			"The credentials and region are resolved in order from the provider block, the ALICLOUD_* environment variables and then the profile, " +
+			"so the profile only fills in the ones which are not specified before it.",
+		"shared_credentials_file":        "The path of the shared credentials file written by the aliyun CLI. Default to ~/.aliyun/config.json.",
+		"ecs_role_name":                  "The RAM role name attached on an ECS instance. The provider fetches and renews its credentials from the ECS metadata service.",
+		"ecs_metadata_endpoint":          "The endpoint of the ECS metadata service which the credentials of 'ecs_role_name' are fetched from. Default to http://100.100.100.200.",
+		"endpoint":                       "Use this to override the default endpoint URL constructed from the `region`. It's typically used to connect to custom endpoints. The endpoints of the regional services, e.g. ECS, only apply to the provider's region, so a resource in another region uses its default endpoints.",
+		"api_rate_limit":                 "The max API calls per second to the service. The calls exceeding it are queued instead of being throttled. Default to 0, no limit.",
+		"max_retries":                    "The max times to retry an API call which fails with a throttling or transient error. Default to 5.",
+		"default_tags":                   "The tags applied on every taggable resource. A resource's own tags override them. Changes are applied on an existing resource the next time its tags are updated.",
+		"skip_region_validation":         "Skip checking the region against the regions known by the provider, so a newly launched region can be used. Default to false.",
+		"skip_credentials_validation":    "Skip validating the credentials by calling DescribeRegions when the provider is configured, e.g. to run without the network. Default to false.",
+		"assume_role_role_arn":           "The ARN of a RAM role to assume prior to making API calls.",
+		"assume_role_session_name":       "The session name to use when assuming the role.",
+		"assume_role_session_expiration": "The time after which the established session for assuming role expires. Valid value range: [900-3600] seconds.",
+		"assume_role_policy":             "The permissions applied when assuming a role. You cannot use this policy to grant permissions which exceed those of the role that is being assumed.",
+	}
+}
+
+func assumeRoleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role_arn": &schema.Schema{
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: descriptions["assume_role_role_arn"],
+				},
+				"session_name": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     DefaultRoleSessionName,
+					Description: descriptions["assume_role_session_name"],
+				},
+				"session_expiration": &schema.Schema{
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      3600,
+					ValidateFunc: validateIntegerInRange(900, 3600),
+					Description:  descriptions["assume_role_session_expiration"],
+				},
+				"policy": &schema.Schema{
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: descriptions["assume_role_policy"],
+				},
+			},
+		},
+	}
+}
+
+func apiRateLimitsSchema() *schema.Schema {
+	limits := make(map[string]*schema.Schema)
+	for _, code := range SupportedServiceCodes {
+		limits[string(code)] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validateIntegerInRange(0, 1000),
+			Description:  descriptions["api_rate_limit"],
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: limits,
+		},
+	}
+}
+
+func endpointsSchema() *schema.Schema {
+	endpoints := make(map[string]*schema.Schema)
+	for _, code := range SupportedServiceCodes {
+		endpoints[string(code)] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: descriptions["endpoint"],
+		}
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: endpoints,
+		},
+	}
+}
+
+// regionalResource adds an optional 'region' argument to the resource, so it can be managed in a region
+// other than the provider's one. All of the operations of the resource are performed with the client of that region.
+func regionalResource(r *schema.Resource) *schema.Resource {
+	r.Schema["region"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ForceNew:     true,
+		ValidateFunc: validateRegion,
+	}
+
+	r.Create = withResourceRegion(r.Create)
+	r.Read = withResourceRegion(r.Read)
+	if r.Update != nil {
+		r.Update = withResourceRegion(r.Update)
+	}
+	r.Delete = withResourceRegion(r.Delete)
+
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			client, err := resourceRegionClient(d, meta)
+			if err != nil {
+				return false, err
+			}
+			return exists(d, client)
+		}
+	}
+
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			client, err := resourceRegionClient(d, meta)
+			if err != nil {
+				return nil, err
+			}
+			return state(d, client)
+		}
 	}
+
+	return r
+}
+
+func withResourceRegion(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, err := resourceRegionClient(d, meta)
+		if err != nil {
+			return err
+		}
+
+		if err := f(d, client); err != nil {
+			return err
+		}
+
+		if d.Id() != "" {
+			d.Set("region", string(client.Region))
+		}
+		return nil
+	}
+}
+
+// resourceRegionClient returns the client of the resource's region, and the provider's one if the region is not set.
+func resourceRegionClient(d *schema.ResourceData, meta interface{}) (*AliyunClient, error) {
+	return meta.(*AliyunClient).withRegion(common.Region(d.Get("region").(string)))
 }
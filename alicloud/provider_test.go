@@ -2,10 +2,18 @@ package alicloud
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/denverdino/aliyungo/common"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -21,6 +29,12 @@ func init() {
 	}
 }
 
+// TestMain runs the sweepers instead of the tests when the flag -sweep is given, e.g.
+// go test ./alicloud -v -sweep=cn-beijing
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -57,3 +71,236 @@ func testAccCheckAlicloudDataSourceID(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+// testAccImportStateIdFunc builds the import ID of a resource by joining its attributes with ":".
+func testAccImportStateIdFunc(n string, attributes ...string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("Can't find resource: %s", n)
+		}
+
+		var parts []string
+		for _, attribute := range attributes {
+			parts = append(parts, rs.Primary.Attributes[attribute])
+		}
+		return strings.Join(parts, ":"), nil
+	}
+}
+
+func TestRegionalResource(t *testing.T) {
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client got an error: %#v", err)
+	}
+
+	var used common.Region
+	r := regionalResource(&schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			used = getRegion(d, meta)
+			d.SetId("test")
+			return nil
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			used = getRegion(d, meta)
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Schema: map[string]*schema.Schema{},
+	})
+
+	cases := []struct {
+		region   string
+		expected common.Region
+	}{
+		{"", common.Beijing},
+		{string(common.Shanghai), common.Shanghai},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"region": c.region})
+		if err := r.Create(d, client); err != nil {
+			t.Fatalf("creating resource in region %q got an error: %#v", c.region, err)
+		}
+		if used != c.expected {
+			t.Fatalf("expected the resource to be created in %s, got %s", c.expected, used)
+		}
+		if region := d.Get("region").(string); region != string(c.expected) {
+			t.Fatalf("expected region %s in state, got %s", c.expected, region)
+		}
+	}
+}
+
+// testSweepPrefixes are the name prefixes of the resources which the acceptance tests create,
+// e.g. tf-testAccSlb or tf_test_foo. The sweepers only delete the resources named by them.
+var testSweepPrefixes = []string{"tf-test", "tf_test"}
+
+func isTestSweepName(name string) bool {
+	for _, prefix := range testSweepPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestIsTestSweepName(t *testing.T) {
+	cases := map[string]bool{
+		"tf-testAccSlb":    true,
+		"tf_test_foo":      true,
+		"tf_testAccVpc":    true,
+		"test_foo":         false,
+		"production-tf":    false,
+		"":                 false,
+		"TF-TESTACC-UPPER": false,
+	}
+	for name, expected := range cases {
+		if actual := isTestSweepName(name); actual != expected {
+			t.Fatalf("expected isTestSweepName(%q) to be %t, got %t", name, expected, actual)
+		}
+	}
+}
+
+// testSweepNameAttributes are the arguments naming the resources which the sweepers delete.
+var testSweepNameAttributes = map[string][]string{
+	"alicloud_db_instance":    {"instance_name"},
+	"alicloud_instance":       {"instance_name"},
+	"alicloud_key_pair":       {"key_name", "key_name_prefix"},
+	"alicloud_nat_gateway":    {"name"},
+	"alicloud_ram_user":       {"name"},
+	"alicloud_security_group": {"name"},
+	"alicloud_slb":            {"name"},
+	"alicloud_vpc":            {"name"},
+	"alicloud_vswitch":        {"name"},
+}
+
+var (
+	testFixtureResource = regexp.MustCompile(`(?m)^\s*resource\s+"(\w+)"\s+"([\w-]+)"\s*{`)
+	testFixtureVariable = regexp.MustCompile(`(?s)variable\s+"(\w+)"\s*{[^}]*?default\s*=\s*"([^"]*)"`)
+	testFixtureVarRef   = regexp.MustCompile(`^\$\{var\.(\w+)\}`)
+)
+
+// TestAccFixturesSweepNames checks the resources of the acceptance test configurations are named so that
+// the sweepers delete them when a test leaks them. The unit test configurations, which only run against
+// the fake server, are not checked.
+func TestAccFixturesSweepNames(t *testing.T) {
+	files, err := filepath.Glob("*_test.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value := spec.(*ast.ValueSpec)
+				for i, name := range value.Names {
+					if strings.HasPrefix(name.Name, "testUnit") || i >= len(value.Values) {
+						continue
+					}
+					lit, ok := value.Values[i].(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					config, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						t.Fatal(err)
+					}
+					for _, msg := range testFixtureSweepErrors(config) {
+						t.Errorf("%s: %s: %s", fset.Position(lit.Pos()), name.Name, msg)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testFixtureSweepErrors returns the resources of the configuration which the sweepers wouldn't delete.
+func testFixtureSweepErrors(config string) (errs []string) {
+	variables := make(map[string]string)
+	for _, m := range testFixtureVariable.FindAllStringSubmatch(config, -1) {
+		variables[m[1]] = m[2]
+	}
+	for _, loc := range testFixtureResource.FindAllStringSubmatchIndex(config, -1) {
+		resourceType, resourceName := config[loc[2]:loc[3]], config[loc[4]:loc[5]]
+		attributes, ok := testSweepNameAttributes[resourceType]
+		if !ok {
+			continue
+		}
+		body := testFixtureBlock(config[loc[1]:])
+		named := ""
+		for _, attribute := range attributes {
+			m := regexp.MustCompile(`(?m)^\s*"?` + attribute + `"?\s*=\s*"([^"]*)"`).FindStringSubmatch(body)
+			if m == nil {
+				continue
+			}
+			named = m[1]
+			if ref := testFixtureVarRef.FindStringSubmatch(named); ref != nil {
+				named = variables[ref[1]] + named[len(ref[0]):]
+			}
+		}
+		if !isTestSweepName(named) {
+			errs = append(errs, fmt.Sprintf("%s.%s is named %q, which doesn't start with any of %v by %v",
+				resourceType, resourceName, named, testSweepPrefixes, attributes))
+		}
+	}
+	return
+}
+
+// testFixtureBlock returns the body of the block whose opening brace the configuration follows.
+func testFixtureBlock(config string) string {
+	depth := 1
+	for i, c := range config {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return config[:i]
+			}
+		}
+	}
+	return config
+}
+
+// sharedClientForRegion builds the client of a sweeper from the same environment variables as the acceptance tests.
+func sharedClientForRegion(region string) (*AliyunClient, error) {
+	config := Config{
+		AccessKey:     os.Getenv("ALICLOUD_ACCESS_KEY"),
+		SecretKey:     os.Getenv("ALICLOUD_SECRET_KEY"),
+		SecurityToken: os.Getenv("ALICLOUD_SECURITY_TOKEN"),
+		Region:        common.Region(region),
+		MaxRetries:    DefaultMaxRetries,
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("ALICLOUD_ACCESS_KEY and ALICLOUD_SECRET_KEY must be set for sweepers")
+	}
+	return config.Client()
+}
+
+// testSweepResource deletes a leaked resource by the Delete function of its resource,
+// which waits for the resource to be deleted as applying a destroy does.
+func testSweepResource(r *schema.Resource, client *AliyunClient, id string, attributes map[string]interface{}) error {
+	d := r.Data(nil)
+	d.SetId(id)
+	for key, value := range attributes {
+		if err := d.Set(key, value); err != nil {
+			return err
+		}
+	}
+	return r.Delete(d, client)
+}
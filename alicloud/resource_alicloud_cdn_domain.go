@@ -17,6 +17,15 @@ func resourceAlicloudCdnDomain() *schema.Resource {
 		Read:   resourceAlicloudCdnDomainRead,
 		Update: resourceAlicloudCdnDomainUpdate,
 		Delete: resourceAlicloudCdnDomainDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"domain_name": &schema.Schema{
@@ -258,7 +267,8 @@ func resourceAlicloudCdnDomain() *schema.Resource {
 }
 
 func resourceAlicloudCdnDomainCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).cdnconn
+	client := meta.(*AliyunClient)
+	conn := client.cdnConn()
 
 	args := cdn.AddDomainRequest{
 		DomainName: d.Get("domain_name").(string),
@@ -290,11 +300,17 @@ func resourceAlicloudCdnDomainCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	d.SetId(args.DomainName)
+
+	if err := waitForCdnDomainConfigured(client, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
 	return resourceAlicloudCdnDomainUpdate(d, meta)
 }
 
 func resourceAlicloudCdnDomainUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).cdnconn
+	client := meta.(*AliyunClient)
+	conn := client.cdnConn()
 
 	d.Partial(true)
 
@@ -325,11 +341,15 @@ func resourceAlicloudCdnDomainUpdate(d *schema.ResourceData, meta interface{}) e
 			if err != nil {
 				return fmt.Errorf("ModifyCdnDomain got an error: %#v", err)
 			}
+
+			if err := waitForCdnDomainConfigured(client, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return err
+			}
 		}
 	}
 
 	// set optimize_enable 、range_enable、page_compress_enable and video_seek_enable
-	if err := enableConfigUpdate(conn, d); err != nil {
+	if err := enableConfigUpdate(client, d); err != nil {
 		return err
 	}
 
@@ -343,37 +363,37 @@ func resourceAlicloudCdnDomainUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("parameter_filter_config") {
-		if err := queryStringConfigUpdate(conn, d); err != nil {
+		if err := queryStringConfigUpdate(client, d); err != nil {
 			return err
 		}
 	}
 
 	if d.HasChange("page_404_config") {
-		if err := page404ConfigUpdate(conn, d); err != nil {
+		if err := page404ConfigUpdate(client, d); err != nil {
 			return err
 		}
 	}
 
 	if d.HasChange("refer_config") {
-		if err := referConfigUpdate(conn, d); err != nil {
+		if err := referConfigUpdate(client, d); err != nil {
 			return err
 		}
 	}
 
 	if d.HasChange("auth_config") {
-		if err := authConfigUpdate(conn, d); err != nil {
+		if err := authConfigUpdate(client, d); err != nil {
 			return err
 		}
 	}
 
 	if d.HasChange("http_header_config") {
-		if err := httpHeaderConfigUpdate(conn, d); err != nil {
+		if err := httpHeaderConfigUpdate(client, d); err != nil {
 			return err
 		}
 	}
 
 	if d.HasChange("cache_config") {
-		if err := cacheConfigUpdate(conn, d); err != nil {
+		if err := cacheConfigUpdate(client, d); err != nil {
 			return err
 		}
 	}
@@ -383,7 +403,7 @@ func resourceAlicloudCdnDomainUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudCdnDomainRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).cdnconn
+	conn := meta.(*AliyunClient).cdnConn()
 
 	args := cdn.DescribeDomainRequest{
 		DomainName: d.Id(),
@@ -494,23 +514,37 @@ func resourceAlicloudCdnDomainRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudCdnDomainDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).cdnconn
+	conn := meta.(*AliyunClient).cdnConn()
 
 	args := cdn.DescribeDomainRequest{
 		DomainName: d.Id(),
 	}
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
-		if _, err := conn.DeleteCdnDomain(args); err != nil {
-			if IsExceptedError(err, ServiceBusy) {
-				return resource.RetryableError(fmt.Errorf("The specified Domain is configuring, please retry later."))
-			}
-			return resource.NonRetryableError(fmt.Errorf("Error deleting cdn domain %s: %#v.", d.Id(), err))
+	if _, err := conn.DeleteCdnDomain(args); err != nil {
+		return fmt.Errorf("Error deleting cdn domain %s: %#v.", d.Id(), err)
+	}
+	return nil
+}
+
+// waitForCdnDomainConfigured waits for the domain to finish applying its last change,
+// as the domain's configs cannot be set while it is configuring.
+func waitForCdnDomainConfigured(client *AliyunClient, domainName string, timeout time.Duration) error {
+	args := cdn.DescribeDomainRequest{
+		DomainName: domainName,
+	}
+	return resource.Retry(timeout, func() *resource.RetryError {
+		response, err := client.cdnConn().DescribeCdnDomainDetail(args)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("DescribeCdnDomainDetail got an error: %#v", err))
+		}
+		if response.GetDomainDetailModel.DomainStatus == CdnDomainConfiguring {
+			return resource.RetryableError(fmt.Errorf("The specified Domain is configuring, please retry later."))
 		}
 		return nil
 	})
 }
 
-func enableConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func enableConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	type configFunc func(req cdn.ConfigRequest) (cdn.CdnCommonResponse, error)
 
 	relation := map[string]configFunc{
@@ -535,7 +569,8 @@ func enableConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 	return nil
 }
 
-func queryStringConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func queryStringConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	valSet := d.Get("parameter_filter_config").(*schema.Set)
 	args := cdn.QueryStringConfigRequest{DomainName: d.Id()}
 
@@ -560,7 +595,8 @@ func queryStringConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error
 	return nil
 }
 
-func page404ConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func page404ConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	valSet := d.Get("page_404_config").(*schema.Set)
 	args := cdn.ErrorPageConfigRequest{DomainName: d.Id()}
 
@@ -596,7 +632,8 @@ func page404ConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 	return nil
 }
 
-func referConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func referConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	valSet := d.Get("refer_config").(*schema.Set)
 	args := cdn.ReferConfigRequest{DomainName: d.Id()}
 
@@ -623,7 +660,8 @@ func referConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 	return nil
 }
 
-func authConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func authConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	ov, nv := d.GetChange("auth_config")
 	oldConfig, newConfig := ov.(*schema.Set), nv.(*schema.Set)
 	args := cdn.ReqAuthConfigRequest{DomainName: d.Id()}
@@ -673,7 +711,8 @@ func authConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 	return nil
 }
 
-func httpHeaderConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func httpHeaderConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	ov, nv := d.GetChange("http_header_config")
 	oldConfigs := ov.(*schema.Set).List()
 	newConfigs := nv.(*schema.Set).List()
@@ -708,7 +747,8 @@ func httpHeaderConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 	return nil
 }
 
-func cacheConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
+func cacheConfigUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.cdnConn()
 	ov, nv := d.GetChange("cache_config")
 	oldConfigs := ov.(*schema.Set).List()
 	newConfigs := nv.(*schema.Set).List()
@@ -738,7 +778,7 @@ func cacheConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 			TTL:          strconv.Itoa(val["ttl"].(int)),
 			Weight:       strconv.Itoa(val["weight"].(int)),
 		}
-		if err := setCacheExpiredConfig(args, val["cache_type"].(string), conn); err != nil {
+		if err := setCacheExpiredConfig(args, val["cache_type"].(string), client); err != nil {
 			return err
 		}
 	}
@@ -746,7 +786,8 @@ func cacheConfigUpdate(conn *cdn.CdnClient, d *schema.ResourceData) error {
 	return nil
 }
 
-func setCacheExpiredConfig(req cdn.CacheConfigRequest, cacheType string, conn *cdn.CdnClient) (err error) {
+func setCacheExpiredConfig(req cdn.CacheConfigRequest, cacheType string, client *AliyunClient) (err error) {
+	conn := client.cdnConn()
 	if cacheType == "suffix" {
 		_, err = conn.SetFileCacheExpiredConfig(req)
 	} else {
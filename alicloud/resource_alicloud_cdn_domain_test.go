@@ -52,7 +52,7 @@ func testAccCheckCdnDomainExists(n string, domain *cdn.DomainDetail) resource.Te
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.cdnconn
+		conn := client.cdnConn()
 
 		request := cdn.DescribeDomainRequest{
 			DomainName: rs.Primary.Attributes["domain_name"],
@@ -78,7 +78,7 @@ func testAccCheckCdnDomainDestroy(s *terraform.State) error {
 
 		// Try to find the domain
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.cdnconn
+		conn := client.cdnConn()
 
 		request := cdn.DescribeDomainRequest{
 			DomainName: rs.Primary.Attributes["domain_name"],
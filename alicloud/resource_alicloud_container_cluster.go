@@ -20,6 +20,12 @@ func resourceAlicloudContainerCluster() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:          schema.TypeString,
@@ -92,7 +98,7 @@ func resourceAlicloudContainerCluster() *schema.Resource {
 
 func resourceAlicloudContainerClusterCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.csconn
+	conn := client.csConn()
 
 	// Ensure instance_type is generation three
 	_, err := meta.(*AliyunClient).CheckParameterValidity(d, meta)
@@ -128,7 +134,7 @@ func resourceAlicloudContainerClusterCreate(d *schema.ResourceData, meta interfa
 		args.VSwitchID = v.(string)
 		args.SubnetCIDR = cidr.(string)
 
-		vswInfo, _, err := client.vpcconn.DescribeVSwitches(&ecs.DescribeVSwitchesArgs{
+		vswInfo, _, err := client.vpcConn().DescribeVSwitches(&ecs.DescribeVSwitchesArgs{
 			RegionId:  getRegion(d, meta),
 			VSwitchId: v.(string),
 		})
@@ -144,7 +150,7 @@ func resourceAlicloudContainerClusterCreate(d *schema.ResourceData, meta interfa
 	}
 
 	if imageId, ok := d.GetOk("image_id"); ok {
-		connection := client.ecsconn
+		connection := client.ecsConn()
 		argsImage := &ecs.DescribeImagesArgs{
 			RegionId: getRegion(d, meta),
 			ImageId:  imageId.(string),
@@ -163,7 +169,7 @@ func resourceAlicloudContainerClusterCreate(d *schema.ResourceData, meta interfa
 		return fmt.Errorf("Creating container Cluster got an error: %#v", err)
 	}
 
-	err = conn.WaitForClusterAsyn(cluster.ClusterID, cs.Running, 500)
+	err = conn.WaitForClusterAsyn(cluster.ClusterID, cs.Running, timeoutInSeconds(d, schema.TimeoutCreate))
 
 	if err != nil {
 		return fmt.Errorf("Waitting for container Cluster %#v got an error: %#v", cs.Running, err)
@@ -175,7 +181,7 @@ func resourceAlicloudContainerClusterCreate(d *schema.ResourceData, meta interfa
 }
 
 func resourceAlicloudContainerClusterUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).csconn
+	conn := meta.(*AliyunClient).csConn()
 	d.Partial(true)
 	if d.HasChange("size") && !d.IsNewResource() {
 		o, n := d.GetChange("size")
@@ -198,7 +204,7 @@ func resourceAlicloudContainerClusterUpdate(d *schema.ResourceData, meta interfa
 			return fmt.Errorf("Resize Cluster got an error: %#v", err)
 		}
 
-		err = conn.WaitForClusterAsyn(d.Id(), cs.Running, 500)
+		err = conn.WaitForClusterAsyn(d.Id(), cs.Running, timeoutInSeconds(d, schema.TimeoutUpdate))
 
 		if err != nil {
 			return fmt.Errorf("Waitting for container Cluster %#v got an error: %#v", cs.Running, err)
@@ -210,7 +216,7 @@ func resourceAlicloudContainerClusterUpdate(d *schema.ResourceData, meta interfa
 }
 
 func resourceAlicloudContainerClusterRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).csconn
+	conn := meta.(*AliyunClient).csConn()
 
 	cluster, err := conn.DescribeCluster(d.Id())
 
@@ -232,12 +238,12 @@ func resourceAlicloudContainerClusterRead(d *schema.ResourceData, meta interface
 }
 
 func resourceAlicloudContainerClusterDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).csconn
+	conn := meta.(*AliyunClient).csConn()
 
-	return resource.Retry(3*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		err := conn.DeleteCluster(d.Id())
 		if err != nil {
-			if IsExceptedError(err, ErrorClusterNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.RetryableError(fmt.Errorf("Cluster in use 1- trying again while it is deleted."))
@@ -245,7 +251,7 @@ func resourceAlicloudContainerClusterDelete(d *schema.ResourceData, meta interfa
 
 		resp, err := conn.DescribeCluster(d.Id())
 		if err != nil {
-			if IsExceptedError(err, ErrorClusterNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Deleting container cluster got an error: %#v", err))
@@ -69,7 +69,7 @@ func testAccCheckContainerClusterExists(n string, d *cs.ClusterType) resource.Te
 			return fmt.Errorf("No Container cluster ID is set")
 		}
 
-		client := testAccProvider.Meta().(*AliyunClient).csconn
+		client := testAccProvider.Meta().(*AliyunClient).csConn()
 		attr, err := client.DescribeCluster(cluster.Primary.ID)
 		log.Printf("[DEBUG] check cluster %s attribute %#v", cluster.Primary.ID, attr)
 
@@ -87,7 +87,7 @@ func testAccCheckContainerClusterExists(n string, d *cs.ClusterType) resource.Te
 }
 
 func testAccCheckContainerClusterDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*AliyunClient).csconn
+	client := testAccProvider.Meta().(*AliyunClient).csConn()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "alicloud_container_cluster" {
@@ -97,7 +97,7 @@ func testAccCheckContainerClusterDestroy(s *terraform.State) error {
 		cluster, err := client.DescribeCluster(rs.Primary.ID)
 
 		if err != nil {
-			if IsExceptedError(err, ErrorClusterNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -143,6 +143,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  name = "tf_test_foo"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "10.1.1.0/24"
   availability_zone = "${data.alicloud_zones.main.zones.0.id}"
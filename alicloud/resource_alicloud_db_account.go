@@ -56,6 +56,7 @@ func resourceAlicloudDBAccount() *schema.Resource {
 
 func resourceAlicloudDBAccountCreate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	args := rds.CreateAccountArgs{
 		DBInstanceId:    d.Get("instance_id").(string),
 		AccountName:     d.Get("name").(string),
@@ -67,11 +68,11 @@ func resourceAlicloudDBAccountCreate(d *schema.ResourceData, meta interface{}) e
 	}
 	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
 		ag := args
-		if _, err := meta.(*AliyunClient).rdsconn.CreateAccount(&ag); err != nil {
+		if _, err := client.rdsConn().CreateAccount(&ag); err != nil {
 			if IsExceptedError(err, InvalidAccountNameDuplicate) {
 				return resource.NonRetryableError(fmt.Errorf("The account %s has already existed. Please import it using ID '%s:%s' or specify a new 'name' and try again.",
 					args.AccountName, args.DBInstanceId, args.AccountName))
-			} else if IsExceptedError(err, OperationDeniedDBInstanceStatus) {
+			} else if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Create db account got an error: %#v.", err))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Create db account got an error: %#v.", err))
@@ -86,7 +87,7 @@ func resourceAlicloudDBAccountCreate(d *schema.ResourceData, meta interface{}) e
 
 	d.SetId(fmt.Sprintf("%s%s%s", args.DBInstanceId, COLON_SEPARATED, args.AccountName))
 
-	if err := meta.(*AliyunClient).rdsconn.WaitForAccount(args.DBInstanceId, args.AccountName, rds.Available, defaultTimeout); err != nil {
+	if err := client.rdsConn().WaitForAccount(args.DBInstanceId, args.AccountName, rds.Available, defaultTimeout); err != nil {
 		return fmt.Errorf("Wait db account %s got an error: %#v.", rds.Available, err)
 	}
 
@@ -122,7 +123,7 @@ func resourceAlicloudDBAccountUpdate(d *schema.ResourceData, meta interface{}) e
 
 	if d.HasChange("description") && !d.IsNewResource() {
 
-		if err := meta.(*AliyunClient).rdsconn.ModifyAccountDescription(&rds.ModifyAccountDescriptionArgs{
+		if err := meta.(*AliyunClient).rdsConn().ModifyAccountDescription(&rds.ModifyAccountDescriptionArgs{
 			DBInstanceId:       instanceId,
 			AccountName:        accountName,
 			AccountDescription: d.Get("description").(string),
@@ -133,7 +134,7 @@ func resourceAlicloudDBAccountUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("password") && !d.IsNewResource() {
-		if _, err := client.rdsconn.ResetAccountPassword(instanceId, accountName, d.Get("password").(string)); err != nil {
+		if _, err := client.rdsConn().ResetAccountPassword(instanceId, accountName, d.Get("password").(string)); err != nil {
 			return fmt.Errorf("Error reset db account password error: %#v", err)
 		}
 		d.SetPartial("password")
@@ -144,19 +145,20 @@ func resourceAlicloudDBAccountUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudDBAccountDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
 	parts := strings.Split(d.Id(), COLON_SEPARATED)
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
-		if _, err := meta.(*AliyunClient).rdsconn.DeleteAccount(parts[0], parts[1]); err != nil {
-			if IsExceptedError(err, InvalidAccountNameNotFound) {
+		if _, err := client.rdsConn().DeleteAccount(parts[0], parts[1]); err != nil {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.RetryableError(fmt.Errorf("Delete database account got an error: %#v.", err))
 		}
 
-		resp, err := meta.(*AliyunClient).DescribeDatabaseAccount(parts[0], parts[1])
+		resp, err := client.DescribeDatabaseAccount(parts[0], parts[1])
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidAccountNameNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
@@ -133,7 +133,7 @@ func resourceAlicloudDBAccountPrivilegeDelete(d *schema.ResourceData, meta inter
 
 	account, err := client.DescribeDatabaseAccount(parts[0], parts[1])
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidAccountNameNotFound) {
+		if NotFoundError(err) {
 			return nil
 		}
 		return fmt.Errorf("Describe db account got an error: %#v", err)
@@ -151,7 +151,7 @@ func resourceAlicloudDBAccountPrivilegeDelete(d *schema.ResourceData, meta inter
 		}
 		account, err := client.DescribeDatabaseAccount(parts[0], parts[1])
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidAccountNameNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Describe db account got an error: %#v", err))
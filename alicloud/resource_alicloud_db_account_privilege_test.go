@@ -81,7 +81,7 @@ func testAccCheckDBAccountPrivilegeDestroy(s *terraform.State) error {
 
 		// Verify the error is what we want
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidAccountNameNotFound) {
+			if NotFoundError(err) {
 				continue
 			}
 			return err
@@ -106,12 +106,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+ 	name = "tf_test_foo"
  	vpc_id = "${alicloud_vpc.foo.id}"
  	cidr_block = "172.16.0.0/21"
  	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_db_instance" "instance" {
+	instance_name = "tf_test_instance"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -77,11 +77,12 @@ func resourceAlicloudDBBackupPolicyCreate(d *schema.ResourceData, meta interface
 
 func resourceAlicloudDBBackupPolicyRead(d *schema.ResourceData, meta interface{}) error {
 
-	resp, err := meta.(*AliyunClient).rdsconn.DescribeBackupPolicy(&rds.DescribeBackupPolicyArgs{
+	client := meta.(*AliyunClient)
+	resp, err := client.rdsConn().DescribeBackupPolicy(&rds.DescribeBackupPolicyArgs{
 		DBInstanceId: d.Id(),
 	})
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -100,6 +101,7 @@ func resourceAlicloudDBBackupPolicyRead(d *schema.ResourceData, meta interface{}
 
 func resourceAlicloudDBBackupPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	d.Partial(true)
 
 	update := false
@@ -147,8 +149,8 @@ func resourceAlicloudDBBackupPolicyUpdate(d *schema.ResourceData, meta interface
 	if update {
 		err := resource.Retry(3*time.Minute, func() *resource.RetryError {
 			ag := args
-			if _, err := meta.(*AliyunClient).rdsconn.ModifyBackupPolicy(&ag); err != nil {
-				if IsExceptedError(err, OperationDeniedDBInstanceStatus) {
+			if _, err := client.rdsConn().ModifyBackupPolicy(&ag); err != nil {
+				if IsConflictError(err) {
 					return resource.RetryableError(fmt.Errorf("ModifyBackupPolicy got an error: %#v.", err))
 				}
 				return resource.NonRetryableError(fmt.Errorf("ModifyBackupPolicy got an error: %#v.", err))
@@ -168,6 +170,7 @@ func resourceAlicloudDBBackupPolicyUpdate(d *schema.ResourceData, meta interface
 
 func resourceAlicloudDBBackupPolicyDelete(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	args := &rds.ModifyBackupPolicyArgs{
 		DBInstanceId: d.Id(),
 	}
@@ -178,7 +181,7 @@ func resourceAlicloudDBBackupPolicyDelete(d *schema.ResourceData, meta interface
 	args.LogBackupRetentionPeriod = "7"
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
-		if _, err := meta.(*AliyunClient).rdsconn.ModifyBackupPolicy(args); err != nil {
+		if _, err := client.rdsConn().ModifyBackupPolicy(args); err != nil {
 			return resource.RetryableError(fmt.Errorf("ModifyBackupPolicy got an error: %#v", err))
 		}
 
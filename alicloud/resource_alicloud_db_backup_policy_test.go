@@ -49,7 +49,7 @@ func testAccCheckDBBackupPolicyExists(n string, d *rds.DescribeBackupPolicyRespo
 
 		client := testAccProvider.Meta().(*AliyunClient)
 
-		resp, err := client.rdsconn.DescribeBackupPolicy(&rds.DescribeBackupPolicyArgs{
+		resp, err := client.rdsConn().DescribeBackupPolicy(&rds.DescribeBackupPolicyArgs{
 			DBInstanceId: rs.Primary.ID,
 		})
 		if err != nil {
@@ -74,11 +74,11 @@ func testAccCheckDBBackupPolicyDestroy(s *terraform.State) error {
 			continue
 		}
 
-		_, err := client.rdsconn.DescribeBackupPolicy(&rds.DescribeBackupPolicyArgs{
+		_, err := client.rdsConn().DescribeBackupPolicy(&rds.DescribeBackupPolicyArgs{
 			DBInstanceId: rs.Primary.ID,
 		})
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+			if NotFoundError(err) {
 				continue
 			}
 			return fmt.Errorf("Error Describe DB backup policy: %#v", err)
@@ -99,12 +99,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+ 	name = "tf_test_foo"
  	vpc_id = "${alicloud_vpc.foo.id}"
  	cidr_block = "172.16.0.0/21"
  	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_db_instance" "instance" {
+	instance_name = "tf_test_instance"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -78,7 +78,7 @@ func resourceAlicloudDBConnectionRead(d *schema.ResourceData, meta interface{})
 	conn, err := meta.(*AliyunClient).DescribeDBInstanceNetInfoByIpType(parts[0], rds.Public)
 
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidCurrentConnectionStringNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -95,6 +95,7 @@ func resourceAlicloudDBConnectionRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAlicloudDBConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
 	d.Partial(true)
 
 	parts := strings.Split(d.Id(), COLON_SEPARATED)
@@ -118,10 +119,10 @@ func resourceAlicloudDBConnectionUpdate(d *schema.ResourceData, meta interface{}
 	}
 
 	if update {
-		if err := meta.(*AliyunClient).rdsconn.ModifyDBInstanceConnectionString(args); err != nil {
+		if err := client.rdsConn().ModifyDBInstanceConnectionString(args); err != nil {
 			return fmt.Errorf("ModifyDBInstanceConnectionString got an error: %#v", err)
 		}
-		connection, err := meta.(*AliyunClient).DescribeDBInstanceNetInfoByIpType(args.DBInstanceId, rds.Public)
+		connection, err := client.DescribeDBInstanceNetInfoByIpType(args.DBInstanceId, rds.Public)
 		if err != nil {
 			return err
 		}
@@ -140,7 +141,7 @@ func resourceAlicloudDBConnectionDelete(d *schema.ResourceData, meta interface{}
 		err := client.ReleaseDBPublicConnection(parts[0], parts[1])
 
 		if err != nil {
-			if IsExceptedError(err, InvalidCurrentConnectionStringNotFound) || IsExceptedError(err, AtLeastOneNetTypeExists) {
+			if NotFoundError(err) || IsExceptedError(err, AtLeastOneNetTypeExists) {
 				return nil
 			}
 			return resource.RetryableError(fmt.Errorf("Release DB connection timeout and got an error: %#v.", err))
@@ -148,7 +149,7 @@ func resourceAlicloudDBConnectionDelete(d *schema.ResourceData, meta interface{}
 		conn, err := meta.(*AliyunClient).DescribeDBInstanceNetInfoByIpType(parts[0], rds.Public)
 
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidCurrentConnectionStringNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Release DB connection got an error: %#v.", err))
@@ -51,6 +51,7 @@ func resourceAlicloudDBDatabase() *schema.Resource {
 
 func resourceAlicloudDBDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	args := rds.CreateDatabaseArgs{
 		DBInstanceId:     d.Get("instance_id").(string),
 		DBName:           d.Get("name").(string),
@@ -62,8 +63,8 @@ func resourceAlicloudDBDatabaseCreate(d *schema.ResourceData, meta interface{})
 
 	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
 		ag := args
-		if _, err := meta.(*AliyunClient).rdsconn.CreateDatabase(&ag); err != nil {
-			if IsExceptedError(err, OperationDeniedDBInstanceStatus) {
+		if _, err := client.rdsConn().CreateDatabase(&ag); err != nil {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Create database got an error: %#v.", err))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Create database got an error: %#v.", err))
@@ -85,7 +86,7 @@ func resourceAlicloudDBDatabaseRead(d *schema.ResourceData, meta interface{}) er
 	parts := strings.Split(d.Id(), COLON_SEPARATED)
 	db, err := meta.(*AliyunClient).DescribeDatabaseByName(parts[0], parts[1])
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidDBNameNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -102,11 +103,12 @@ func resourceAlicloudDBDatabaseRead(d *schema.ResourceData, meta interface{}) er
 
 func resourceAlicloudDBDatabaseUpdate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	d.Partial(true)
 
 	if d.HasChange("description") && !d.IsNewResource() {
 		parts := strings.Split(d.Id(), COLON_SEPARATED)
-		if err := meta.(*AliyunClient).rdsconn.ModifyDatabaseDescription(&rds.ModifyDatabaseDescriptionArgs{
+		if err := client.rdsConn().ModifyDatabaseDescription(&rds.ModifyDatabaseDescriptionArgs{
 			DBInstanceId:  parts[0],
 			DBName:        parts[1],
 			DBDescription: d.Get("description").(string),
@@ -121,21 +123,22 @@ func resourceAlicloudDBDatabaseUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAlicloudDBDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).rdsconn
+	client := meta.(*AliyunClient)
+	conn := client.rdsConn()
 	parts := strings.Split(d.Id(), COLON_SEPARATED)
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := conn.DeleteDatabase(parts[0], parts[1])
 
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBNameNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.RetryableError(fmt.Errorf("Delete database %s timeout and got an error: %#v.", parts[1], err))
 		}
 
-		db, err := meta.(*AliyunClient).DescribeDatabaseByName(parts[0], parts[1])
+		db, err := client.DescribeDatabaseByName(parts[0], parts[1])
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBNameNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error Describe DB InstanceAttribute: %#v", err))
@@ -80,7 +80,7 @@ func testAccCheckDBDatabaseDestroy(s *terraform.State) error {
 		// Verify the error is what we want
 		if err != nil {
 			// Verify the error is what we want
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBNameNotFound) {
+			if NotFoundError(err) {
 				continue
 			}
 			return err
@@ -105,12 +105,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+ 	name = "tf_test_foo"
  	vpc_id = "${alicloud_vpc.foo.id}"
  	cidr_block = "172.16.0.0/21"
  	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_db_instance" "instance" {
+	instance_name = "tf_test_instance"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
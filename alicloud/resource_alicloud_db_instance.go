@@ -17,14 +17,24 @@ import (
 
 func resourceAlicloudDBInstance() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAlicloudDBInstanceCreate,
-		Read:   resourceAlicloudDBInstanceRead,
-		Update: resourceAlicloudDBInstanceUpdate,
-		Delete: resourceAlicloudDBInstanceDelete,
+		Create:        resourceAlicloudDBInstanceCreate,
+		Read:          resourceAlicloudDBInstanceRead,
+		Update:        resourceAlicloudDBInstanceUpdate,
+		Delete:        resourceAlicloudDBInstanceDelete,
+		CustomizeDiff: dbInstanceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		SchemaVersion: 1,
+		MigrateState:  resourceAlicloudDBInstanceMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"engine": &schema.Schema{
 				Type:         schema.TypeString,
@@ -58,6 +68,13 @@ func resourceAlicloudDBInstance() *schema.Resource {
 				Required: true,
 			},
 
+			"instance_name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+
 			"instance_charge_type": &schema.Schema{
 				Type:         schema.TypeString,
 				ValidateFunc: validateAllowedStringValue([]string{string(rds.Postpaid), string(rds.Prepaid)}),
@@ -218,13 +235,14 @@ func resourceAlicloudDBInstance() *schema.Resource {
 				},
 				Deprecated: "Field 'db_mappings' has been deprecated from provider version 1.5.0. New resource 'alicloud_db_database' replaces it.",
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAlicloudDBInstanceCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*AliyunClient)
-	conn := client.rdsconn
+	conn := meta.(*AliyunClient).rdsConn()
 
 	args, err := buildDBCreateOrderArgs(d, meta)
 	if err != nil {
@@ -240,7 +258,7 @@ func resourceAlicloudDBInstanceCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(resp.DBInstanceId)
 
 	// wait instance status change from Creating to running
-	if err := conn.WaitForInstanceAsyn(d.Id(), rds.Running, defaultLongTimeout); err != nil {
+	if err := conn.WaitForInstanceAsyn(d.Id(), rds.Running, timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("WaitForInstance %s got error: %#v", rds.Running, err)
 	}
 
@@ -249,9 +267,24 @@ func resourceAlicloudDBInstanceCreate(d *schema.ResourceData, meta interface{})
 
 func resourceAlicloudDBInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.rdsconn
+	conn := client.rdsConn()
 	d.Partial(true)
 
+	if err := setTags(client, rdsTags{}, d); err != nil {
+		return fmt.Errorf("Set tags for DB instance got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
+	if d.HasChange("instance_name") {
+		if err := conn.ModifyDBInstanceDescription(&rds.ModifyDBInstanceDescriptionArgs{
+			DBInstanceId:          d.Id(),
+			DBInstanceDescription: d.Get("instance_name").(string),
+		}); err != nil {
+			return fmt.Errorf("ModifyDBInstanceDescription got an error: %#v", err)
+		}
+		d.SetPartial("instance_name")
+	}
+
 	if d.HasChange("security_ips") {
 		ipList := expandStringList(d.Get("security_ips").([]interface{}))
 
@@ -289,10 +322,10 @@ func resourceAlicloudDBInstanceUpdate(d *schema.ResourceData, meta interface{})
 		if _, err := conn.ModifyDBInstanceSpec(&args); err != nil {
 			return err
 		}
-		//// wait instance status change from Creating to running
-		//if err := conn.WaitForInstanceAsyn(d.Id(), rds.Running, defaultLongTimeout); err != nil {
-		//	return fmt.Errorf("WaitForInstance %s got error: %#v", rds.Running, err)
-		//}
+		// wait instance status change from DBInstanceClassChanging to running
+		if err := conn.WaitForInstanceAsyn(d.Id(), rds.Running, timeoutInSeconds(d, schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("WaitForInstance %s got error: %#v", rds.Running, err)
+		}
 	}
 
 	d.Partial(false)
@@ -304,7 +337,7 @@ func resourceAlicloudDBInstanceRead(d *schema.ResourceData, meta interface{}) er
 
 	instance, err := client.DescribeDBInstanceById(d.Id())
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -322,12 +355,17 @@ func resourceAlicloudDBInstanceRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("instance_type", instance.DBInstanceClass)
 	d.Set("port", instance.Port)
 	d.Set("instance_storage", instance.DBInstanceStorage)
+	d.Set("instance_name", instance.DBInstanceDescription)
 	d.Set("zone_id", instance.ZoneId)
 	d.Set("instance_charge_type", instance.PayType)
 	d.Set("period", d.Get("period"))
 	d.Set("vswitch_id", instance.VSwitchId)
 	d.Set("connection_string", instance.ConnectionString)
 
+	if err := readTags(client, rdsTags{}, d); err != nil {
+		return fmt.Errorf("Describe tags for DB instance got error: %#v", err)
+	}
+
 	return nil
 }
 
@@ -336,7 +374,7 @@ func resourceAlicloudDBInstanceDelete(d *schema.ResourceData, meta interface{})
 
 	instance, err := client.DescribeDBInstanceById(d.Id())
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+		if NotFoundError(err) {
 			return nil
 		}
 		return fmt.Errorf("Error Describe DB InstanceAttribute: %#v", err)
@@ -344,11 +382,11 @@ func resourceAlicloudDBInstanceDelete(d *schema.ResourceData, meta interface{})
 	if instance.PayType == rds.Prepaid {
 		return fmt.Errorf("At present, 'Prepaid' instance cannot be deleted and must wait it to be expired and release it automatically.")
 	}
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
-		err := client.rdsconn.DeleteInstance(d.Id())
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := client.rdsConn().DeleteInstance(d.Id())
 
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.RetryableError(fmt.Errorf("Delete DB instance timeout and got an error: %#v.", err))
@@ -356,7 +394,7 @@ func resourceAlicloudDBInstanceDelete(d *schema.ResourceData, meta interface{})
 
 		instance, err := client.DescribeDBInstanceById(d.Id())
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error Describe DB InstanceAttribute: %#v", err))
@@ -11,6 +11,54 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_db_instance", &resource.Sweeper{
+		Name: "alicloud_db_instance",
+		F:    testSweepDBInstances,
+	})
+}
+
+func testSweepDBInstances(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var instances []rds.DBInstanceAttribute
+	args := &rds.DescribeDBInstancesArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		resp, err := client.rdsConn().DescribeDBInstances(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving RDS Instances: %#v", err)
+		}
+		instances = append(instances, resp.Items.DBInstance...)
+		next := resp.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range instances {
+		if !isTestSweepName(v.DBInstanceDescription) {
+			log.Printf("[INFO] Skipping RDS Instance: %s (%s)", v.DBInstanceDescription, v.DBInstanceId)
+			continue
+		}
+		if v.PayType == rds.Prepaid {
+			log.Printf("[INFO] Skipping Prepaid RDS Instance: %s (%s)", v.DBInstanceDescription, v.DBInstanceId)
+			continue
+		}
+		log.Printf("[INFO] Deleting RDS Instance: %s (%s)", v.DBInstanceDescription, v.DBInstanceId)
+		if err := testSweepResource(resourceAlicloudDBInstance(), client, v.DBInstanceId, nil); err != nil {
+			log.Printf("[ERROR] Failed to delete RDS Instance (%s (%s)): %s", v.DBInstanceDescription, v.DBInstanceId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudDBInstance_basic(t *testing.T) {
 	var instance rds.DBInstanceAttribute
 
@@ -186,6 +234,56 @@ func TestAccAlicloudDBInstance_upgradeClass(t *testing.T) {
 
 }
 
+func TestUnitAlicloudDBInstance_update(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_db_instance.foo",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.dbInstances) > 0 {
+				return fmt.Errorf("DB instances still exist: %d", len(server.dbInstances))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitDBInstanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "instance_name", "tf_test_foo"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "instance_type", "rds.mysql.t1.small"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "instance_storage", "10"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "tags.env", "test"),
+					resource.TestCheckResourceAttrSet("alicloud_db_instance.foo", "connection_string"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitDBInstanceConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "instance_name", "tf_test_bar"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "instance_type", "rds.mysql.s1.small"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "instance_storage", "20"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "security_ips.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_db_instance.foo", "tags.team", "rds"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudDBInstance_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitDBInstanceConfigInvalid, []fakeInvalidPlan{
+		{`engine_version = "5.6"
+  period = 3`, "'period' is only supported when the instance_charge_type is Prepaid"},
+		{`engine_version = "9.4"`, "'engine_version' of MySQL must be one of [5.5 5.6 5.7], got 9.4"},
+	})
+}
+
 func testAccCheckSecurityIpExists(n string, ips []map[string]interface{}) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -197,7 +295,7 @@ func testAccCheckSecurityIpExists(n string, ips []map[string]interface{}) resour
 			return fmt.Errorf("No DB Instance ID is set")
 		}
 
-		conn := testAccProvider.Meta().(*AliyunClient).rdsconn
+		conn := testAccProvider.Meta().(*AliyunClient).rdsConn()
 		args := rds.DescribeDBInstanceIPsArgs{
 			DBInstanceId: rs.Primary.ID,
 		}
@@ -284,7 +382,7 @@ func testAccCheckDBInstanceDestroy(s *terraform.State) error {
 
 		// Verify the error is what we want
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidDBInstanceNameNotFound) {
+			if NotFoundError(err) {
 				continue
 			}
 			return err
@@ -296,6 +394,7 @@ func testAccCheckDBInstanceDestroy(s *terraform.State) error {
 
 const testAccDBInstanceConfig = `
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -315,12 +414,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+ 	name = "tf_test_foo"
  	vpc_id = "${alicloud_vpc.foo.id}"
  	cidr_block = "172.16.0.0/21"
  	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -333,6 +434,7 @@ resource "alicloud_db_instance" "foo" {
 `
 const testAccDBInstance_multiAZ = `
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -343,6 +445,7 @@ resource "alicloud_db_instance" "foo" {
 
 const testAccDBInstance_securityIps = `
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -352,6 +455,7 @@ resource "alicloud_db_instance" "foo" {
 `
 const testAccDBInstance_securityIpsUpdate = `
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -364,6 +468,7 @@ resource "alicloud_db_instance" "foo" {
 
 const testAccDBInstance_class = `
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.t1.small"
@@ -372,9 +477,48 @@ resource "alicloud_db_instance" "foo" {
 `
 const testAccDBInstance_classUpgrade = `
 resource "alicloud_db_instance" "foo" {
+	instance_name = "tf_test_foo"
 	engine = "MySQL"
 	engine_version = "5.6"
 	instance_type = "rds.mysql.s1.small"
 	instance_storage = "10"
 }
 `
+
+const testUnitDBInstanceConfig = `
+resource "alicloud_db_instance" "foo" {
+  instance_name = "tf_test_foo"
+  engine = "MySQL"
+  engine_version = "5.6"
+  instance_type = "rds.mysql.t1.small"
+  instance_storage = "10"
+  instance_charge_type = "Postpaid"
+  tags = {
+    env = "test"
+  }
+}
+`
+
+const testUnitDBInstanceConfigUpdate = `
+resource "alicloud_db_instance" "foo" {
+  instance_name = "tf_test_bar"
+  engine = "MySQL"
+  engine_version = "5.6"
+  instance_type = "rds.mysql.s1.small"
+  instance_storage = "20"
+  instance_charge_type = "Postpaid"
+  security_ips = ["10.168.1.12", "100.69.7.112"]
+  tags = {
+    team = "rds"
+  }
+}
+`
+
+const testUnitDBInstanceConfigInvalid = `
+resource "alicloud_db_instance" "invalid" {
+  engine = "MySQL"
+  instance_type = "rds.mysql.t1.small"
+  instance_storage = "10"
+  %s
+}
+`
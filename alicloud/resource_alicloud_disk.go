@@ -13,14 +13,20 @@ import (
 
 func resourceAliyunDisk() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunDiskCreate,
-		Read:   resourceAliyunDiskRead,
-		Update: resourceAliyunDiskUpdate,
-		Delete: resourceAliyunDiskDelete,
+		Create:        resourceAliyunDiskCreate,
+		Read:          resourceAliyunDiskRead,
+		Update:        resourceAliyunDiskUpdate,
+		Delete:        resourceAliyunDiskDelete,
+		CustomizeDiff: diskCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"availability_zone": &schema.Schema{
 				Type:     schema.TypeString,
@@ -70,7 +76,7 @@ func resourceAliyunDisk() *schema.Resource {
 func resourceAliyunDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
 
-	conn := client.ecsconn
+	conn := client.ecsConn()
 
 	availabilityZone, err := client.DescribeZone(d.Get("availability_zone").(string))
 	if err != nil {
@@ -92,13 +98,8 @@ func resourceAliyunDiskCreate(d *schema.ResourceData, meta interface{}) error {
 
 	if v, ok := d.GetOk("size"); ok {
 		size := v.(int)
-		if args.DiskCategory == ecs.DiskCategoryCloud && (size < 5 || size > 2000) {
-			return fmt.Errorf("the size of cloud disk must between 5 to 2000")
-		}
-
-		if (args.DiskCategory == ecs.DiskCategoryCloudEfficiency ||
-			args.DiskCategory == ecs.DiskCategoryCloudSSD) && (size < 20 || size > 32768) {
-			return fmt.Errorf("the size of %s disk must between 20 to 32768", args.DiskCategory)
+		if err := validateDiskSize(args.DiskCategory, size); err != nil {
+			return err
 		}
 		args.Size = size
 
@@ -128,11 +129,16 @@ func resourceAliyunDiskCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(diskID)
 
+	if err := conn.WaitForDisk(getRegion(d, meta), d.Id(), ecs.DiskStatusAvailable, timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("WaitForDisk %s got error: %#v", ecs.DiskStatusAvailable, err)
+	}
+
 	return resourceAliyunDiskUpdate(d, meta)
 }
 
 func resourceAliyunDiskRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	disks, _, err := conn.DescribeDisks(&ecs.DescribeDisksArgs{
 		RegionId: getRegion(d, meta),
@@ -162,28 +168,20 @@ func resourceAliyunDiskRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("description", disk.Description)
 	d.Set("snapshot_id", disk.SourceSnapshotId)
 
-	tags, _, err := conn.DescribeTags(&ecs.DescribeTagsArgs{
-		RegionId:     getRegion(d, meta),
-		ResourceType: ecs.TagResourceDisk,
-		ResourceId:   d.Id(),
-	})
-
-	if err != nil {
+	if err := readTags(client, ecsTags(ecs.TagResourceDisk), d); err != nil {
 		log.Printf("[DEBUG] DescribeTags for disk got error: %#v", err)
 	}
 
-	d.Set("tags", tagsToMap(tags))
-
 	return nil
 }
 
 func resourceAliyunDiskUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.ecsconn
+	conn := client.ecsConn()
 
 	d.Partial(true)
 
-	if err := setTags(client, ecs.TagResourceDisk, d); err != nil {
+	if err := setTags(client, ecsTags(ecs.TagResourceDisk), d); err != nil {
 		log.Printf("[DEBUG] Set tags for instance got error: %#v", err)
 		return fmt.Errorf("Set tags for instance got error: %#v", err)
 	} else {
@@ -221,9 +219,9 @@ func resourceAliyunDiskUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAliyunDiskDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		err := conn.DeleteDisk(d.Id())
 		if err != nil {
 			e, _ := err.(*common.Error)
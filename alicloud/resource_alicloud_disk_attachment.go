@@ -13,9 +13,10 @@ import (
 
 func resourceAliyunDiskAttachment() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunDiskAttachmentCreate,
-		Read:   resourceAliyunDiskAttachmentRead,
-		Delete: resourceAliyunDiskAttachmentDelete,
+		Create:   resourceAliyunDiskAttachmentCreate,
+		Read:     resourceAliyunDiskAttachmentRead,
+		Delete:   resourceAliyunDiskAttachmentDelete,
+		Importer: importByCompositeId("disk_id", "instance_id"),
 
 		Schema: map[string]*schema.Schema{
 			"instance_id": &schema.Schema{
@@ -53,12 +54,13 @@ func resourceAliyunDiskAttachmentCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAliyunDiskAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
 	diskId, instanceId, err := getDiskIDAndInstanceID(d, meta)
 	if err != nil {
 		return err
 	}
 
-	conn := meta.(*AliyunClient).ecsconn
+	conn := client.ecsConn()
 	disks, _, err := conn.DescribeDisks(&ecs.DescribeDisksArgs{
 		RegionId:   getRegion(d, meta),
 		InstanceId: instanceId,
@@ -87,7 +89,7 @@ func resourceAliyunDiskAttachmentRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAliyunDiskAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	diskID, instanceID, err := getDiskIDAndInstanceID(d, meta)
 	if err != nil {
 		return err
@@ -96,8 +98,7 @@ func resourceAliyunDiskAttachmentDelete(d *schema.ResourceData, meta interface{}
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := conn.DetachDisk(instanceID, diskID)
 		if err != nil {
-			if IsExceptedError(err, DiskIncorrectStatus) || IsExceptedError(err, InstanceLockedForSecurity) ||
-				IsExceptedError(err, DiskInvalidOperation) {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Detach Disk timeout and got an error: %#v", err))
 			}
 		}
@@ -130,7 +131,7 @@ func getDiskIDAndInstanceID(d *schema.ResourceData, meta interface{}) (string, s
 	return parts[0], parts[1], nil
 }
 func diskAttachment(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	diskID := d.Get("disk_id").(string)
 	instanceID := d.Get("instance_id").(string)
@@ -145,9 +146,7 @@ func diskAttachment(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("error : %s", err)
 
 		if err != nil {
-			if IsExceptedError(err, DiskIncorrectStatus) || IsExceptedError(err, InstanceIncorrectStatus) ||
-				IsExceptedError(err, DiskOperationConflict) || IsExceptedError(err, DiskInternalError) ||
-				IsExceptedError(err, DiskInvalidOperation) {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Attach Disk timeout and got an error: %#v", err))
 			}
 			return resource.NonRetryableError(err)
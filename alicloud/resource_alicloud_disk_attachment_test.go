@@ -105,7 +105,7 @@ func testAccCheckDiskAttachmentExists(n string, instance *ecs.InstanceAttributes
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		request := &ecs.DescribeDisksArgs{
 			RegionId: client.Region,
@@ -142,7 +142,7 @@ func testAccCheckDiskAttachmentDestroy(s *terraform.State) error {
 		}
 		// Try to find the Disk
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		request := &ecs.DescribeDisksArgs{
 			RegionId: client.Region,
@@ -181,7 +181,7 @@ resource "alicloud_instance" "instance" {
   instance_type = "ecs.n4.small"
   availability_zone = "cn-beijing-a"
   security_groups = ["${alicloud_security_group.group.id}"]
-  instance_name = "hello"
+  instance_name = "tf_test_hello"
   internet_charge_type = "PayByBandwidth"
 
   tags {
@@ -195,7 +195,7 @@ resource "alicloud_disk_attachment" "disk-att" {
 }
 
 resource "alicloud_security_group" "group" {
-  name = "terraform-test-group"
+  name = "tf-test-group"
   description = "New security group"
 }
 `
@@ -220,7 +220,7 @@ resource "alicloud_instance" "instance" {
   instance_type = "ecs.n4.small"
   availability_zone = "cn-beijing-a"
   security_groups = ["${alicloud_security_group.group.id}"]
-  instance_name = "hello"
+  instance_name = "tf_test_hello"
   internet_charge_type = "PayByBandwidth"
 
   tags {
@@ -235,7 +235,7 @@ resource "alicloud_disk_attachment" "disks-attach" {
 }
 
 resource "alicloud_security_group" "group" {
-  name = "terraform-test-group"
+  name = "tf-test-group"
   description = "New security group"
 }
 `
@@ -72,6 +72,16 @@ func TestAccAlicloudDisk_withTags(t *testing.T) {
 	})
 }
 
+func TestUnitAlicloudDisk_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitDiskConfigInvalid, []fakeInvalidPlan{
+		{`category = "cloud_efficiency"`, "One of size or snapshot_id is required"},
+		{`category = "cloud"
+  size = 4000`, "the size of cloud disk must between 5 to 2000"},
+		{`category = "cloud_ssd"
+  size = 10`, "the size of cloud_ssd disk must between 20 to 32768"},
+	})
+}
+
 func testAccCheckDiskExists(n string, disk *ecs.DiskItemType) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -84,7 +94,7 @@ func testAccCheckDiskExists(n string, disk *ecs.DiskItemType) resource.TestCheck
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		request := &ecs.DescribeDisksArgs{
 			RegionId: client.Region,
@@ -113,7 +123,7 @@ func testAccCheckDiskDestroy(s *terraform.State) error {
 
 		// Try to find the Disk
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		request := &ecs.DescribeDisksArgs{
 			RegionId: client.Region,
@@ -164,3 +174,10 @@ resource "alicloud_disk" "bar" {
         }
 }
 `
+
+const testUnitDiskConfigInvalid = `
+resource "alicloud_disk" "invalid" {
+  availability_zone = "cn-beijing-b"
+  %s
+}
+`
@@ -42,7 +42,7 @@ func resourceAlicloudDns() *schema.Resource {
 }
 
 func resourceAlicloudDnsCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.AddDomainArgs{
 		DomainName: d.Get("name").(string),
@@ -58,7 +58,7 @@ func resourceAlicloudDnsCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAlicloudDnsUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	d.Partial(true)
 
@@ -81,7 +81,7 @@ func resourceAlicloudDnsUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAlicloudDnsRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DescribeDomainInfoArgs{
 		DomainName: d.Id(),
@@ -103,7 +103,7 @@ func resourceAlicloudDnsRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAlicloudDnsDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DeleteDomainArgs{
 		DomainName: d.Id(),
@@ -27,7 +27,7 @@ func resourceAlicloudDnsGroup() *schema.Resource {
 }
 
 func resourceAlicloudDnsGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 	args := &dns.AddDomainGroupArgs{
 		GroupName: d.Get("name").(string),
 	}
@@ -43,7 +43,7 @@ func resourceAlicloudDnsGroupCreate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAlicloudDnsGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	d.Partial(true)
 	args := &dns.UpdateDomainGroupArgs{
@@ -63,7 +63,7 @@ func resourceAlicloudDnsGroupUpdate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAlicloudDnsGroupRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DescribeDomainGroupsArgs{
 		KeyWord: d.Get("name").(string),
@@ -89,7 +89,7 @@ func resourceAlicloudDnsGroupRead(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAlicloudDnsGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DeleteDomainGroupArgs{
 		GroupId: d.Id(),
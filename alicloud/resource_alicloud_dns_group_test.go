@@ -52,7 +52,7 @@ func testAccCheckDnsGroupExists(n string, group *dns.DomainGroupType) resource.T
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.dnsconn
+		conn := client.dnsConn()
 
 		request := &dns.DescribeDomainGroupsArgs{
 			KeyWord: rs.Primary.Attributes["name"],
@@ -80,7 +80,7 @@ func testAccCheckDnsGroupDestroy(s *terraform.State) error {
 
 		// Try to find the domain group
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.dnsconn
+		conn := client.dnsConn()
 
 		request := &dns.DescribeDomainGroupsArgs{
 			KeyWord: rs.Primary.Attributes["name"],
@@ -69,7 +69,7 @@ func resourceAlicloudDnsRecord() *schema.Resource {
 }
 
 func resourceAlicloudDnsRecordCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.AddDomainRecordArgs{
 		DomainName: d.Get("name").(string),
@@ -96,7 +96,7 @@ func resourceAlicloudDnsRecordCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudDnsRecordUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	d.Partial(true)
 	attributeUpdate := false
@@ -146,7 +146,7 @@ func resourceAlicloudDnsRecordUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudDnsRecordRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 
 	args := &dns.DescribeDomainRecordInfoNewArgs{
 		RecordId: d.Id(),
@@ -175,7 +175,7 @@ func resourceAlicloudDnsRecordRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudDnsRecordDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).dnsconn
+	conn := meta.(*AliyunClient).dnsConn()
 	args := &dns.DeleteDomainRecordArgs{
 		RecordId: d.Id(),
 	}
@@ -193,7 +193,7 @@ func resourceAlicloudDnsRecordDelete(d *schema.ResourceData, meta interface{}) e
 			RecordId: d.Id(),
 		})
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, DomainRecordNotBelongToUser) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Describe domain record got an error: %#v.", err))
@@ -52,7 +52,7 @@ func testAccCheckDnsRecordExists(n string, record *dns.RecordTypeNew) resource.T
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.dnsconn
+		conn := client.dnsConn()
 
 		request := &dns.DescribeDomainRecordInfoNewArgs{
 			RecordId: rs.Primary.ID,
@@ -108,7 +108,7 @@ func testAccCheckDnsRecordDestroy(s *terraform.State) error {
 
 		// Try to find the domain record
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.dnsconn
+		conn := client.dnsConn()
 
 		request := &dns.DescribeDomainRecordInfoNewArgs{
 			RecordId: rs.Primary.ID,
@@ -53,7 +53,7 @@ func testAccCheckDnsExists(n string, domain *dns.DomainType) resource.TestCheckF
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.dnsconn
+		conn := client.dnsConn()
 
 		request := &dns.DescribeDomainInfoArgs{
 			DomainName: rs.Primary.Attributes["name"],
@@ -79,7 +79,7 @@ func testAccCheckDnsDestroy(s *terraform.State) error {
 
 		// Try to find the domain
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.dnsconn
+		conn := client.dnsConn()
 
 		request := &dns.DescribeDomainInfoArgs{
 			DomainName: rs.Primary.Attributes["name"],
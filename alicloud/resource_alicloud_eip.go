@@ -50,12 +50,14 @@ func resourceAliyunEip() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAliyunEipCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	args, err := buildAliyunEipArgs(d, meta)
 	if err != nil {
@@ -102,15 +104,25 @@ func resourceAliyunEipRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("ip_address", eip.IpAddress)
 	d.Set("status", eip.Status)
 
+	if err := readTags(client, VpcTagResourceEip, d); err != nil {
+		return fmt.Errorf("Describe tags for eip got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAliyunEipUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	d.Partial(true)
 
+	if err := setTags(client, VpcTagResourceEip, d); err != nil {
+		return fmt.Errorf("Set tags for eip got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
 	if d.HasChange("bandwidth") && !d.IsNewResource() {
 		err := conn.ModifyEipAddressAttribute(d.Id(), d.Get("bandwidth").(int))
 		if err != nil {
@@ -126,7 +138,7 @@ func resourceAliyunEipUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAliyunEipDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := conn.ReleaseEipAddress(d.Id())
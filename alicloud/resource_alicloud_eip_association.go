@@ -13,9 +13,10 @@ import (
 
 func resourceAliyunEipAssociation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunEipAssociationCreate,
-		Read:   resourceAliyunEipAssociationRead,
-		Delete: resourceAliyunEipAssociationDelete,
+		Create:   resourceAliyunEipAssociationCreate,
+		Read:     resourceAliyunEipAssociationRead,
+		Delete:   resourceAliyunEipAssociationDelete,
+		Importer: importByCompositeId("allocation_id", "instance_id"),
 
 		Schema: map[string]*schema.Schema{
 			"allocation_id": &schema.Schema{
@@ -37,7 +38,7 @@ func resourceAliyunEipAssociation() *schema.Resource {
 
 func resourceAliyunEipAssociationCreate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	allocationId := d.Get("allocation_id").(string)
 	instanceId := d.Get("instance_id").(string)
@@ -85,7 +86,7 @@ func resourceAliyunEipAssociationRead(d *schema.ResourceData, meta interface{})
 
 func resourceAliyunEipAssociationDelete(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	allocationId, instanceId, err := getAllocationIdAndInstanceId(d, meta)
 	if err != nil {
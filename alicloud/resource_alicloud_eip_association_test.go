@@ -87,7 +87,7 @@ func testAccCheckEIPAssociationDestroy(s *terraform.State) error {
 		}
 
 		// Try to find the EIP
-		eips, _, err := client.ecsconn.DescribeEipAddresses(&ecs.DescribeEipAddressesArgs{
+		eips, _, err := client.ecsConn().DescribeEipAddresses(&ecs.DescribeEipAddressesArgs{
 			RegionId:     client.Region,
 			AllocationId: rs.Primary.Attributes["allocation_id"],
 		})
@@ -113,10 +113,12 @@ data "alicloud_zones" "default" {
 }
 
 resource "alicloud_vpc" "main" {
+  name = "tf_test_main"
   cidr_block = "10.1.0.0/21"
 }
 
 resource "alicloud_vswitch" "main" {
+  name = "tf_test_main"
   vpc_id = "${alicloud_vpc.main.id}"
   cidr_block = "10.1.1.0/24"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -134,7 +136,7 @@ resource "alicloud_instance" "instance" {
   system_disk_category = "cloud_efficiency"
 
   security_groups = ["${alicloud_security_group.group.id}"]
-  instance_name = "test_foo"
+  instance_name = "tf_test_foo"
 
   tags {
     Name = "TerraformTest-instance"
@@ -150,7 +152,7 @@ resource "alicloud_eip_association" "foo" {
 }
 
 resource "alicloud_security_group" "group" {
-  name = "terraform-test-group"
+  name = "tf-test-group"
   description = "New security group"
   vpc_id = "${alicloud_vpc.main.id}"
 }
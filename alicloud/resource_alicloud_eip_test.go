@@ -97,7 +97,7 @@ func testAccCheckEIPDestroy(s *terraform.State) error {
 		}
 
 		// Try to find the EIP
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		args := &ecs.DescribeEipAddressesArgs{
 			RegionId:     client.Region,
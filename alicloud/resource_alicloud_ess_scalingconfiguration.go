@@ -14,10 +14,17 @@ import (
 
 func resourceAlicloudEssScalingConfiguration() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunEssScalingConfigurationCreate,
-		Read:   resourceAliyunEssScalingConfigurationRead,
-		Update: resourceAliyunEssScalingConfigurationUpdate,
-		Delete: resourceAliyunEssScalingConfigurationDelete,
+		Create:        resourceAliyunEssScalingConfigurationCreate,
+		Read:          resourceAliyunEssScalingConfigurationRead,
+		Update:        resourceAliyunEssScalingConfigurationUpdate,
+		Delete:        resourceAliyunEssScalingConfigurationDelete,
+		CustomizeDiff: essScalingConfigurationCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		SchemaVersion: 1,
+		MigrateState:  resourceAlicloudEssScalingConfigurationMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"active": &schema.Schema{
@@ -166,7 +173,8 @@ func resourceAlicloudEssScalingConfiguration() *schema.Resource {
 func resourceAliyunEssScalingConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
 
 	// Ensure instance_type is generation three
-	validData, err := meta.(*AliyunClient).CheckParameterValidity(d, meta)
+	client := meta.(*AliyunClient)
+	validData, err := client.CheckParameterValidity(d, meta)
 	if err != nil {
 		return err
 	}
@@ -180,7 +188,7 @@ func resourceAliyunEssScalingConfigurationCreate(d *schema.ResourceData, meta in
 		args.IoOptimized = ecs.IoOptimizedOptimized
 	}
 
-	essconn := meta.(*AliyunClient).essconn
+	essconn := client.essConn()
 
 	scaling, err := essconn.CreateScalingConfiguration(args)
 	if err != nil && !IsExceptedError(err, IncorrectScalingGroupStatus) {
@@ -236,7 +244,7 @@ func resourceAliyunEssScalingConfigurationUpdate(d *schema.ResourceData, meta in
 
 	if d.HasChange("instance_ids") {
 		sgId := d.Get("scaling_group_id").(string)
-		if _, err := client.essconn.EnableScalingGroup(&ess.EnableScalingGroupArgs{
+		if _, err := client.essConn().EnableScalingGroup(&ess.EnableScalingGroupArgs{
 			ScalingGroupId: sgId,
 			InstanceId:     expandStringList(d.Get("instance_ids").([]interface{})),
 		}); err != nil {
@@ -265,7 +273,7 @@ func enableEssScalingConfiguration(d *schema.ResourceData, meta interface{}) err
 		if enable {
 			if group.LifecycleState == ess.Inacitve {
 
-				cs, _, err := client.essconn.DescribeScalingConfigurations(&ess.DescribeScalingConfigurationsArgs{
+				cs, _, err := client.essConn().DescribeScalingConfigurations(&ess.DescribeScalingConfigurationsArgs{
 					RegionId:       getRegion(d, meta),
 					ScalingGroupId: sgId,
 					Pagination:     getPagination(1, 50),
@@ -288,7 +296,7 @@ func enableEssScalingConfiguration(d *schema.ResourceData, meta interface{}) err
 						"Its all scaling configuration are %s.", sgId, strings.Join(csIds, ","))
 				}
 
-				if _, err := client.essconn.EnableScalingGroup(&ess.EnableScalingGroupArgs{
+				if _, err := client.essConn().EnableScalingGroup(&ess.EnableScalingGroupArgs{
 					ScalingGroupId:               sgId,
 					ActiveScalingConfigurationId: activeConfig,
 				}); err != nil {
@@ -299,7 +307,7 @@ func enableEssScalingConfiguration(d *schema.ResourceData, meta interface{}) err
 			}
 		} else {
 			if group.LifecycleState == ess.Active {
-				if _, err := client.essconn.DisableScalingGroup(&ess.DisableScalingGroupArgs{
+				if _, err := client.essConn().DisableScalingGroup(&ess.DisableScalingGroupArgs{
 					ScalingGroupId: sgId,
 				}); err != nil {
 					return fmt.Errorf("DisableScalingGroup %s got an error: %#v", sgId, err)
@@ -342,7 +350,7 @@ func resourceAliyunEssScalingConfigurationRead(d *schema.ResourceData, meta inte
 	d.Set("key_name", c.KeyPairName)
 	d.Set("user_data", userDataHashSum(c.UserData))
 	d.Set("force_delete", d.Get("force_delete").(bool))
-	d.Set("tags", essTagsToMap(c.Tags.Tag))
+	d.Set("tags", removeDefaultTags(client.defaultTags(), essTagsToMap(c.Tags.Tag), d.Get("tags").(map[string]interface{})))
 
 	return nil
 }
@@ -368,7 +376,7 @@ func resourceAliyunEssScalingConfigurationDelete(d *schema.ResourceData, meta in
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 
-		_, err := client.essconn.DeleteScalingConfiguration(&ess.DeleteScalingConfigurationArgs{
+		_, err := client.essConn().DeleteScalingConfiguration(&ess.DeleteScalingConfigurationArgs{
 			ScalingConfigurationId: d.Id(),
 		})
 
@@ -392,7 +400,7 @@ func resourceAliyunEssScalingConfigurationDelete(d *schema.ResourceData, meta in
 			return resource.NonRetryableError(err)
 		}
 
-		instances, _, err := client.essconn.DescribeScalingInstances(&ess.DescribeScalingInstancesArgs{
+		instances, _, err := client.essConn().DescribeScalingInstances(&ess.DescribeScalingInstancesArgs{
 			RegionId:               getRegion(d, meta),
 			ScalingGroupId:         c.ScalingGroupId,
 			ScalingConfigurationId: d.Id(),
@@ -475,9 +483,9 @@ func buildAlicloudEssScalingConfigurationArgs(d *schema.ResourceData, meta inter
 		args.UserData = v.(string)
 	}
 
-	if v, ok := d.GetOk("tags"); ok {
+	if v := mergeDefaultTags(meta.(*AliyunClient).defaultTags(), d.Get("tags").(map[string]interface{})); len(v) > 0 {
 		tags := "{"
-		for key, value := range v.(map[string]interface{}) {
+		for key, value := range v {
 			tags += "\"" + key + "\"" + ":" + "\"" + value.(string) + "\"" + ","
 		}
 		args.Tags = strings.TrimSuffix(tags, ",") + "}"
@@ -495,7 +503,7 @@ func activeSubstituteScalingConfiguration(d *schema.ResourceData, meta interface
 		return nil, fmt.Errorf("DescribeScalingConfigurationById error: %#v", err)
 	}
 
-	cs, _, err := client.essconn.DescribeScalingConfigurations(&ess.DescribeScalingConfigurationsArgs{
+	cs, _, err := client.essConn().DescribeScalingConfigurations(&ess.DescribeScalingConfigurationsArgs{
 		RegionId:       getRegion(d, meta),
 		ScalingGroupId: c.ScalingGroupId,
 	})
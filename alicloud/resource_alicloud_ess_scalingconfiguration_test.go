@@ -42,7 +42,7 @@ func TestAccAlicloudEssScalingConfiguration_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_ess_scaling_configuration.foo",
 						"key_name",
-						"ess_testcase_for_scaling_configuration"),
+						"tf_test_ess_testcase_for_scaling_configuration"),
 				),
 			},
 		},
@@ -83,7 +83,7 @@ func TestAccAlicloudEssScalingConfiguration_multiConfig(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_ess_scaling_configuration.bar",
 						"key_name",
-						"ess_testcase_for_scaling_configuration"),
+						"tf_test_ess_testcase_for_scaling_configuration"),
 					resource.TestCheckResourceAttr(
 						"alicloud_ess_scaling_configuration.bar",
 						"role_name",
@@ -243,6 +243,14 @@ func TestAccAlicloudEssScalingConfiguration_disable(t *testing.T) {
 	})
 }
 
+func TestUnitAlicloudEssScalingConfiguration_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitEssScalingConfigurationInvalid, []fakeInvalidPlan{
+		{`category = "cloud_ssd"`, "One of size or snapshot_id is required for the data_disk 0"},
+		{`category = "cloud"
+    size = 2048`, "Invalid data_disk 0: the size of cloud disk must between 5 to 2000"},
+	})
+}
+
 func testAccCheckEssScalingConfigurationExists(n string, d *ess.ScalingConfigurationItemType) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -305,6 +313,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -337,7 +346,7 @@ resource "alicloud_ess_scaling_configuration" "foo" {
 }
 
 resource "alicloud_key_pair" "key" {
-  key_name = "ess_testcase_for_scaling_configuration"
+  key_name = "tf_test_ess_testcase_for_scaling_configuration"
 }
 `
 
@@ -355,7 +364,7 @@ data "alicloud_zones" "default" {
 
 // If there is not specifying vpc_id, the module will launch a new vpc
 resource "alicloud_vpc" "vpc" {
-  name = "test-for-ess"
+  name = "tf-test-for-ess"
   cidr_block = "172.16.0.0/12"
 }
 
@@ -364,10 +373,11 @@ resource "alicloud_vswitch" "vswitch" {
   vpc_id = "${alicloud_vpc.vpc.id}"
   cidr_block = "172.16.0.0/24"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
-  name = "test-for-ess"
+  name = "tf-test-for-ess"
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	vpc_id = "${alicloud_vpc.vpc.id}"
 	description = "foo"
 }
@@ -403,7 +413,7 @@ resource "alicloud_ess_scaling_configuration" "bar" {
 	force_delete = true
 }
 resource "alicloud_key_pair" "key" {
-  key_name = "ess_testcase_for_scaling_configuration"
+  key_name = "tf_test_ess_testcase_for_scaling_configuration"
 }
 
 resource "alicloud_ram_role" "role" {
@@ -439,6 +449,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -478,6 +489,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -525,6 +537,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -565,6 +578,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -596,3 +610,16 @@ resource "alicloud_ess_scaling_configuration" "foo" {
 	force_delete = true
 }
 `
+
+const testUnitEssScalingConfigurationInvalid = `
+resource "alicloud_ess_scaling_configuration" "invalid" {
+  scaling_group_id = "asg-fake"
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_group_id = "sg-fake"
+
+  data_disk {
+    %s
+  }
+}
+`
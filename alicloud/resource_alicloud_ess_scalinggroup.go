@@ -65,12 +65,13 @@ func resourceAlicloudEssScalingGroup() *schema.Resource {
 
 func resourceAliyunEssScalingGroupCreate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	args, err := buildAlicloudEssScalingGroupArgs(d, meta)
 	if err != nil {
 		return err
 	}
 
-	essconn := meta.(*AliyunClient).essconn
+	essconn := client.essConn()
 
 	scaling, err := essconn.CreateScalingGroup(args)
 	if err != nil {
@@ -108,7 +109,7 @@ func resourceAliyunEssScalingGroupRead(d *schema.ResourceData, meta interface{})
 
 func resourceAliyunEssScalingGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).essconn
+	conn := meta.(*AliyunClient).essConn()
 	args := &ess.ModifyScalingGroupArgs{
 		ScalingGroupId: d.Id(),
 	}
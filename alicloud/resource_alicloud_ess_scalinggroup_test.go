@@ -263,12 +263,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  	name = "tf_test_foo"
   	vpc_id = "${alicloud_vpc.foo.id}"
   	cidr_block = "172.16.0.0/21"
   	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 }
@@ -308,17 +310,20 @@ data "alicloud_zones" "default" {
 
 // If there is not specifying vpc_id, the module will launch a new vpc
 resource "alicloud_vpc" "vpc" {
+  name = "tf_test_vpc"
   cidr_block = "172.16.0.0/12"
 }
 
 // According to the vswitch cidr blocks to launch several vswitches
 resource "alicloud_vswitch" "vswitch" {
+  name = "tf_test_vswitch"
   vpc_id = "${alicloud_vpc.vpc.id}"
   cidr_block = "172.16.0.0/16"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_security_group" "sg" {
+  name = "tf_test_sg"
   vpc_id = "${alicloud_vpc.vpc.id}"
 }
 
@@ -343,7 +348,7 @@ resource "alicloud_ess_scaling_configuration" "config" {
 
 resource "alicloud_slb" "instance" {
   count=2
-  name = "slb-for-ess"
+  name = "tf-test-slb-for-ess"
   internet_charge_type = "paybytraffic"
   internet = false
 }
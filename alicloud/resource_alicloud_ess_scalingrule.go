@@ -12,10 +12,11 @@ import (
 
 func resourceAlicloudEssScalingRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunEssScalingRuleCreate,
-		Read:   resourceAliyunEssScalingRuleRead,
-		Update: resourceAliyunEssScalingRuleUpdate,
-		Delete: resourceAliyunEssScalingRuleDelete,
+		Create:   resourceAliyunEssScalingRuleCreate,
+		Read:     resourceAliyunEssScalingRuleRead,
+		Update:   resourceAliyunEssScalingRuleUpdate,
+		Delete:   resourceAliyunEssScalingRuleDelete,
+		Importer: importByCompositeId("scaling_group_id", "scaling_rule_id"),
 
 		Schema: map[string]*schema.Schema{
 			"scaling_group_id": &schema.Schema{
@@ -52,12 +53,13 @@ func resourceAlicloudEssScalingRule() *schema.Resource {
 
 func resourceAliyunEssScalingRuleCreate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	args, err := buildAlicloudEssScalingRuleArgs(d, meta)
 	if err != nil {
 		return err
 	}
 
-	essconn := meta.(*AliyunClient).essconn
+	essconn := client.essConn()
 
 	rule, err := essconn.CreateScalingRule(args)
 	if err != nil {
@@ -118,7 +120,7 @@ func resourceAliyunEssScalingRuleDelete(d *schema.ResourceData, meta interface{}
 
 func resourceAliyunEssScalingRuleUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).essconn
+	conn := meta.(*AliyunClient).essConn()
 	ids := strings.Split(d.Id(), COLON_SEPARATED)
 
 	args := &ess.ModifyScalingRuleArgs{
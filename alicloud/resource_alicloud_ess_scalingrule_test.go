@@ -162,6 +162,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -206,6 +207,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
@@ -250,6 +252,7 @@ data "alicloud_images" "ecs_image" {
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_tf_test_foo"
 	description = "foo"
 }
 
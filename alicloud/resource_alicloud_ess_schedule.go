@@ -71,12 +71,13 @@ func resourceAlicloudEssSchedule() *schema.Resource {
 
 func resourceAliyunEssScheduleCreate(d *schema.ResourceData, meta interface{}) error {
 
+	client := meta.(*AliyunClient)
 	args, err := buildAlicloudEssScheduleArgs(d, meta)
 	if err != nil {
 		return err
 	}
 
-	essconn := meta.(*AliyunClient).essconn
+	essconn := client.essConn()
 
 	rule, err := essconn.CreateScheduledTask(args)
 	if err != nil {
@@ -116,7 +117,7 @@ func resourceAliyunEssScheduleRead(d *schema.ResourceData, meta interface{}) err
 
 func resourceAliyunEssScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).essconn
+	conn := meta.(*AliyunClient).essConn()
 
 	args := &ess.ModifyScheduledTaskArgs{
 		ScheduledTaskId: d.Id(),
@@ -13,6 +13,9 @@ func resourceAliyunForwardEntry() *schema.Resource {
 		Read:   resourceAliyunForwardEntryRead,
 		Update: resourceAliyunForwardEntryUpdate,
 		Delete: resourceAliyunForwardEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAliyunForwardEntryImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"forward_table_id": &schema.Schema{
@@ -49,7 +52,10 @@ func resourceAliyunForwardEntry() *schema.Resource {
 }
 
 func resourceAliyunForwardEntryCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).vpcconn
+	conn := meta.(*AliyunClient).vpcConn()
+
+	unlock := lockParent(ForwardTableLock, d.Get("forward_table_id").(string))
+	defer unlock()
 
 	args := &ecs.CreateForwardEntryArgs{
 		RegionId:       getRegion(d, meta),
@@ -79,6 +85,7 @@ func resourceAliyunForwardEntryRead(d *schema.ResourceData, meta interface{}) er
 
 	if err != nil {
 		if NotFoundError(err) {
+			d.SetId("")
 			return nil
 		}
 		return err
@@ -96,7 +103,10 @@ func resourceAliyunForwardEntryRead(d *schema.ResourceData, meta interface{}) er
 
 func resourceAliyunForwardEntryUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := client.vpcConn()
+
+	unlock := lockParent(ForwardTableLock, d.Get("forward_table_id").(string))
+	defer unlock()
 
 	forwardEntry, err := client.DescribeForwardEntry(d.Get("forward_table_id").(string), d.Id())
 	if err != nil {
@@ -145,13 +155,27 @@ func resourceAliyunForwardEntryUpdate(d *schema.ResourceData, meta interface{})
 	return resourceAliyunForwardEntryRead(d, meta)
 }
 
+// resourceAliyunForwardEntryImport imports a forward entry by the ID <forward_table_id>:<forward_entry_id>.
+func resourceAliyunForwardEntryImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "forward_table_id", "forward_entry_id")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("forward_table_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAliyunForwardEntryDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := meta.(*AliyunClient).vpcConn()
 
 	forwardEntryId := d.Id()
 	forwardTableId := d.Get("forward_table_id").(string)
 
+	unlock := lockParent(ForwardTableLock, forwardTableId)
+	defer unlock()
+
 	args := &ecs.DeleteForwardEntryArgs{
 		RegionId:       getRegion(d, meta),
 		ForwardTableId: forwardTableId,
@@ -42,6 +42,53 @@ func TestAccAlicloudForward_basic(t *testing.T) {
 
 }
 
+func TestUnitAlicloudForwardEntry_concurrent(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	noConflicts := func(*terraform.State) error {
+		if n := server.conflicted(); n > 0 {
+			return fmt.Errorf("The entries of one forward table should be changed one by one, but %d changes conflicted.", n)
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: resource.ComposeTestCheckFunc(noConflicts, func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if n := len(server.forwardEntries[fakeForwardTableId]); n > 0 {
+				return fmt.Errorf("Forward entries still exist: %d", n)
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitForwardEntryConcurrent,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo.9", "external_port", "89"),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo.9", "internal_port", "8080"),
+					func(*terraform.State) error {
+						if n := server.calls("vpc/CreateForwardEntry"); n != 10 {
+							return fmt.Errorf("Expected 10 forward entries created, got %d.", n)
+						}
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitForwardEntryConcurrentUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo.9", "internal_port", "9090"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckForwardEntryDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*AliyunClient)
 
@@ -113,6 +160,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -121,7 +169,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Small"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 1
 	  bandwidth = 5
@@ -169,6 +217,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -177,7 +226,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Small"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 1
 	  bandwidth = 5
@@ -210,3 +259,27 @@ resource "alicloud_forward_entry" "foo1"{
 	internal_port = "8080"
 }
 `
+
+const testUnitForwardEntryConcurrent = `
+resource "alicloud_forward_entry" "foo" {
+  count = 10
+  forward_table_id = "ftb-fake"
+  external_ip = "47.0.0.1"
+  external_port = "${80 + count.index}"
+  ip_protocol = "tcp"
+  internal_ip = "172.16.0.${count.index + 10}"
+  internal_port = "8080"
+}
+`
+
+const testUnitForwardEntryConcurrentUpdate = `
+resource "alicloud_forward_entry" "foo" {
+  count = 10
+  forward_table_id = "ftb-fake"
+  external_ip = "47.0.0.1"
+  external_port = "${80 + count.index}"
+  ip_protocol = "tcp"
+  internal_ip = "172.16.0.${count.index + 10}"
+  internal_port = "9090"
+}
+`
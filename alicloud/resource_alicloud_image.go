@@ -0,0 +1,274 @@
+package alicloud
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlicloudImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudImageCreate,
+		Read:   resourceAlicloudImageRead,
+		Update: resourceAlicloudImageUpdate,
+		Delete: resourceAlicloudImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"snapshot_id", "disk_device_mapping"},
+			},
+
+			// The image of a system disk snapshot.
+			"snapshot_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "disk_device_mapping"},
+			},
+
+			// The image of the snapshots of a system disk and data disks. The first one is the system disk.
+			"disk_device_mapping": &schema.Schema{
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "snapshot_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"device": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"size": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateImageName,
+			},
+
+			"description": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDiskDescription,
+			},
+
+			"os_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAlicloudImageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
+
+	args := &CreateImageArgs{
+		RegionId:    getRegion(d, meta),
+		InstanceId:  d.Get("instance_id").(string),
+		SnapshotId:  d.Get("snapshot_id").(string),
+		ImageName:   d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+	for _, v := range d.Get("disk_device_mapping").([]interface{}) {
+		mapping := v.(map[string]interface{})
+		args.DiskDeviceMapping = append(args.DiskDeviceMapping, ImageDiskDeviceMapping{
+			SnapshotId: mapping["snapshot_id"].(string),
+			Device:     mapping["device"].(string),
+			Size:       mapping["size"].(int),
+		})
+	}
+
+	if args.InstanceId == "" && args.SnapshotId == "" && len(args.DiskDeviceMapping) == 0 {
+		return fmt.Errorf("One of instance_id, snapshot_id or disk_device_mapping is required when specifying an image.")
+	}
+
+	var response ecs.CreateImageResponse
+	err := conn.Invoke("CreateImage", args, &response)
+	if err != nil {
+		return fmt.Errorf("CreateImage got an error: %#v", err)
+	}
+
+	d.SetId(response.ImageId)
+
+	if err := waitForImage(client, d.Id(), timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceAlicloudImageUpdate(d, meta)
+}
+
+func resourceAlicloudImageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	image, err := client.QueryImageById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("DescribeImages got an error: %#v", err)
+	}
+
+	var mappings []map[string]interface{}
+	for _, mapping := range image.DiskDeviceMappings.DiskDeviceMapping {
+		size, _ := strconv.Atoi(mapping.Size)
+		mappings = append(mappings, map[string]interface{}{
+			"snapshot_id": mapping.SnapshotId,
+			"device":      mapping.Device,
+			"size":        size,
+		})
+	}
+	if err := d.Set("disk_device_mapping", mappings); err != nil {
+		return err
+	}
+
+	d.Set("name", image.ImageName)
+	d.Set("description", image.Description)
+	d.Set("os_name", image.OSName)
+	d.Set("status", image.Status)
+
+	if err := readTags(client, ecsTags(ecs.TagResourceImage), d); err != nil {
+		log.Printf("[DEBUG] DescribeTags for image got error: %#v", err)
+	}
+
+	return nil
+}
+
+func resourceAlicloudImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	d.Partial(true)
+
+	if err := setTags(client, ecsTags(ecs.TagResourceImage), d); err != nil {
+		return fmt.Errorf("Set tags for image got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
+	if err := modifyImageAttribute(client, d); err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return resourceAlicloudImageRead(d, meta)
+}
+
+func resourceAlicloudImageDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteImage(meta.(*AliyunClient), d)
+}
+
+// waitForImage waits for the image to be created or copied, and fails fast if it can't be.
+// The image is polled in any status, since a failed one is no longer described by default.
+func waitForImage(client *AliyunClient, imageId string, timeout int) error {
+	if timeout <= 0 {
+		timeout = ecs.ImageDefaultTimeout
+	}
+	for {
+		image, err := client.QueryImageById(imageId)
+		if err != nil {
+			return fmt.Errorf("DescribeImages got an error: %#v", err)
+		}
+		switch image.Status {
+		case ecs.ImageStatusAvailable:
+			return nil
+		case ecs.ImageStatusCreateFailed, ecs.ImageStatusUnAvailable:
+			return fmt.Errorf("Image %s is %s, expected %s.", imageId, image.Status, ecs.ImageStatusAvailable)
+		}
+
+		timeout = timeout - ecs.DefaultWaitForInterval
+		if timeout <= 0 {
+			return fmt.Errorf("Image %s is still %s (%s) after the timeout.", imageId, image.Status, image.Progress)
+		}
+		time.Sleep(ecs.DefaultWaitForInterval * time.Second)
+	}
+}
+
+// modifyImageAttribute changes the name and the description of an existing image, which are set on creation otherwise.
+func modifyImageAttribute(client *AliyunClient, d *schema.ResourceData) error {
+	if d.IsNewResource() || !(d.HasChange("name") || d.HasChange("description")) {
+		return nil
+	}
+
+	args := &ModifyImageAttributeArgs{
+		RegionId:    client.Region,
+		ImageId:     d.Id(),
+		ImageName:   d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+	if err := client.ecsConn().Invoke("ModifyImageAttribute", args, &common.Response{}); err != nil {
+		return fmt.Errorf("ModifyImageAttribute got an error: %#v", err)
+	}
+	d.SetPartial("name")
+	d.SetPartial("description")
+	return nil
+}
+
+// deleteImage deletes an image, retrying while it's used, e.g. shared or being copied.
+func deleteImage(client *AliyunClient, d *schema.ResourceData) error {
+	conn := client.ecsConn()
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := conn.DeleteImage(client.Region, d.Id())
+		if err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			if IsConflictError(err) {
+				return resource.RetryableError(fmt.Errorf("Image in use - trying again while it is deleted."))
+			}
+			return resource.NonRetryableError(fmt.Errorf("DeleteImage got an error: %#v", err))
+		}
+
+		if _, err := client.QueryImageById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(fmt.Errorf("DescribeImages got an error: %#v", err))
+		}
+		return resource.RetryableError(fmt.Errorf("Image in use - trying again while it is deleted."))
+	})
+}
@@ -0,0 +1,160 @@
+package alicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceAlicloudImageCopy copies an image of another region to the region of the resource.
+func resourceAlicloudImageCopy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudImageCopyCreate,
+		Read:   resourceAlicloudImageCopyRead,
+		Update: resourceAlicloudImageCopyUpdate,
+		Delete: resourceAlicloudImageCopyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_image_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_region_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRegion,
+			},
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateImageName,
+			},
+
+			"description": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDiskDescription,
+			},
+
+			// Whether the API reports the image as copied from another one.
+			"is_copied": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAlicloudImageCopyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	sourceRegion := common.Region(d.Get("source_region_id").(string))
+	source, err := client.withRegion(sourceRegion)
+	if err != nil {
+		return err
+	}
+
+	args := &ecs.CopyImageArgs{
+		RegionId:               sourceRegion,
+		ImageId:                d.Get("source_image_id").(string),
+		DestinationRegionId:    getRegion(d, meta),
+		DestinationImageName:   d.Get("name").(string),
+		DestinationDescription: d.Get("description").(string),
+	}
+	imageId, err := source.ecsConn().CopyImage(args)
+	if err != nil {
+		return fmt.Errorf("CopyImage got an error: %#v", err)
+	}
+
+	d.SetId(imageId)
+
+	if err := waitForImage(client, d.Id(), timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
+	return resourceAlicloudImageCopyUpdate(d, meta)
+}
+
+func resourceAlicloudImageCopyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	image, err := client.QueryImageById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("DescribeImages got an error: %#v", err)
+	}
+
+	d.Set("name", image.ImageName)
+	d.Set("description", image.Description)
+	d.Set("is_copied", image.IsCopied)
+	d.Set("status", image.Status)
+
+	if err := readTags(client, ecsTags(ecs.TagResourceImage), d); err != nil {
+		log.Printf("[DEBUG] DescribeTags for image got error: %#v", err)
+	}
+
+	return nil
+}
+
+func resourceAlicloudImageCopyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	d.Partial(true)
+
+	if err := setTags(client, ecsTags(ecs.TagResourceImage), d); err != nil {
+		return fmt.Errorf("Set tags for image got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
+	if err := modifyImageAttribute(client, d); err != nil {
+		return err
+	}
+
+	d.Partial(false)
+
+	return resourceAlicloudImageCopyRead(d, meta)
+}
+
+func resourceAlicloudImageCopyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	// A copy in progress, e.g. of a create which timed out, can't be deleted until it's canceled.
+	image, err := client.QueryImageById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("DescribeImages got an error: %#v", err)
+	}
+	if image.Status == ecs.ImageStatusCreating {
+		if err := client.ecsConn().CancelCopyImage(client.Region, d.Id()); err != nil && !NotFoundError(err) {
+			return fmt.Errorf("CancelCopyImage got an error: %#v", err)
+		}
+	}
+
+	return deleteImage(client, d)
+}
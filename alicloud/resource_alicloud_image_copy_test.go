@@ -0,0 +1,122 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudImageCopy_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_image_copy.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccImageCopyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "region", string(common.Shanghai)),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "is_copied", "true"),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "status", string(ecs.ImageStatusAvailable)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudImageCopy_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+	// The copy in Shanghai is managed by the default endpoints of the region, not the custom ones of the provider.
+	server.redirectDefaultEndpoints()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.images) > 0 {
+				return fmt.Errorf("Images still exist: %d", len(server.images))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitImageCopyConfig, "tf-testAccImageCopy"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "region", string(common.Shanghai)),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "name", "tf-testAccImageCopy"),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "is_copied", "true"),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "status", string(ecs.ImageStatusAvailable)),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "tags.Name", "TerraformTest"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "region", string(common.Beijing)),
+					func(s *terraform.State) error {
+						server.lock.Lock()
+						defer server.lock.Unlock()
+						image, ok := server.images[s.RootModule().Resources["alicloud_image_copy.foo"].Primary.ID]
+						if !ok || image.RegionId != common.Shanghai {
+							return fmt.Errorf("Expected the image copied to %s, got %#v.", common.Shanghai, image)
+						}
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitImageCopyConfig, "tf-testAccImageCopy-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "name", "tf-testAccImageCopy-renamed"),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CopyImage"); n != 1 {
+							return fmt.Errorf("The copy should be renamed in place, but it was copied %d times.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+const testAccImageCopyConfig = testAccImageConfig + `
+resource "alicloud_image_copy" "foo" {
+	region = "cn-shanghai"
+	source_image_id = "${alicloud_image.foo.id}"
+	source_region_id = "${alicloud_image.foo.region}"
+	name = "tf-testAccImageCopy"
+}
+`
+
+const testUnitImageCopyConfig = `
+resource "alicloud_disk" "foo" {
+  availability_zone = "cn-beijing-a"
+  size = 40
+}
+
+resource "alicloud_snapshot" "foo" {
+  disk_id = "${alicloud_disk.foo.id}"
+}
+
+resource "alicloud_image" "foo" {
+  snapshot_id = "${alicloud_snapshot.foo.id}"
+}
+
+resource "alicloud_image_copy" "foo" {
+  region = "cn-shanghai"
+  source_image_id = "${alicloud_image.foo.id}"
+  source_region_id = "${alicloud_image.foo.region}"
+  name = "%s"
+  tags = {
+    Name = "TerraformTest"
+  }
+}
+`
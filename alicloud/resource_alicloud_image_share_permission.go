@@ -0,0 +1,122 @@
+package alicloud
+
+import (
+	"fmt"
+
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ModifyImageSharePermission adds or removes 10 accounts at most in a call.
+const imageShareAccountsPerCall = 10
+
+func resourceAlicloudImageSharePermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudImageSharePermissionCreate,
+		Read:   resourceAlicloudImageSharePermissionRead,
+		Update: resourceAlicloudImageSharePermissionUpdate,
+		Delete: resourceAlicloudImageSharePermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"image_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The IDs of the Alibaba Cloud accounts to share the image with.
+			"account_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudImageSharePermissionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+	imageId := d.Get("image_id").(string)
+
+	if err := modifyImageSharePermission(client, imageId, expandStringList(d.Get("account_ids").(*schema.Set).List()), nil); err != nil {
+		return fmt.Errorf("ModifyImageSharePermission got an error: %#v", err)
+	}
+
+	d.SetId(imageId)
+
+	return resourceAlicloudImageSharePermissionRead(d, meta)
+}
+
+func resourceAlicloudImageSharePermissionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	accounts, err := client.DescribeImageShareAccounts(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("DescribeImageSharePermission got an error: %#v", err)
+	}
+
+	d.Set("image_id", d.Id())
+	d.Set("account_ids", accounts)
+
+	return nil
+}
+
+func resourceAlicloudImageSharePermissionUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	if d.HasChange("account_ids") {
+		o, n := d.GetChange("account_ids")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		add := expandStringList(ns.Difference(os).List())
+		remove := expandStringList(os.Difference(ns).List())
+		if err := modifyImageSharePermission(client, d.Id(), add, remove); err != nil {
+			return fmt.Errorf("ModifyImageSharePermission got an error: %#v", err)
+		}
+	}
+
+	return resourceAlicloudImageSharePermissionRead(d, meta)
+}
+
+func resourceAlicloudImageSharePermissionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	err := modifyImageSharePermission(client, d.Id(), nil, expandStringList(d.Get("account_ids").(*schema.Set).List()))
+	if err != nil && !NotFoundError(err) {
+		return fmt.Errorf("ModifyImageSharePermission got an error: %#v", err)
+	}
+	return nil
+}
+
+// modifyImageSharePermission shares the image with the accounts to add, and stops sharing it with the ones to remove.
+func modifyImageSharePermission(client *AliyunClient, imageId string, add, remove []string) error {
+	for len(add) > 0 || len(remove) > 0 {
+		args := &ecs.ModifyImageSharePermissionArgs{
+			RegionId: client.Region,
+			ImageId:  imageId,
+		}
+		args.AddAccount, add = splitStringList(add, imageShareAccountsPerCall)
+		args.RemoveAccount, remove = splitStringList(remove, imageShareAccountsPerCall)
+
+		if err := client.ecsConn().ModifyImageSharePermission(args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStringList splits the first n strings at most from the others.
+func splitStringList(list []string, n int) ([]string, []string) {
+	if len(list) <= n {
+		return list, nil
+	}
+	return list[:n], list[n:]
+}
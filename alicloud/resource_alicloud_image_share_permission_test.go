@@ -0,0 +1,120 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudImageSharePermission_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_image_share_permission.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccImageSharePermissionConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("alicloud_image_share_permission.foo", "image_id", "alicloud_image.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_image_share_permission.foo", "account_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudImageSharePermission_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	// More accounts than ModifyImageSharePermission takes in a call.
+	var accounts []string
+	for i := 0; i < imageShareAccountsPerCall+2; i++ {
+		accounts = append(accounts, fmt.Sprintf(`"12345678%02d"`, i))
+	}
+	shared := func(count int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			image, ok := server.images[s.RootModule().Resources["alicloud_image.foo"].Primary.ID]
+			if !ok {
+				return fmt.Errorf("The image isn't found.")
+			}
+			if len(image.accounts) != count {
+				return fmt.Errorf("Expected the image shared with %d accounts, got %v.", count, image.accounts)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.images) > 0 {
+				return fmt.Errorf("Images still exist: %d", len(server.images))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitImageSharePermissionConfig, strings.Join(accounts, ", ")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("alicloud_image_share_permission.foo", "image_id", "alicloud_image.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_image_share_permission.foo", "account_ids.#", "12"),
+					shared(12),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitImageSharePermissionConfig, strings.Join(accounts[1:3], ", ")+`, "1234567899"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_image_share_permission.foo", "account_ids.#", "3"),
+					shared(3),
+				),
+			},
+			resource.TestStep{
+				Config:            server.providerConfig() + fmt.Sprintf(testUnitImageSharePermissionConfig, strings.Join(accounts[1:3], ", ")+`, "1234567899"`),
+				ResourceName:      "alicloud_image_share_permission.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccImageSharePermissionConfig = testAccImageConfig + `
+resource "alicloud_image_share_permission" "foo" {
+	image_id = "${alicloud_image.foo.id}"
+	account_ids = ["1234567890123456"]
+}
+`
+
+const testUnitImageSharePermissionConfig = `
+resource "alicloud_disk" "foo" {
+  availability_zone = "cn-beijing-a"
+  size = 40
+}
+
+resource "alicloud_snapshot" "foo" {
+  disk_id = "${alicloud_disk.foo.id}"
+}
+
+resource "alicloud_image" "foo" {
+  snapshot_id = "${alicloud_snapshot.foo.id}"
+}
+
+resource "alicloud_image_share_permission" "foo" {
+  image_id = "${alicloud_image.foo.id}"
+  account_ids = [%s]
+}
+`
@@ -0,0 +1,238 @@
+package alicloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudImage_basic(t *testing.T) {
+	var v ecs.ImageType
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_image.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccImageConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("alicloud_image.foo", &v),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "name", "tf-testAccImage"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "status", string(ecs.ImageStatusAvailable)),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "disk_device_mapping.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudImage_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.images) > 0 {
+				return fmt.Errorf("Images still exist: %d", len(server.images))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitImageConfig, "tf-testAccImage", "Golden image."),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_image.instance", "status", string(ecs.ImageStatusAvailable)),
+					resource.TestCheckResourceAttr("alicloud_image.instance", "disk_device_mapping.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_image.instance", "disk_device_mapping.0.size", "80"),
+					resource.TestCheckResourceAttrSet("alicloud_image.instance", "os_name"),
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "name", "tf-testAccImage"),
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "description", "Golden image."),
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "disk_device_mapping.#", "2"),
+					resource.TestCheckResourceAttrPair("alicloud_image.snapshots", "disk_device_mapping.1.snapshot_id", "alicloud_snapshot.foo.1", "id"),
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "disk_device_mapping.1.size", "20"),
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "tags.Name", "TerraformTest"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitImageConfig, "tf-testAccImage-renamed", "Renamed image."),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "name", "tf-testAccImage-renamed"),
+					resource.TestCheckResourceAttr("alicloud_image.snapshots", "description", "Renamed image."),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CreateImage"); n != 2 {
+							return fmt.Errorf("The images should be renamed in place, but they were created %d times.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudImage_createFailed(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+	server.failImages = true
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.images) > 0 {
+				return fmt.Errorf("Images still exist: %d", len(server.images))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      server.providerConfig() + testUnitImageConfigFailed,
+				ExpectError: regexp.MustCompile("is CreateFailed, expected Available"),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudImage_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitImageConfigInvalid, []fakeInvalidPlan{
+		{`instance_id = "i-fake"
+  snapshot_id = "s-fake"`, `"instance_id": conflicts with snapshot_id`},
+		{`snapshot_id = "s-fake"
+  name = "aliyun-image"`, "name cannot starts with aliyun or acs:"},
+	})
+}
+
+func testAccCheckImageExists(n string, image *ecs.ImageType) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Image ID is set")
+		}
+
+		client := testAccProvider.Meta().(*AliyunClient)
+		v, err := client.QueryImageById(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding Image %s: %#v", rs.Primary.ID, err)
+		}
+		*image = *v
+		return nil
+	}
+}
+
+func testAccCheckImageDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*AliyunClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_image" {
+			continue
+		}
+
+		if _, err := client.QueryImageById(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Image %s still exist", rs.Primary.ID)
+		} else if !NotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccImageConfig = `
+data "alicloud_images" "default" {
+	most_recent = true
+	owners = "system"
+	name_regex = "^centos_7"
+}
+
+resource "alicloud_security_group" "foo" {
+	name = "tf-testAccImage"
+}
+
+resource "alicloud_instance" "foo" {
+	image_id = "${data.alicloud_images.default.images.0.id}"
+	instance_type = "ecs.n4.large"
+	security_groups = ["${alicloud_security_group.foo.id}"]
+	instance_name = "tf-testAccImage"
+}
+
+resource "alicloud_image" "foo" {
+	instance_id = "${alicloud_instance.foo.id}"
+	name = "tf-testAccImage"
+	description = "Golden image."
+}
+`
+
+const testUnitImageConfig = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+  system_disk_size = 80
+}
+
+resource "alicloud_image" "instance" {
+  instance_id = "${alicloud_instance.foo.id}"
+}
+
+resource "alicloud_disk" "foo" {
+  count = 2
+  availability_zone = "cn-beijing-a"
+  size = "${40 - count.index * 20}"
+}
+
+resource "alicloud_snapshot" "foo" {
+  count = 2
+  disk_id = "${alicloud_disk.foo.*.id[count.index]}"
+}
+
+resource "alicloud_image" "snapshots" {
+  disk_device_mapping {
+    snapshot_id = "${alicloud_snapshot.foo.0.id}"
+  }
+  disk_device_mapping {
+    snapshot_id = "${alicloud_snapshot.foo.1.id}"
+  }
+  name = "%s"
+  description = "%s"
+  tags = {
+    Name = "TerraformTest"
+  }
+}
+`
+
+const testUnitImageConfigFailed = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+}
+
+resource "alicloud_image" "instance" {
+  instance_id = "${alicloud_instance.foo.id}"
+}
+`
+
+const testUnitImageConfigInvalid = `
+resource "alicloud_image" "invalid" {
+  %s
+}
+`
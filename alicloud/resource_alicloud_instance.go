@@ -1,6 +1,7 @@
 package alicloud
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
 	"strings"
@@ -14,14 +15,24 @@ import (
 
 func resourceAliyunInstance() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunInstanceCreate,
-		Read:   resourceAliyunInstanceRead,
-		Update: resourceAliyunInstanceUpdate,
-		Delete: resourceAliyunInstanceDelete,
+		Create:        resourceAliyunInstanceCreate,
+		Read:          resourceAliyunInstanceRead,
+		Update:        resourceAliyunInstanceUpdate,
+		Delete:        resourceAliyunInstanceDelete,
+		CustomizeDiff: instanceCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAliyunInstanceImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		SchemaVersion: 1,
+		MigrateState:  resourceAlicloudInstanceMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"availability_zone": &schema.Schema{
 				Type:     schema.TypeString,
@@ -35,10 +46,10 @@ func resourceAliyunInstance() *schema.Resource {
 				Required: true,
 			},
 
+			// Changing the instance type stops the instance, and starts it again after its spec is changed.
 			"instance_type": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validateInstanceType,
 			},
 
@@ -118,6 +129,65 @@ func resourceAliyunInstance() *schema.Resource {
 				ValidateFunc: validateIntegerInRange(40, 500),
 			},
 
+			// The data disks created with the instance. The disks attached to it later, e.g. by alicloud_disk_attachment, aren't part of them.
+			"data_disks": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				MaxItems: 16,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validateDiskName,
+						},
+						"size": &schema.Schema{
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ForceNew:         true,
+							DiffSuppressFunc: ecsDataDiskSizeDiffSuppressFunc,
+						},
+						"category": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      ecs.DiskCategoryCloudEfficiency,
+							ValidateFunc: validateDiskCategory,
+						},
+						"snapshot_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"encrypted": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  false,
+						},
+						"delete_with_instance": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+							Default:  true,
+						},
+						"description": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validateDiskDescription,
+						},
+						"disk_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
 			//subnet_id and vswitch_id both exists, cause compatible old version, and aws habit.
 			"subnet_id": &schema.Schema{
 				Type:          schema.TypeString,
@@ -221,10 +291,11 @@ func resourceAliyunInstance() *schema.Resource {
 }
 
 func resourceAliyunInstanceCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	// Ensure instance_type is generation three
-	validData, err := meta.(*AliyunClient).CheckParameterValidity(d, meta)
+	validData, err := client.CheckParameterValidity(d, meta)
 	if err != nil {
 		return err
 	}
@@ -235,16 +306,22 @@ func resourceAliyunInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 	args.IoOptimized = validData[IoOptimizedKey].(ecs.IoOptimized)
 
-	instanceID, err := conn.CreateInstance(args)
+	if args.UserData != "" {
+		// conn.CreateInstance encodes the user data, but it can't create encrypted data disks.
+		args.UserData = base64.StdEncoding.EncodeToString([]byte(args.UserData))
+	}
+
+	var response ecs.CreateInstanceResponse
+	err = conn.Invoke("CreateInstance", args, &response)
 	if err != nil {
 		return fmt.Errorf("Error creating Aliyun ecs instance: %#v", err)
 	}
 
-	d.SetId(instanceID)
+	d.SetId(response.InstanceId)
 
 	// after instance created, its status is pending,
 	// so we need to wait it become to stopped and then start it
-	if err := conn.WaitForInstanceAsyn(d.Id(), ecs.Stopped, defaultTimeout); err != nil {
+	if err := conn.WaitForInstanceAsyn(d.Id(), ecs.Stopped, timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("WaitForInstance %s got error: %#v", ecs.Stopped, err)
 	}
 
@@ -256,16 +333,29 @@ func resourceAliyunInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Start instance got error: %#v", err)
 	}
 
-	if err := conn.WaitForInstanceAsyn(d.Id(), ecs.Running, 500); err != nil {
+	if err := conn.WaitForInstanceAsyn(d.Id(), ecs.Running, timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("WaitForInstance %s got error: %#v", ecs.Running, err)
 	}
 
 	return resourceAliyunInstanceUpdate(d, meta)
 }
 
+// resourceAliyunInstanceImport imports all the data disks of the instance, since there's no state to tell
+// the disks created with it from the ones attached later.
+func resourceAliyunInstanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	dataDisks, err := meta.(*AliyunClient).QueryInstanceDataDisks(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Error DescribeDataDisks: %#v", err)
+	}
+	if err := d.Set("data_disks", flattenInstanceDataDisks(dataDisks, nil, true)); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.ecsconn
+	conn := client.ecsConn()
 
 	instance, err := client.QueryInstancesById(d.Id())
 
@@ -296,6 +386,14 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("instance_type", instance.InstanceType)
 	d.Set("system_disk_category", disk.Category)
 	d.Set("system_disk_size", disk.Size)
+
+	dataDisks, err := client.QueryInstanceDataDisks(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error DescribeDataDisks: %#v", err)
+	}
+	if err := d.Set("data_disks", flattenInstanceDataDisks(dataDisks, d.Get("data_disks").([]interface{}), false)); err != nil {
+		return err
+	}
 	d.Set("password", d.Get("password"))
 	d.Set("internet_max_bandwidth_out", instance.InternetMaxBandwidthOut)
 	d.Set("internet_max_bandwidth_in", instance.InternetMaxBandwidthIn)
@@ -364,27 +462,20 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	tags, _, err := conn.DescribeTags(&ecs.DescribeTagsArgs{
-		RegionId:     getRegion(d, meta),
-		ResourceType: ecs.TagResourceInstance,
-		ResourceId:   d.Id(),
-	})
-
-	if err != nil {
+	if err := readTags(client, ecsTags(ecs.TagResourceInstance), d); err != nil {
 		log.Printf("[ERROR] DescribeTags for instance got error: %#v", err)
 	}
-	d.Set("tags", tagsToMap(tags))
 
 	return nil
 }
 
 func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.ecsconn
+	conn := client.ecsConn()
 
 	d.Partial(true)
 
-	if err := setTags(client, ecs.TagResourceInstance, d); err != nil {
+	if err := setTags(client, ecsTags(ecs.TagResourceInstance), d); err != nil {
 		log.Printf("[DEBUG] Set tags for instance got error: %#v", err)
 		return fmt.Errorf("Set tags for instance got error: %#v", err)
 	} else {
@@ -408,7 +499,7 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 				if err := conn.StopInstance(d.Id(), true); err != nil {
 					return fmt.Errorf("Force Stop Instance got an error: %#v", err)
 				}
-				if err := conn.WaitForInstance(d.Id(), ecs.Stopped, 60); err != nil {
+				if err := conn.WaitForInstance(d.Id(), ecs.Stopped, timeoutInSeconds(d, schema.TimeoutUpdate)); err != nil {
 					return fmt.Errorf("WaitForInstance got error: %#v", err)
 				}
 			}
@@ -420,7 +511,7 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 
 		// Ensure instance's image has been replaced successfully.
-		timeout := ecs.InstanceDefaultTimeout
+		timeout := timeoutInSeconds(d, schema.TimeoutUpdate)
 		for {
 			instance, errDesc := conn.DescribeInstanceAttribute(d.Id())
 			if errDesc != nil {
@@ -442,9 +533,19 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		d.SetPartial("system_disk_size")
 		d.SetPartial("image_id")
 	}
-	// Provider doesn't support change 'system_disk_size'separately.
-	if d.HasChange("system_disk_size") && !d.HasChange("image_id") {
-		return fmt.Errorf("Update resource failed. 'system_disk_size' isn't allowed to change separately. You can update it via renewing instance or replacing system disk.")
+	// The system disk is grown separately, or replaced with the new size above. Shrinking it is rejected at plan time.
+	// A basic cloud disk can't be resized online, so it's resized while the running instance is stopped below.
+	systemDiskUpdate := false
+	if d.HasChange("system_disk_size") && !d.HasChange("image_id") && !d.IsNewResource() {
+		if ecs.DiskCategory(d.Get("system_disk_category").(string)) == ecs.DiskCategoryCloud &&
+			ecs.InstanceStatus(d.Get("status").(string)) == ecs.Running {
+			systemDiskUpdate = true
+		} else {
+			if err := resizeInstanceSystemDisk(d, meta); err != nil {
+				return err
+			}
+			d.SetPartial("system_disk_size")
+		}
 	}
 
 	attributeUpdate := false
@@ -545,41 +646,58 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		d.SetPartial("private_ip")
 	}
 
-	if imageUpdate || passwordUpdate || vpcUpdate {
+	specUpdate := false
+	if d.HasChange("instance_type") && !d.IsNewResource() {
+		if err := client.CheckInstanceTypeInZone(d.Get("availability_zone").(string), d.Get("instance_type").(string)); err != nil {
+			return err
+		}
+		specUpdate = true
+	}
+
+	if imageUpdate || passwordUpdate || vpcUpdate || specUpdate || systemDiskUpdate {
 		instance, errDesc := conn.DescribeInstanceAttribute(d.Id())
 		if errDesc != nil {
 			return fmt.Errorf("Describe instance got an error: %#v", errDesc)
 		}
 		if instance.Status == ecs.Running {
-			log.Printf("[DEBUG] Stop instance when changing image or password or vpc attribute")
+			log.Printf("[DEBUG] Stop instance when changing image or password or vpc attribute or instance type")
 			if err := conn.StopInstance(d.Id(), false); err != nil {
 				return fmt.Errorf("StopInstance got error: %#v", err)
 			}
-			if err := conn.WaitForInstanceAsyn(d.Id(), ecs.Stopped, defaultTimeout); err != nil {
+			if err := conn.WaitForInstanceAsyn(d.Id(), ecs.Stopped, timeoutInSeconds(d, schema.TimeoutUpdate)); err != nil {
 				return fmt.Errorf("WaitForInstance %s got error: %#v", ecs.Stopped, err)
 			}
-			if vpcUpdate {
-				if err := conn.ModifyInstanceVpcAttribute(vpcArgs); err != nil {
-					return fmt.Errorf("ModifyInstanceVPCAttribute got an error: %#v.", err)
-				}
+		} else if instance.Status != ecs.Stopped {
+			return fmt.Errorf("ECS instance's status doesn't support to start or stop operation when chaning image_id or password or vpc attribute or instance_type. The current instance's status is %#v", instance.Status)
+		}
+
+		if vpcUpdate {
+			if err := conn.ModifyInstanceVpcAttribute(vpcArgs); err != nil {
+				return fmt.Errorf("ModifyInstanceVPCAttribute got an error: %#v.", err)
 			}
-		} else if instance.Status == ecs.Stopped {
-			if vpcUpdate {
-				if err := conn.ModifyInstanceVpcAttribute(vpcArgs); err != nil {
-					return fmt.Errorf("ModifyInstanceVPCAttribute got an error: %#v.", err)
-				}
+		}
+
+		if specUpdate {
+			if err := modifyInstanceType(d, meta); err != nil {
+				return err
 			}
-		} else {
-			return fmt.Errorf("ECS instance's status doesn't support to start or stop operation when chaning image_id or password or vpc attribute. The current instance's status is %#v", instance.Status)
+			d.SetPartial("instance_type")
+		}
+
+		if systemDiskUpdate {
+			if err := resizeInstanceSystemDisk(d, meta); err != nil {
+				return err
+			}
+			d.SetPartial("system_disk_size")
 		}
 
-		log.Printf("[DEBUG] Start instance after changing image or password or vpc attribute")
+		log.Printf("[DEBUG] Start instance after changing image or password or vpc attribute or instance type")
 		if err := conn.StartInstance(d.Id()); err != nil {
 			return fmt.Errorf("StartInstance got error: %#v", err)
 		}
 
 		// Start instance sometimes costs more than 8 minutes when os type is centos.
-		if err := conn.WaitForInstance(d.Id(), ecs.Running, 500); err != nil {
+		if err := conn.WaitForInstance(d.Id(), ecs.Running, timeoutInSeconds(d, schema.TimeoutUpdate)); err != nil {
 			return fmt.Errorf("WaitForInstance got error: %#v", err)
 		}
 	}
@@ -594,11 +712,11 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 
 func resourceAliyunInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.ecsconn
+	conn := client.ecsConn()
 	if common.InstanceChargeType(d.Get("instance_charge_type").(string)) == common.PrePaid {
 		return fmt.Errorf("At present, 'PrePaid' instance cannot be deleted and must wait it to be expired and release it automatically.")
 	}
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		instance, err := client.QueryInstancesById(d.Id())
 		if err != nil {
 			if NotFoundError(err) {
@@ -626,7 +744,7 @@ func resourceAliyunInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 }
 
 func allocateIpAndBandWidthRelative(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	if d.Get("allocate_public_ip").(bool) {
 		if d.Get("internet_max_bandwidth_out") == 0 {
 			return fmt.Errorf("Error: if allocate_public_ip is true than the internet_max_bandwidth_out cannot equal zero.")
@@ -640,12 +758,14 @@ func allocateIpAndBandWidthRelative(d *schema.ResourceData, meta interface{}) er
 	return nil
 }
 
-func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.CreateInstanceArgs, error) {
+func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*CreateInstanceArgs, error) {
 	client := meta.(*AliyunClient)
 
-	args := &ecs.CreateInstanceArgs{
-		RegionId:     getRegion(d, meta),
-		InstanceType: d.Get("instance_type").(string),
+	args := &CreateInstanceArgs{
+		CreateInstanceArgs: ecs.CreateInstanceArgs{
+			RegionId:     getRegion(d, meta),
+			InstanceType: d.Get("instance_type").(string),
+		},
 	}
 
 	imageID := d.Get("image_id").(string)
@@ -655,8 +775,21 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Cre
 	systemDiskCategory := ecs.DiskCategory(d.Get("system_disk_category").(string))
 	systemDiskSize := d.Get("system_disk_size").(int)
 
+	for _, v := range d.Get("data_disks").([]interface{}) {
+		disk := v.(map[string]interface{})
+		args.DataDisk = append(args.DataDisk, DataDiskType{
+			Size:               disk["size"].(int),
+			Category:           ecs.DiskCategory(disk["category"].(string)),
+			SnapshotId:         disk["snapshot_id"].(string),
+			DiskName:           disk["name"].(string),
+			Description:        disk["description"].(string),
+			Encrypted:          disk["encrypted"].(bool),
+			DeleteWithInstance: disk["delete_with_instance"].(bool),
+		})
+	}
+
 	zoneID := d.Get("availability_zone").(string)
-	// check instanceType and systemDiskCategory, when zoneID is not empty
+	// check instanceType and disk categories, when zoneID is not empty
 	if zoneID != "" {
 		zone, err := client.DescribeZone(zoneID)
 		if err != nil {
@@ -671,6 +804,12 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Cre
 			return nil, err
 		}
 
+		for _, disk := range args.DataDisk {
+			if err := client.DiskAvailable(zone, disk.Category); err != nil {
+				return nil, err
+			}
+		}
+
 		args.ZoneId = zoneID
 
 	}
@@ -764,8 +903,88 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Cre
 	return args, nil
 }
 
+// flattenInstanceDataDisks returns the data disks of the instance in the order of the state, or all of them when
+// the instance is being imported.
+func flattenInstanceDataDisks(disks []ecs.DiskItemType, state []interface{}, all bool) []map[string]interface{} {
+	if !all {
+		disks = pickInstanceDataDisks(disks, state)
+	}
+
+	result := make([]map[string]interface{}, 0, len(disks))
+	for _, disk := range disks {
+		result = append(result, map[string]interface{}{
+			"name":                 disk.DiskName,
+			"size":                 disk.Size,
+			"category":             disk.Category,
+			"snapshot_id":          disk.SourceSnapshotId,
+			"encrypted":            disk.Encrypted,
+			"delete_with_instance": disk.DeleteWithInstance,
+			"description":          disk.Description,
+			"disk_id":              disk.DiskId,
+		})
+	}
+	return result
+}
+
+// pickInstanceDataDisks returns the disks of the data disks in the state, in its order. The disks attached to
+// the instance later aren't its data disks, and nor are the ones made from the snapshots of its image.
+// A data disk without an ID, e.g. right after the instance is created, is taken as the first disk left
+// which was created from it.
+func pickInstanceDataDisks(disks []ecs.DiskItemType, state []interface{}) []ecs.DiskItemType {
+	picked := make([]*ecs.DiskItemType, len(state))
+	taken := make(map[string]bool)
+	for i, v := range state {
+		config, _ := v.(map[string]interface{})
+		if id, _ := config["disk_id"].(string); id != "" {
+			for j := range disks {
+				if disks[j].DiskId == id {
+					picked[i] = &disks[j]
+					taken[id] = true
+				}
+			}
+		}
+	}
+	for i, v := range state {
+		config, _ := v.(map[string]interface{})
+		if id, _ := config["disk_id"].(string); id != "" || config == nil {
+			continue
+		}
+		for j := range disks {
+			if !taken[disks[j].DiskId] && instanceDataDiskMatches(disks[j], config) {
+				picked[i] = &disks[j]
+				taken[disks[j].DiskId] = true
+				break
+			}
+		}
+	}
+
+	result := make([]ecs.DiskItemType, 0, len(picked))
+	for _, disk := range picked {
+		if disk != nil {
+			result = append(result, *disk)
+		}
+	}
+	return result
+}
+
+// instanceDataDiskMatches returns whether the disk has the name, size, category and snapshot of the data disk.
+// A size left to the snapshot matches any.
+func instanceDataDiskMatches(disk ecs.DiskItemType, config map[string]interface{}) bool {
+	if name, ok := config["name"].(string); ok && name != disk.DiskName {
+		return false
+	}
+	if size, ok := config["size"].(int); ok && size > 0 && size != disk.Size {
+		return false
+	}
+	if category, ok := config["category"].(string); ok && category != "" && ecs.DiskCategory(category) != disk.Category {
+		return false
+	}
+	snapshot, _ := config["snapshot_id"].(string)
+	return snapshot == disk.SourceSnapshotId
+}
+
 func modifyInstanceChargeType(d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	if d.HasChange("instance_charge_type") && !d.IsNewResource() {
 		chargeType := d.Get("instance_charge_type").(string)
@@ -791,3 +1010,55 @@ func modifyInstanceChargeType(d *schema.ResourceData, meta interface{}) (bool, e
 
 	return false, nil
 }
+
+// resizeInstanceSystemDisk grows the system disk, online when the instance is running and the disk isn't a basic cloud disk.
+func resizeInstanceSystemDisk(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	disk, err := client.QueryInstanceSystemDisk(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error DescribeSystemDisk: %#v", err)
+	}
+
+	args := &ResizeDiskArgs{
+		DiskId:  disk.DiskId,
+		NewSize: d.Get("system_disk_size").(int),
+		Type:    ResizeDiskOffline,
+	}
+	if ecs.InstanceStatus(d.Get("status").(string)) == ecs.Running && disk.Category != ecs.DiskCategoryCloud {
+		args.Type = ResizeDiskOnline
+	}
+	if err := client.ecsConn().Invoke("ResizeDisk", args, &common.Response{}); err != nil {
+		return fmt.Errorf("ResizeDisk got an error: %#v", err)
+	}
+	return nil
+}
+
+// modifyInstanceType changes the instance type of a stopped instance, by the API of its charge type.
+func modifyInstanceType(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AliyunClient).ecsConn()
+
+	// The charge type is changed after the instance type, so the current one decides the API.
+	chargeType, _ := d.GetChange("instance_charge_type")
+	if common.InstanceChargeType(chargeType.(string)) == common.PrePaid {
+		args := &ModifyPrepayInstanceSpecArgs{
+			RegionId:     getRegion(d, meta),
+			InstanceId:   d.Id(),
+			InstanceType: d.Get("instance_type").(string),
+			AutoPay:      true,
+		}
+		if err := conn.Invoke("ModifyPrepayInstanceSpec", args, &common.Response{}); err != nil {
+			return fmt.Errorf("ModifyPrepayInstanceSpec got an error: %#v", err)
+		}
+		return nil
+	}
+
+	args := &ecs.ModifyInstanceSpecArgs{
+		InstanceId:   d.Id(),
+		InstanceType: d.Get("instance_type").(string),
+	}
+	if err := conn.ModifyInstanceSpec(args); err != nil {
+		return fmt.Errorf("ModifyInstanceSpec got an error: %#v", err)
+	}
+	return nil
+}
@@ -3,14 +3,66 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"testing"
 
+	"github.com/denverdino/aliyungo/common"
 	"github.com/denverdino/aliyungo/ecs"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_instance", &resource.Sweeper{
+		Name: "alicloud_instance",
+		F:    testSweepInstances,
+	})
+}
+
+func testSweepInstances(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var instances []ecs.InstanceAttributesType
+	args := &ecs.DescribeInstancesArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		page, pagination, err := client.ecsConn().DescribeInstances(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Instances: %#v", err)
+		}
+		instances = append(instances, page...)
+		next := pagination.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range instances {
+		if !isTestSweepName(v.InstanceName) {
+			log.Printf("[INFO] Skipping Instance: %s (%s)", v.InstanceName, v.InstanceId)
+			continue
+		}
+		if v.InstanceChargeType == common.PrePaid {
+			log.Printf("[INFO] Skipping PrePaid Instance: %s (%s)", v.InstanceName, v.InstanceId)
+			continue
+		}
+		log.Printf("[INFO] Deleting Instance: %s (%s)", v.InstanceName, v.InstanceId)
+		if err := testSweepResource(resourceAliyunInstance(), client, v.InstanceId, map[string]interface{}{
+			"instance_charge_type": string(v.InstanceChargeType),
+		}); err != nil {
+			log.Printf("[ERROR] Failed to delete Instance (%s (%s)): %s", v.InstanceName, v.InstanceId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudInstance_basic(t *testing.T) {
 	var instance ecs.InstanceAttributesType
 
@@ -50,7 +102,7 @@ func TestAccAlicloudInstance_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"test_foo"),
+						"tf_test_foo"),
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"internet_charge_type",
@@ -73,7 +125,7 @@ func TestAccAlicloudInstance_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"test_foo"),
+						"tf_test_foo"),
 				),
 			},
 		},
@@ -219,7 +271,7 @@ func TestAccAlicloudInstance_multiSecurityGroup(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"test_foo"),
+						"tf_test_foo"),
 				),
 			},
 			resource.TestStep{
@@ -235,7 +287,7 @@ func TestAccAlicloudInstance_multiSecurityGroup(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"test_foo"),
+						"tf_test_foo"),
 				),
 			},
 			resource.TestStep{
@@ -251,7 +303,7 @@ func TestAccAlicloudInstance_multiSecurityGroup(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"test_foo"),
+						"tf_test_foo"),
 				),
 			},
 		},
@@ -300,7 +352,7 @@ func TestAccAlicloudInstance_multiSecurityGroupByCount(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"test_foo"),
+						"tf_test_foo"),
 				),
 			},
 		},
@@ -382,7 +434,7 @@ func TestAccAlicloudInstance_update(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"instance_foo"),
+						"tf_test_instance_foo"),
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"host_name",
@@ -397,7 +449,7 @@ func TestAccAlicloudInstance_update(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"instance_name",
-						"instance_bar"),
+						"tf_test_instance_bar"),
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.foo",
 						"host_name",
@@ -443,6 +495,109 @@ func TestAccAlicloudInstanceImage_update(t *testing.T) {
 	})
 }
 
+func TestAccAlicloudInstanceType_update(t *testing.T) {
+	var before, after ecs.InstanceAttributesType
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckInstanceTypeConfig, "ecs.n4.small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.update_type", &before),
+					resource.TestCheckResourceAttr("alicloud_instance.update_type", "instance_type", "ecs.n4.small"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckInstanceTypeConfig, "ecs.n4.large"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.update_type", &after),
+					resource.TestCheckResourceAttr("alicloud_instance.update_type", "instance_type", "ecs.n4.large"),
+					resource.TestCheckResourceAttr("alicloud_instance.update_type", "status", string(ecs.Running)),
+					func(*terraform.State) error {
+						if before.InstanceId != after.InstanceId {
+							return fmt.Errorf("The instance should be changed in place, but %s was replaced by %s.", before.InstanceId, after.InstanceId)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudInstance_dataDisks(t *testing.T) {
+	var instance ecs.InstanceAttributesType
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "alicloud_instance.data_disks",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckInstanceDataDisks,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.data_disks", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.data_disks", "data_disks.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.data_disks", "data_disks.0.size", "20"),
+					resource.TestCheckResourceAttr("alicloud_instance.data_disks", "data_disks.0.encrypted", "true"),
+					resource.TestCheckResourceAttr("alicloud_instance.data_disks", "data_disks.1.category", "cloud_ssd"),
+					resource.TestCheckResourceAttrSet("alicloud_instance.data_disks", "data_disks.1.disk_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudInstanceSystemDisk_resize(t *testing.T) {
+	var before, after ecs.InstanceAttributesType
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckInstanceSystemDiskConfig, 50),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.resize_disk", &before),
+					resource.TestCheckResourceAttr("alicloud_instance.resize_disk", "system_disk_size", "50"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckInstanceSystemDiskConfig, 80),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.resize_disk", &after),
+					resource.TestCheckResourceAttr("alicloud_instance.resize_disk", "system_disk_size", "80"),
+					func(*terraform.State) error {
+						if before.InstanceId != after.InstanceId {
+							return fmt.Errorf("The system disk should be resized in place, but %s was replaced by %s.", before.InstanceId, after.InstanceId)
+						}
+						return nil
+					},
+				),
+			},
+
+			resource.TestStep{
+				Config:      fmt.Sprintf(testAccCheckInstanceSystemDiskConfig, 60),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("'system_disk_size' can't be shrunk"),
+			},
+		},
+	})
+}
+
 func TestAccAlicloudInstance_privateIP(t *testing.T) {
 	var instance ecs.InstanceAttributesType
 
@@ -568,7 +723,7 @@ func TestAccAlicloudInstance_keyPair(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_instance.key_pair",
 						"key_name",
-						"key_pair_for_instance_test"),
+						"tf_test_key_pair_for_instance_test"),
 				),
 			},
 		},
@@ -670,6 +825,379 @@ func TestAccAlicloudInstance_spot(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudInstance_basic runs the instance against the fake API server, which doesn't need any credentials.
+func TestUnitAlicloudInstance_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.instances) > 0 {
+				return fmt.Errorf("Instances still exist: %d", len(server.instances))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitInstanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("alicloud_instance.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf_test_foo"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "availability_zone", fakeZoneId),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(ecs.Running)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "80"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "tags.foo", "bar"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitInstanceConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf_test_bar"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_type", fakeInstanceTypeUp),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(ecs.Running)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "tags.foo", "baz"),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CreateInstance"); n != 1 {
+							return fmt.Errorf("The instance should be updated in place, but it was created %d times.", n)
+						}
+						if n := server.calls("ecs/ModifyInstanceSpec"); n != 1 {
+							return fmt.Errorf("The instance type should be changed once, but it was changed %d times.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudInstance_dataDisks(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			for _, disk := range server.disks {
+				if disk.DeleteWithInstance {
+					return fmt.Errorf("Disk %s should be deleted with the instance.", disk.DiskId)
+				}
+				if disk.Status != ecs.DiskStatusAvailable {
+					return fmt.Errorf("Disk %s should be detached from the instance, but it's %s.", disk.DiskId, disk.Status)
+				}
+			}
+			if len(server.disks) != 2 {
+				return fmt.Errorf("The kept and the attached disks should be left, but %d disks are.", len(server.disks))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitInstanceConfigDataDisks,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.name", "encrypted"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.size", "30"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.category", "cloud_ssd"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.encrypted", "true"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.delete_with_instance", "true"),
+					resource.TestCheckResourceAttrSet("alicloud_instance.foo", "data_disks.0.disk_id"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.name", "kept"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.size", "20"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.category", "cloud_efficiency"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.encrypted", "false"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.delete_with_instance", "false"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.description", "kept after the instance"),
+				),
+			},
+			resource.TestStep{
+				Config:                  server.providerConfig() + testUnitInstanceConfigDataDisks,
+				ResourceName:            "alicloud_instance.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"allocate_public_ip"},
+			},
+			resource.TestStep{
+				// A disk attached later isn't one of the data disks, so it doesn't replace the instance.
+				PreConfig: func() {
+					server.lock.Lock()
+					defer server.lock.Unlock()
+					for _, instance := range server.instances {
+						server.disks["d-attached"] = &ecs.DiskItemType{
+							DiskId:     "d-attached",
+							DiskName:   "attached",
+							Type:       ecs.DiskTypeAllData,
+							Category:   ecs.DiskCategoryCloudEfficiency,
+							Size:       50,
+							Status:     ecs.DiskStatusInUse,
+							InstanceId: instance.InstanceId,
+							Device:     "/dev/xvdd",
+						}
+					}
+				},
+				Config: server.providerConfig() + testUnitInstanceConfigDataDisks,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.#", "2"),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CreateInstance"); n != 1 {
+							return fmt.Errorf("The instance shouldn't be replaced, but it was created %d times.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudInstance_attachedDisk(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.instances) > 0 {
+				return fmt.Errorf("Instances still exist: %d", len(server.instances))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitInstanceConfig,
+				Check:  resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.#", "0"),
+			},
+			resource.TestStep{
+				// The instance created without data disks doesn't take the disk attached to it later.
+				PreConfig: func() {
+					server.lock.Lock()
+					defer server.lock.Unlock()
+					for _, instance := range server.instances {
+						server.disks["d-attached"] = &ecs.DiskItemType{
+							DiskId:     "d-attached",
+							DiskName:   "attached",
+							Type:       ecs.DiskTypeAllData,
+							Category:   ecs.DiskCategoryCloudEfficiency,
+							Size:       50,
+							Status:     ecs.DiskStatusInUse,
+							InstanceId: instance.InstanceId,
+							Device:     "/dev/xvdb",
+						}
+					}
+				},
+				Config: server.providerConfig() + testUnitInstanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.#", "0"),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CreateInstance"); n != 1 {
+							return fmt.Errorf("The instance shouldn't be replaced, but it was created %d times.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudInstance_imageDataDisks(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testUnitProviders(),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				// The disk made from the data disk snapshot of the image isn't one of the data disks.
+				PreConfig: func() {
+					server.lock.Lock()
+					defer server.lock.Unlock()
+					image := server.newImage(common.Beijing, "tf-testAccImageDataDisks", "")
+					image.ImageId = "m-data-disks"
+					image.addDisk("", 40)
+					image.addDisk("s-image-data", 100)
+					server.images[image.ImageId] = image
+				},
+				Config: server.providerConfig() + testUnitInstanceConfigImageDataDisks,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.name", "logs"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.0.size", "30"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.name", "data"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "data_disks.1.size", "20"),
+					func(*terraform.State) error {
+						server.lock.Lock()
+						defer server.lock.Unlock()
+						// The system disk, the one of the image and the two of the arguments.
+						if len(server.disks) != 4 {
+							return fmt.Errorf("The instance should have 4 disks, got %d.", len(server.disks))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudInstance_systemDisk(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	calls := func(action string, expected int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if n := server.calls("ecs/" + action); n != expected {
+				return fmt.Errorf("%s should be called %d times, but it was called %d times.", action, expected, n)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testUnitProviders(),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigSystemDisk, "ubuntu_140405_64_40G_cloudinit_20161115.vhd", 80),
+				Check:  resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "80"),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigSystemDisk, "ubuntu_140405_64_40G_cloudinit_20161115.vhd", 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "100"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(ecs.Running)),
+					calls("ResizeDisk", 1),
+					calls("StopInstance", 0),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigSystemDisk, "centos_7_04_64_20G_alibase_201701015.vhd", 120),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "image_id", "centos_7_04_64_20G_alibase_201701015.vhd"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "120"),
+					calls("ReplaceSystemDisk", 1),
+					calls("ResizeDisk", 1),
+				),
+			},
+			resource.TestStep{
+				Config:      server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigSystemDisk, "centos_7_04_64_20G_alibase_201701015.vhd", 60),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("'system_disk_size' can't be shrunk from 120 to 60"),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigSystemDisk, "ubuntu_140405_64_40G_cloudinit_20161115.vhd", 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "60"),
+					calls("ReplaceSystemDisk", 2),
+					calls("CreateInstance", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudInstance_cloudSystemDisk(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	calls := func(action string, expected int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if n := server.calls("ecs/" + action); n != expected {
+				return fmt.Errorf("%s should be called %d times, but it was called %d times.", action, expected, n)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testUnitProviders(),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigSystemDisk, "ubuntu_140405_64_40G_cloudinit_20161115.vhd", 40),
+			},
+			resource.TestStep{
+				// The instance types of the fake zone don't support basic cloud disks, so the disk of an older instance
+				// is made one. It can only be resized offline, so the running instance is restarted.
+				PreConfig: func() {
+					server.lock.Lock()
+					defer server.lock.Unlock()
+					for _, disk := range server.disks {
+						if disk.Type == ecs.DiskTypeAllSystem {
+							disk.Category = ecs.DiskCategoryCloud
+						}
+					}
+				},
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigCloudSystemDisk, 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_category", string(ecs.DiskCategoryCloud)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "60"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(ecs.Running)),
+					calls("ResizeDisk", 1),
+					calls("StopInstance", 1),
+					calls("StartInstance", 2),
+					calls("CreateInstance", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudInstance_unavailableInstanceType(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigInstanceType, fakeInstanceType),
+			},
+			resource.TestStep{
+				Config:      server.providerConfig() + fmt.Sprintf(testUnitInstanceConfigInstanceType, "ecs.n4.2xlarge"),
+				ExpectError: regexp.MustCompile("Instance type ecs.n4.2xlarge is not supported in the availability zone " + fakeZoneId),
+			},
+		},
+	})
+
+	if n := server.calls("ecs/ModifyInstanceSpec"); n != 0 {
+		t.Fatalf("The unavailable instance type should be rejected before the instance is changed, but it was changed %d times.", n)
+	}
+	if n := server.calls("ecs/StopInstance"); n != 1 {
+		t.Fatalf("The instance should only be stopped to be destroyed, but it was stopped %d times.", n)
+	}
+}
+
+func TestUnitAlicloudInstance_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitInstanceConfigInvalid, []fakeInvalidPlan{
+		{`spot_strategy = "SpotAsPriceGo"
+  spot_price_limit = 1.5`, "'spot_price_limit' is only supported when the spot_strategy is SpotWithPriceLimit"},
+		{`period = 3`, "'period' and 'period_unit' are only supported when the instance_charge_type is PrePaid"},
+		{`instance_charge_type = "PrePaid"
+  spot_strategy = "SpotAsPriceGo"`, "'spot_strategy' SpotAsPriceGo is only supported when the instance_charge_type is PostPaid"},
+		{`allocate_public_ip = true`, "'internet_max_bandwidth_out' must be greater than 0"},
+		{`data_disks {
+    category = "cloud_ssd"
+  }`, "One of size or snapshot_id is required for the data_disks 0"},
+		{`data_disks {
+    size = 10
+  }`, "the size of cloud_efficiency disk must between 20 to 32768"},
+	})
+}
+
 func testAccCheckInstanceExists(n string, i *ecs.InstanceAttributesType) resource.TestCheckFunc {
 	providers := []*schema.Provider{testAccProvider}
 	return testAccCheckInstanceExistsWithProviders(n, i, &providers)
@@ -808,7 +1336,7 @@ resource "alicloud_instance" "foo" {
 	instance_type = "ecs.xn4.small"
 	internet_charge_type = "PayByBandwidth"
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 
 	tags {
 		foo = "bar"
@@ -828,6 +1356,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+ 	name = "tf_test_foo"
  	vpc_id = "${alicloud_vpc.foo.id}"
  	cidr_block = "172.16.0.0/21"
  	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -853,7 +1382,7 @@ resource "alicloud_instance" "foo" {
 	internet_max_bandwidth_out = 5
 	allocate_public_ip = true
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 }
 
 `
@@ -870,6 +1399,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  	name = "tf_test_foo"
   	vpc_id = "${alicloud_vpc.foo.id}"
   	cidr_block = "172.16.0.0/21"
   	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -893,7 +1423,7 @@ resource "alicloud_instance" "foo" {
 	internet_max_bandwidth_out = 5
 	allocate_public_ip = true
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 	user_data = "echo 'net.ipv4.ip_forward=1'>> /etc/sysctl.conf"
 }
 `
@@ -931,7 +1461,7 @@ resource "alicloud_instance" "foo" {
   	instance_type = "ecs.n4.large"
   	system_disk_category = "cloud_efficiency"
   	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-  	instance_name = "test_foo"
+  	instance_name = "tf_test_foo"
 }
 
 resource "alicloud_instance" "bar" {
@@ -944,7 +1474,7 @@ resource "alicloud_instance" "bar" {
 	instance_type = "ecs.n4.large"
 	system_disk_category = "cloud_efficiency"
 	security_groups = ["${alicloud_security_group.tf_test_bar.id}"]
-	instance_name = "test_bar"
+	instance_name = "tf_test_bar"
 }
 `
 
@@ -966,7 +1496,7 @@ resource "alicloud_instance" "foo" {
 	instance_type = "ecs.n4.large"
 	internet_charge_type = "PayByBandwidth"
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}", "${alicloud_security_group.tf_test_bar.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 	system_disk_category = "cloud_efficiency"
 }`
 
@@ -994,7 +1524,7 @@ resource "alicloud_instance" "foo" {
 	internet_charge_type = "PayByBandwidth"
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}", "${alicloud_security_group.tf_test_bar.id}",
 				"${alicloud_security_group.tf_test_add_sg.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 	system_disk_category = "cloud_efficiency"
 }
 `
@@ -1034,7 +1564,7 @@ resource "alicloud_instance" "foo" {
 	instance_type = "ecs.n4.large"
 	internet_charge_type = "PayByBandwidth"
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 	system_disk_category = "cloud_efficiency"
 }
 `
@@ -1053,7 +1583,7 @@ resource "alicloud_instance" "foo" {
 	instance_type = "ecs.mn4.small"
 	internet_charge_type = "PayByBandwidth"
 	security_groups = ["${alicloud_security_group.tf_test_foo.*.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 	system_disk_category = "cloud_efficiency"
 }
 `
@@ -1070,6 +1600,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  	name = "tf_test_foo"
   	vpc_id = "${alicloud_vpc.foo.id}"
   	cidr_block = "172.16.0.0/21"
   	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -1091,7 +1622,7 @@ resource "alicloud_instance" "foo" {
 	system_disk_category = "cloud_efficiency"
 
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 
 	internet_max_bandwidth_out = 5
 	allocate_public_ip = "true"
@@ -1114,7 +1645,7 @@ resource "alicloud_instance" "foo" {
 	system_disk_category = "cloud_efficiency"
 
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 
 	tags {
 		foo = "bar"
@@ -1138,7 +1669,7 @@ resource "alicloud_instance" "foo" {
 	system_disk_category = "cloud_efficiency"
 
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 
 	tags {
 		bar = "zzz"
@@ -1184,7 +1715,7 @@ resource "alicloud_instance" "foo" {
 
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
 
-	instance_name = "instance_foo"
+	instance_name = "tf_test_instance_foo"
 	host_name = "host-foo"
 }
 `
@@ -1228,7 +1759,7 @@ resource "alicloud_instance" "foo" {
 
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
 
-	instance_name = "instance_bar"
+	instance_name = "tf_test_instance_bar"
 	host_name = "host-bar"
 }
 `
@@ -1245,6 +1776,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  	name = "tf_test_foo"
   	vpc_id = "${alicloud_vpc.foo.id}"
   	cidr_block = "172.16.0.0/24"
   	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -1266,7 +1798,7 @@ resource "alicloud_instance" "foo" {
 	instance_type = "ecs.n4.large"
 	system_disk_category = "cloud_efficiency"
 	image_id = "ubuntu_140405_32_40G_cloudinit_20161115.vhd"
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 }
 `
 const testAccInstanceConfigAssociatePublicIP = `
@@ -1281,6 +1813,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  	name = "tf_test_foo"
   	vpc_id = "${alicloud_vpc.foo.id}"
   	cidr_block = "172.16.0.0/24"
   	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -1305,7 +1838,7 @@ resource "alicloud_instance" "foo" {
 	instance_type = "ecs.n4.large"
 	system_disk_category = "cloud_efficiency"
 	image_id = "ubuntu_140405_32_40G_cloudinit_20161115.vhd"
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 }
 `
 const testAccVpcInstanceWithSecurityRule = `
@@ -1320,6 +1853,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  	name = "tf_test_foo"
   	vpc_id = "${alicloud_vpc.foo.id}"
   	cidr_block = "10.1.1.0/24"
   	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -1357,7 +1891,7 @@ resource "alicloud_instance" "foo" {
 
     	system_disk_category = "cloud_efficiency"
     	image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
-    	instance_name = "test_foo"
+    	instance_name = "tf_test_foo"
 }
 `
 const testAccCheckInstanceImageOrigin = `
@@ -1373,6 +1907,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "cn-beijing-a"
@@ -1391,12 +1926,129 @@ resource "alicloud_instance" "update_image" {
   	system_disk_size = 50
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "update_image"
+  	instance_name = "tf_test_update_image"
   	password = "Test12345"
   	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
 	vswitch_id = "${alicloud_vswitch.foo.id}"
 }
 `
+const testAccCheckInstanceTypeConfig = `
+data "alicloud_images" "centos" {
+	most_recent = true
+	owners = "system"
+	name_regex = "^centos_6\\w{1,5}[64]{1}.*"
+}
+
+resource "alicloud_vpc" "foo" {
+	name = "tf_test_type"
+	cidr_block = "10.1.0.0/21"
+}
+
+resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
+	vpc_id = "${alicloud_vpc.foo.id}"
+	cidr_block = "10.1.1.0/24"
+	availability_zone = "cn-beijing-a"
+}
+
+resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_foo"
+	description = "foo"
+	vpc_id = "${alicloud_vpc.foo.id}"
+}
+
+resource "alicloud_instance" "update_type" {
+	image_id = "${data.alicloud_images.centos.images.0.id}"
+	availability_zone = "cn-beijing-a"
+	instance_type = "%s"
+	instance_name = "tf_test_update_type"
+	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
+	vswitch_id = "${alicloud_vswitch.foo.id}"
+}
+`
+const testAccCheckInstanceDataDisks = `
+data "alicloud_images" "centos" {
+	most_recent = true
+	owners = "system"
+	name_regex = "^centos_6\\w{1,5}[64]{1}.*"
+}
+
+resource "alicloud_vpc" "foo" {
+	name = "tf_test_data_disks"
+	cidr_block = "10.1.0.0/21"
+}
+
+resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
+	vpc_id = "${alicloud_vpc.foo.id}"
+	cidr_block = "10.1.1.0/24"
+	availability_zone = "cn-beijing-a"
+}
+
+resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_foo"
+	description = "foo"
+	vpc_id = "${alicloud_vpc.foo.id}"
+}
+
+resource "alicloud_instance" "data_disks" {
+	image_id = "${data.alicloud_images.centos.images.0.id}"
+	availability_zone = "cn-beijing-a"
+	instance_type = "ecs.n4.small"
+	instance_name = "tf_test_data_disks"
+	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
+	vswitch_id = "${alicloud_vswitch.foo.id}"
+
+	data_disks {
+		name = "tf_test_encrypted"
+		size = 20
+		encrypted = true
+	}
+
+	data_disks {
+		name = "tf_test_ssd"
+		size = 40
+		category = "cloud_ssd"
+		description = "deleted with the instance"
+	}
+}
+`
+const testAccCheckInstanceSystemDiskConfig = `
+data "alicloud_images" "centos" {
+	most_recent = true
+	owners = "system"
+	name_regex = "^centos_6\\w{1,5}[64]{1}.*"
+}
+
+resource "alicloud_vpc" "foo" {
+	name = "tf_test_resize_disk"
+	cidr_block = "10.1.0.0/21"
+}
+
+resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
+	vpc_id = "${alicloud_vpc.foo.id}"
+	cidr_block = "10.1.1.0/24"
+	availability_zone = "cn-beijing-a"
+}
+
+resource "alicloud_security_group" "tf_test_foo" {
+	name = "tf_test_foo"
+	description = "foo"
+	vpc_id = "${alicloud_vpc.foo.id}"
+}
+
+resource "alicloud_instance" "resize_disk" {
+	image_id = "${data.alicloud_images.centos.images.0.id}"
+	availability_zone = "cn-beijing-a"
+	system_disk_category = "cloud_efficiency"
+	system_disk_size = %d
+	instance_type = "ecs.n4.small"
+	instance_name = "tf_test_resize_disk"
+	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
+	vswitch_id = "${alicloud_vswitch.foo.id}"
+}
+`
 const testAccCheckInstanceImageUpdate = `
 data "alicloud_images" "ubuntu" {
 	most_recent = true
@@ -1410,6 +2062,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "cn-beijing-a"
@@ -1428,7 +2081,7 @@ resource "alicloud_instance" "update_image" {
   	system_disk_size = 60
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "update_image"
+  	instance_name = "tf_test_update_image"
   	password = "Test12345"
   	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
 	vswitch_id = "${alicloud_vswitch.foo.id}"
@@ -1447,6 +2100,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "cn-beijing-a"
@@ -1459,7 +2113,7 @@ resource "alicloud_security_group" "tf_test_foo" {
 }
 
 resource "alicloud_key_pair" "key_pair" {
-  key_name = "key_pair_for_instance_test"
+  key_name = "tf_test_key_pair_for_instance_test"
 }
 
 resource "alicloud_instance" "key_pair" {
@@ -1469,7 +2123,7 @@ resource "alicloud_instance" "key_pair" {
   	system_disk_size = 60
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "with_key_pair"
+  	instance_name = "tf_test_with_key_pair"
   	password = "Test12345"
   	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
 	vswitch_id = "${alicloud_vswitch.foo.id}"
@@ -1491,12 +2145,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "${data.alicloud_zones.zones.zones.0.id}"
 }
 
 resource "alicloud_vswitch" "bar" {
+	name = "tf_test_bar"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.2.0/24"
 	availability_zone = "${data.alicloud_zones.zones.zones.0.id}"
@@ -1513,7 +2169,7 @@ resource "alicloud_instance" "private_ip" {
   	system_disk_size = 40
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "with_private_ip"
+  	instance_name = "tf_test_with_private_ip"
   	security_groups = ["${alicloud_security_group.group.id}"]
 	vswitch_id = "${alicloud_vswitch.foo.id}"
 	private_ip = "10.1.1.3"
@@ -1535,12 +2191,14 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "${data.alicloud_zones.zones.zones.0.id}"
 }
 
 resource "alicloud_vswitch" "bar" {
+	name = "tf_test_bar"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.2.0/24"
 	availability_zone = "${data.alicloud_zones.zones.zones.0.id}"
@@ -1557,7 +2215,7 @@ resource "alicloud_instance" "private_ip" {
   	system_disk_size = 40
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "with_private_ip"
+  	instance_name = "tf_test_with_private_ip"
   	security_groups = ["${alicloud_security_group.group.id}"]
 	vswitch_id = "${alicloud_vswitch.bar.id}"
 	private_ip = "10.1.2.3"
@@ -1579,6 +2237,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "${data.alicloud_zones.zones.zones.0.id}"
@@ -1595,7 +2254,7 @@ resource "alicloud_instance" "charge_type" {
   	system_disk_size = 40
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "with_charge_type"
+  	instance_name = "tf_test_with_charge_type"
   	security_groups = ["${alicloud_security_group.group.id}"]
 	vswitch_id = "${alicloud_vswitch.foo.id}"
 	private_ip = "10.1.1.3"
@@ -1617,6 +2276,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "${data.alicloud_zones.zones.zones.0.id}"
@@ -1633,7 +2293,7 @@ resource "alicloud_instance" "charge_type" {
   	system_disk_size = 40
 
   	instance_type = "ecs.n4.small"
-  	instance_name = "with_charge_type"
+  	instance_name = "tf_test_with_charge_type"
   	security_groups = ["${alicloud_security_group.group.id}"]
 	vswitch_id = "${alicloud_vswitch.foo.id}"
 	private_ip = "10.1.1.3"
@@ -1648,16 +2308,19 @@ data "alicloud_zones" "default" {
 }
 
 resource "alicloud_vpc" "foo" {
+  name = "tf_test_foo"
   cidr_block = "172.16.0.0/12"
 }
 
 resource "alicloud_vswitch" "foo" {
+  name = "tf_test_foo"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "172.16.0.0/21"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 
 resource "alicloud_security_group" "tf_test_foo" {
+  name = "tf_test_tf_test_foo"
   vpc_id = "${alicloud_vpc.foo.id}"
 }
 
@@ -1675,8 +2338,112 @@ resource "alicloud_instance" "spot" {
   internet_max_bandwidth_out = 5
   allocate_public_ip = true
   security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-  instance_name = "test_for_spot"
+  instance_name = "tf_test_for_spot"
   spot_strategy = "SpotWithPriceLimit"
   spot_price_limit = "1.002"
 }
 `
+
+const testUnitInstanceConfig = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+  system_disk_size = 80
+  instance_name = "tf_test_foo"
+
+  tags = {
+    foo = "bar"
+  }
+}
+`
+
+const testUnitInstanceConfigInvalid = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+  %s
+}
+`
+
+const testUnitInstanceConfigUpdate = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.xlarge"
+  security_groups = ["sg-fake"]
+  system_disk_size = 80
+  instance_name = "tf_test_bar"
+
+  tags = {
+    foo = "baz"
+  }
+}
+`
+
+const testUnitInstanceConfigInstanceType = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "%s"
+  security_groups = ["sg-fake"]
+}
+`
+
+const testUnitInstanceConfigDataDisks = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+
+  data_disks {
+    name = "encrypted"
+    size = 30
+    category = "cloud_ssd"
+    encrypted = true
+  }
+
+  data_disks {
+    name = "kept"
+    size = 20
+    delete_with_instance = false
+    description = "kept after the instance"
+  }
+}
+`
+
+const testUnitInstanceConfigImageDataDisks = `
+resource "alicloud_instance" "foo" {
+  image_id = "m-data-disks"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+
+  data_disks {
+    name = "logs"
+    size = 30
+  }
+
+  data_disks {
+    name = "data"
+    size = 20
+  }
+}
+`
+
+const testUnitInstanceConfigCloudSystemDisk = `
+resource "alicloud_instance" "foo" {
+  image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+  system_disk_category = "cloud"
+  system_disk_size = %d
+}
+`
+
+const testUnitInstanceConfigSystemDisk = `
+resource "alicloud_instance" "foo" {
+  image_id = "%s"
+  instance_type = "ecs.n4.large"
+  security_groups = ["sg-fake"]
+  system_disk_size = %d
+}
+`
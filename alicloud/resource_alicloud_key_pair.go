@@ -62,7 +62,7 @@ func resourceAlicloudKeyPair() *schema.Resource {
 }
 
 func resourceAlicloudKeyPairCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	var keyName string
 	if v, ok := d.GetOk("key_name"); ok {
@@ -103,14 +103,14 @@ func resourceAlicloudKeyPairCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudKeyPairRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	keypairs, _, err := conn.DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
 		RegionId:    getRegion(d, meta),
 		KeyPairName: d.Id(),
 	})
 	if err != nil {
-		if IsExceptedError(err, KeyPairNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -143,7 +143,7 @@ func resourceAlicloudKeyPairDelete(d *schema.ResourceData, meta interface{}) err
 		// Detach keypair from its all instances before removing it.
 		if len(instance_ids) > 0 {
 			detachArgs.InstanceIds = convertListToJsonString(instance_ids)
-			if err := client.ecsconn.DetachKeyPair(detachArgs); err != nil {
+			if err := client.ecsConn().DetachKeyPair(detachArgs); err != nil {
 				return resource.NonRetryableError(fmt.Errorf("Error DetachKeyPair:%#v", err))
 			}
 		}
@@ -155,17 +155,17 @@ func resourceAlicloudKeyPairDelete(d *schema.ResourceData, meta interface{}) err
 			return resource.RetryableError(fmt.Errorf("Delete Key Pair timeout and got an error: %#v.", err))
 		}
 
-		err := client.ecsconn.DeleteKeyPairs(&ecs.DeleteKeyPairsArgs{
+		err := client.ecsConn().DeleteKeyPairs(&ecs.DeleteKeyPairsArgs{
 			RegionId:     getRegion(d, meta),
 			KeyPairNames: convertListToJsonString(append(make([]interface{}, 0, 1), d.Id())),
 		})
 		if err != nil {
-			if IsExceptedError(err, KeyPairNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 		}
 
-		keypairs, _, err := client.ecsconn.DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
+		keypairs, _, err := client.ecsConn().DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
 			RegionId:    getRegion(d, meta),
 			KeyPairName: d.Id(),
 		})
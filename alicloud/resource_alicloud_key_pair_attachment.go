@@ -37,7 +37,7 @@ func resourceAlicloudKeyPairAttachment() *schema.Resource {
 }
 
 func resourceAlicloudKeyPairAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	instanceIds := convertListToJsonString(d.Get("instance_ids").(*schema.Set).List())
 
 	args := &ecs.AttachKeyPairArgs{
@@ -64,14 +64,14 @@ func resourceAlicloudKeyPairAttachmentCreate(d *schema.ResourceData, meta interf
 }
 
 func resourceAlicloudKeyPairAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	keyname := strings.Split(d.Id(), ":")[0]
 	keypairs, _, err := conn.DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
 		RegionId:    getRegion(d, meta),
 		KeyPairName: keyname,
 	})
 	if err != nil {
-		if IsExceptedError(err, KeyPairNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -93,7 +93,7 @@ func resourceAlicloudKeyPairAttachmentDelete(d *schema.ResourceData, meta interf
 	instanceIds := strings.Split(d.Id(), ":")[1]
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
-		err := client.ecsconn.DetachKeyPair(&ecs.DetachKeyPairArgs{
+		err := client.ecsConn().DetachKeyPair(&ecs.DetachKeyPairArgs{
 			RegionId:    getRegion(d, meta),
 			KeyPairName: keyname,
 			InstanceIds: instanceIds,
@@ -103,11 +103,12 @@ variable "availability_zones" {
 }
 
 resource "alicloud_vpc" "main" {
-  name = "vpc-for-keypair"
+  name = "tf-test-vpc-for-keypair"
   cidr_block = "10.1.0.0/21"
 }
 
 resource "alicloud_vswitch" "main" {
+  name = "tf_test_main"
   vpc_id = "${alicloud_vpc.main.id}"
   cidr_block = "10.1.1.0/24"
   availability_zone = "${var.availability_zones}"
@@ -115,13 +116,13 @@ resource "alicloud_vswitch" "main" {
     "alicloud_vpc.main"]
 }
 resource "alicloud_security_group" "group" {
-  name = "test-for-keypair"
+  name = "tf-test-for-keypair"
   description = "New security group"
   vpc_id = "${alicloud_vpc.main.id}"
 }
 
 resource "alicloud_instance" "instance" {
-  instance_name = "test-keypair-${format(var.count_format, count.index+1)}"
+  instance_name = "tf-test-keypair-${format(var.count_format, count.index+1)}"
   image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
   instance_type = "ecs.n4.small"
   count = 2
@@ -141,7 +142,7 @@ resource "alicloud_instance" "instance" {
 }
 
 resource "alicloud_key_pair" "key" {
-  key_name = "terraform-test-key-pair-attachment"
+  key_name = "tf-test-key-pair-attachment"
 }
 
 resource "alicloud_key_pair_attachment" "attach" {
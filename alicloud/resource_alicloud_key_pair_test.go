@@ -12,6 +12,54 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_key_pair", &resource.Sweeper{
+		Name: "alicloud_key_pair",
+		F:    testSweepKeyPairs,
+		// The key pairs can't be deleted until the instances using them are deleted.
+		Dependencies: []string{
+			"alicloud_instance",
+		},
+	})
+}
+
+func testSweepKeyPairs(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var keyPairs []ecs.KeyPairItemType
+	args := &ecs.DescribeKeyPairsArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		page, pagination, err := client.ecsConn().DescribeKeyPairs(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Key Pairs: %#v", err)
+		}
+		keyPairs = append(keyPairs, page...)
+		next := pagination.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range keyPairs {
+		if !isTestSweepName(v.KeyPairName) {
+			log.Printf("[INFO] Skipping Key Pair: %s", v.KeyPairName)
+			continue
+		}
+		log.Printf("[INFO] Deleting Key Pair: %s", v.KeyPairName)
+		if err := testSweepResource(resourceAlicloudKeyPair(), client, v.KeyPairName, nil); err != nil {
+			log.Printf("[ERROR] Failed to delete Key Pair (%s): %s", v.KeyPairName, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudKeyPair_basic(t *testing.T) {
 	var keypair ecs.KeyPairItemType
 
@@ -56,7 +104,7 @@ func TestAccAlicloudKeyPair_prefix(t *testing.T) {
 					testAccCheckKeyPairExists(
 						"alicloud_key_pair.prefix", &keypair),
 					testAccCheckKeyPairHasPrefix(
-						"alicloud_key_pair.prefix", &keypair, "terraform-test-key-pair-prefix"),
+						"alicloud_key_pair.prefix", &keypair, "tf-test-key-pair-prefix"),
 				),
 			},
 		},
@@ -103,7 +151,7 @@ func testAccCheckKeyPairExists(n string, keypair *ecs.KeyPairItemType) resource.
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		response, _, err := conn.DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
 			RegionId:    client.Region,
@@ -134,7 +182,7 @@ func testAccCheckKeyPairHasPrefix(n string, keypair *ecs.KeyPairItemType, prefix
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		response, _, err := conn.DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
 			RegionId:    client.Region,
@@ -163,7 +211,7 @@ func testAccCheckKeyPairDestroy(s *terraform.State) error {
 
 		// Try to find the Disk
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		response, _, err := conn.DescribeKeyPairs(&ecs.DescribeKeyPairsArgs{
 			RegionId:    client.Region,
@@ -186,17 +234,18 @@ func testAccCheckKeyPairDestroy(s *terraform.State) error {
 
 const testAccKeyPairConfig = `
 resource "alicloud_key_pair" "basic" {
-	key_name = "terraform-test-key-pair"
+	key_name = "tf-test-key-pair"
 }
 `
 const testAccKeyPairConfigPrefix = `
 resource "alicloud_key_pair" "prefix" {
-	key_name_prefix = "terraform-test-key-pair-prefix"
+	key_name_prefix = "tf-test-key-pair-prefix"
 }
 `
 
 const testAccKeyPairConfigPublicKey = `
 resource "alicloud_key_pair" "publickey" {
+  	key_name = "tf_test_publickey"
   	public_key = "ssh-rsa AAAAB3Nza12345678qwertyuudsfsg"
 }
 `
@@ -23,6 +23,12 @@ func resourceAliyunNatGateway() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"vpc_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -84,12 +90,15 @@ func resourceAliyunNatGateway() *schema.Resource {
 				Required: true,
 				MaxItems: 4,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAliyunNatGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).vpcconn
+	client := meta.(*AliyunClient)
+	conn := client.vpcConn()
 
 	args := &ecs.CreateNatGatewayArgs{
 		RegionId: getRegion(d, meta),
@@ -133,6 +142,14 @@ func resourceAliyunNatGatewayCreate(d *schema.ResourceData, meta interface{}) er
 
 	d.SetId(resp.NatGatewayId)
 
+	if err := client.WaitForNatGateway(d.Id(), NatGatewayStatusAvailable, timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("WaitForNatGateway %s got error: %#v", NatGatewayStatusAvailable, err)
+	}
+
+	if err := setTags(client, VpcTagResourceNatGateway, d); err != nil {
+		return fmt.Errorf("Set tags for nat gateway got error: %#v", err)
+	}
+
 	return resourceAliyunNatGatewayRead(d, meta)
 }
 
@@ -163,13 +180,17 @@ func resourceAliyunNatGatewayRead(d *schema.ResourceData, meta interface{}) erro
 		d.Set("bandwidth_packages", bindWidthPackages)
 	}
 
+	if err := readTags(client, VpcTagResourceNatGateway, d); err != nil {
+		return fmt.Errorf("Describe tags for nat gateway got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := client.vpcConn()
 
 	natGateway, err := client.DescribeNatGateway(d.Id())
 	if err != nil {
@@ -177,6 +198,12 @@ func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	d.Partial(true)
+
+	if err := setTags(client, VpcTagResourceNatGateway, d); err != nil {
+		return fmt.Errorf("Set tags for nat gateway got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
 	attributeUpdate := false
 	args := &ecs.ModifyNatGatewayAttributeArgs{
 		RegionId:     natGateway.RegionId,
@@ -237,6 +264,10 @@ func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) er
 			return fmt.Errorf("%#v %#v", err, *args)
 		}
 
+		if err := client.WaitForNatGateway(d.Id(), NatGatewayStatusAvailable, timeoutInSeconds(d, schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("WaitForNatGateway %s got error: %#v", NatGatewayStatusAvailable, err)
+		}
+
 	}
 	d.Partial(false)
 
@@ -245,10 +276,9 @@ func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) er
 
 func resourceAliyunNatGatewayDelete(d *schema.ResourceData, meta interface{}) error {
 
-	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := meta.(*AliyunClient).vpcConn()
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 
 		packages, err := conn.DescribeBandwidthPackages(&ecs.DescribeBandwidthPackagesArgs{
 			RegionId:     getRegion(d, meta),
@@ -345,8 +375,7 @@ func flattenBandWidthPackages(bandWidthPackageIds []string, meta interface{}, d
 }
 
 func getPackages(packageId string, meta interface{}, d *schema.ResourceData) (*ecs.DescribeBandwidthPackageType, error) {
-	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := meta.(*AliyunClient).vpcConn()
 	packages, err := conn.DescribeBandwidthPackages(&ecs.DescribeBandwidthPackagesArgs{
 		RegionId:           getRegion(d, meta),
 		BandwidthPackageId: packageId,
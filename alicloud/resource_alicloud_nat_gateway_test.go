@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"fmt"
+	"log"
 	"testing"
 
 	"github.com/denverdino/aliyungo/common"
@@ -10,6 +11,50 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_nat_gateway", &resource.Sweeper{
+		Name: "alicloud_nat_gateway",
+		F:    testSweepNatGateways,
+	})
+}
+
+func testSweepNatGateways(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var gateways []ecs.NatGatewaySetType
+	args := &ecs.DescribeNatGatewaysArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		page, pagination, err := client.vpcConn().DescribeNatGateways(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Nat Gateways: %#v", err)
+		}
+		gateways = append(gateways, page...)
+		next := pagination.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range gateways {
+		if !isTestSweepName(v.Name) {
+			log.Printf("[INFO] Skipping Nat Gateway: %s (%s)", v.Name, v.NatGatewayId)
+			continue
+		}
+		log.Printf("[INFO] Deleting Nat Gateway: %s (%s)", v.Name, v.NatGatewayId)
+		if err := testSweepResource(resourceAliyunNatGateway(), client, v.NatGatewayId, nil); err != nil {
+			log.Printf("[ERROR] Failed to delete Nat Gateway (%s (%s)): %s", v.Name, v.NatGatewayId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudNatGateway_basic(t *testing.T) {
 	var nat ecs.NatGatewaySetType
 
@@ -48,7 +93,7 @@ func TestAccAlicloudNatGateway_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_nat_gateway.foo",
 						"name",
-						"test_foo"),
+						"tf_test_foo"),
 					testAccCheckNatgatewayIpAddress("alicloud_nat_gateway.foo", &nat),
 				),
 			},
@@ -187,6 +232,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -195,7 +241,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Small"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 1
 	  bandwidth = 5
@@ -229,6 +275,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -237,7 +284,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Middle"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 1
 	  bandwidth = 5
@@ -263,6 +310,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -271,7 +319,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Large"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 1
 	  bandwidth = 5
@@ -208,12 +208,18 @@ func resourceAlicloudOssBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAlicloudOssBucketCreate(d *schema.ResourceData, meta interface{}) error {
-	ossconn := meta.(*AliyunClient).ossconn
+	client := meta.(*AliyunClient)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 
 	bucket := d.Get("bucket").(string)
 	isExist, err := ossconn.IsBucketExist(bucket)
@@ -255,11 +261,15 @@ func resourceAlicloudOssBucketCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) error {
-	ossconn := meta.(*AliyunClient).ossconn
+	client := meta.(*AliyunClient)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 
 	info, err := ossconn.GetBucketInfo(d.Id())
 	if err != nil {
-		if ossNotFoundError(err) {
+		if NotFoundError(err) {
 			return nil
 		}
 		return err
@@ -278,7 +288,7 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 	// Read the CORS
 	cors, err := ossconn.GetBucketCORS(d.Id())
 	if err != nil {
-		if ossNotFoundError(err) {
+		if NotFoundError(err) {
 			log.Printf("[WARN] OSS bucket: %s, no CORS rule configuration could be found.", d.Id())
 			return nil
 		}
@@ -304,7 +314,7 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 	// Read the website configuration
 	ws, err := ossconn.GetBucketWebsite(d.Id())
 	if err != nil {
-		if ossNotFoundError(err) {
+		if NotFoundError(err) {
 			log.Printf("[WARN] OSS bucket: %s, no website could be found.", d.Id())
 			return nil
 		}
@@ -328,7 +338,7 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 	// Read the logging configuration
 	logging, err := ossconn.GetBucketLogging(d.Id())
 	if err != nil {
-		if ossNotFoundError(err) {
+		if NotFoundError(err) {
 			log.Printf("[WARN] OSS bucket: %s, no logging could be found.", d.Id())
 			return nil
 		}
@@ -364,7 +374,7 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 	referer, err := ossconn.GetBucketReferer(d.Id())
 	var referers []map[string]interface{}
 	if err != nil {
-		if ossNotFoundError(err) {
+		if NotFoundError(err) {
 			log.Printf("[WARN] OSS bucket: %s, no referer configuration could be found.", d.Id())
 			return nil
 		}
@@ -388,7 +398,7 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 	// Read the lifecycle rule configuration
 	lifecycle, err := ossconn.GetBucketLifecycle(d.Id())
 	if err != nil {
-		if ossNotFoundError(err) {
+		if NotFoundError(err) {
 			log.Printf("[WARN] OSS bucket: %s, no lifecycle could be found.", d.Id())
 			return nil
 		}
@@ -426,11 +436,19 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	if err := readTags(client, ossTags{}, d); err != nil {
+		return fmt.Errorf("Describe tags for OSS bucket got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAlicloudOssBucketUpdate(d *schema.ResourceData, meta interface{}) error {
-	ossconn := meta.(*AliyunClient).ossconn
+	client := meta.(*AliyunClient)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 
 	d.Partial(true)
 
@@ -442,44 +460,53 @@ func resourceAlicloudOssBucketUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("cors_rule") {
-		if err := resourceAlicloudOssBucketCorsUpdate(ossconn, d); err != nil {
+		if err := resourceAlicloudOssBucketCorsUpdate(client, d); err != nil {
 			return err
 		}
 		d.SetPartial("cors_rule")
 	}
 
 	if d.HasChange("website") {
-		if err := resourceAlicloudOssBucketWebsiteUpdate(ossconn, d); err != nil {
+		if err := resourceAlicloudOssBucketWebsiteUpdate(client, d); err != nil {
 			return err
 		}
 		d.SetPartial("website")
 	}
 
 	if d.HasChange("logging") {
-		if err := resourceAlicloudOssBucketLoggingUpdate(ossconn, d); err != nil {
+		if err := resourceAlicloudOssBucketLoggingUpdate(client, d); err != nil {
 			return err
 		}
 		d.SetPartial("logging")
 	}
 
 	if d.HasChange("referer_config") {
-		if err := resourceAlicloudOssBucketRefererUpdate(ossconn, d); err != nil {
+		if err := resourceAlicloudOssBucketRefererUpdate(client, d); err != nil {
 			return err
 		}
 		d.SetPartial("referer_config")
 	}
 
 	if d.HasChange("lifecycle_rule") {
-		if err := resourceAlicloudOssBucketLifecycleRuleUpdate(ossconn, d); err != nil {
+		if err := resourceAlicloudOssBucketLifecycleRuleUpdate(client, d); err != nil {
 			return err
 		}
 		d.SetPartial("lifecycle_rule")
 	}
 
+	if err := setTags(client, ossTags{}, d); err != nil {
+		return fmt.Errorf("Set tags for OSS bucket got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
 	d.Partial(false)
 	return resourceAlicloudOssBucketRead(d, meta)
 }
-func resourceAlicloudOssBucketCorsUpdate(ossconn *oss.Client, d *schema.ResourceData) error {
+func resourceAlicloudOssBucketCorsUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 	cors := d.Get("cors_rule").([]interface{})
 	if cors == nil || len(cors) == 0 {
 		err := resource.Retry(3*time.Minute, func() *resource.RetryError {
@@ -523,14 +550,18 @@ func resourceAlicloudOssBucketCorsUpdate(ossconn *oss.Client, d *schema.Resource
 	}
 
 	log.Printf("[DEBUG] Oss bucket: %s, put CORS: %#v", d.Id(), cors)
-	err := ossconn.SetBucketCORS(d.Id(), rules)
+	err = ossconn.SetBucketCORS(d.Id(), rules)
 	if err != nil {
 		return fmt.Errorf("Error putting oss CORS: %s", err)
 	}
 
 	return nil
 }
-func resourceAlicloudOssBucketWebsiteUpdate(ossconn *oss.Client, d *schema.ResourceData) error {
+func resourceAlicloudOssBucketWebsiteUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 	ws := d.Get("website").(*schema.Set)
 	if ws == nil || ws.Len() == 0 {
 		err := resource.Retry(3*time.Minute, func() *resource.RetryError {
@@ -561,7 +592,11 @@ func resourceAlicloudOssBucketWebsiteUpdate(ossconn *oss.Client, d *schema.Resou
 	return nil
 }
 
-func resourceAlicloudOssBucketLoggingUpdate(ossconn *oss.Client, d *schema.ResourceData) error {
+func resourceAlicloudOssBucketLoggingUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 	logging := d.Get("logging").(*schema.Set)
 	if logging == nil || logging.Len() == 0 {
 		err := resource.Retry(3*time.Minute, func() *resource.RetryError {
@@ -591,7 +626,11 @@ func resourceAlicloudOssBucketLoggingUpdate(ossconn *oss.Client, d *schema.Resou
 	return nil
 }
 
-func resourceAlicloudOssBucketRefererUpdate(ossconn *oss.Client, d *schema.ResourceData) error {
+func resourceAlicloudOssBucketRefererUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 	config := d.Get("referer_config").(*schema.Set)
 	if config == nil || config.Len() == 0 {
 		log.Printf("[DEBUG] OSS set bucket referer as nil")
@@ -619,7 +658,11 @@ func resourceAlicloudOssBucketRefererUpdate(ossconn *oss.Client, d *schema.Resou
 
 	return nil
 }
-func resourceAlicloudOssBucketLifecycleRuleUpdate(ossconn *oss.Client, d *schema.ResourceData) error {
+func resourceAlicloudOssBucketLifecycleRuleUpdate(client *AliyunClient, d *schema.ResourceData) error {
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
 	bucket := d.Id()
 	lifecycleRules := d.Get("lifecycle_rule").([]interface{})
 
@@ -684,7 +727,7 @@ func resourceAlicloudOssBucketLifecycleRuleUpdate(ossconn *oss.Client, d *schema
 		rules = append(rules, rule)
 	}
 
-	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
+	err = resource.Retry(3*time.Minute, func() *resource.RetryError {
 		if err := ossconn.SetBucketLifecycle(bucket, rules); err != nil {
 			return resource.NonRetryableError(err)
 		}
@@ -697,7 +740,10 @@ func resourceAlicloudOssBucketLifecycleRuleUpdate(ossconn *oss.Client, d *schema
 	return nil
 }
 func resourceAlicloudOssBucketDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*AliyunClient).ossconn
+	client, err := meta.(*AliyunClient).ossConn()
+	if err != nil {
+		return err
+	}
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		exist, err := client.IsBucketExist(d.Id())
@@ -20,6 +20,9 @@ func resourceAlicloudOssBucketObject() *schema.Resource {
 		Read:   resourceAlicloudOssBucketObjectRead,
 		Update: resourceAlicloudOssBucketObjectPut,
 		Delete: resourceAlicloudOssBucketObjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudOssBucketObjectImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
@@ -106,7 +109,12 @@ func resourceAlicloudOssBucketObject() *schema.Resource {
 
 func resourceAlicloudOssBucketObjectPut(d *schema.ResourceData, meta interface{}) error {
 
-	bucket, err := meta.(*AliyunClient).ossconn.Bucket(d.Get("bucket").(string))
+	client := meta.(*AliyunClient)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
+	bucket, err := ossconn.Bucket(d.Get("bucket").(string))
 	if err != nil {
 		return fmt.Errorf("Error getting bucket: %#v", err)
 	}
@@ -151,7 +159,12 @@ func resourceAlicloudOssBucketObjectPut(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAlicloudOssBucketObjectRead(d *schema.ResourceData, meta interface{}) error {
-	bucket, err := meta.(*AliyunClient).ossconn.Bucket(d.Get("bucket").(string))
+	client := meta.(*AliyunClient)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
+	bucket, err := ossconn.Bucket(d.Get("bucket").(string))
 	if err != nil {
 		return fmt.Errorf("Error getting bucket: %#v", err)
 	}
@@ -163,7 +176,7 @@ func resourceAlicloudOssBucketObjectRead(d *schema.ResourceData, meta interface{
 
 	object, err := bucket.GetObjectDetailedMeta(d.Get("key").(string), options...)
 	if err != nil {
-		if strings.Contains(string(err.Error()), OssBodyNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return fmt.Errorf("To get the Object: %#v but it is not exist in the specified bucket %s.", d.Get("key").(string), d.Get("bucket").(string))
 		}
@@ -182,11 +195,38 @@ func resourceAlicloudOssBucketObjectRead(d *schema.ResourceData, meta interface{
 	d.Set("server_side_encryption", object.Get("ServerSideEncryption"))
 	d.Set("etag", strings.Trim(object.Get("ETag"), `"`))
 
+	acl, err := bucket.GetObjectACL(d.Get("key").(string))
+	if err != nil {
+		return fmt.Errorf("Error Reading Object ACL: %#v", err)
+	}
+	// the object without its own ACL follows the bucket's one
+	if acl.ACL != string(oss.ACLDefault) {
+		d.Set("acl", acl.ACL)
+	}
+
 	return nil
 }
 
+// resourceAlicloudOssBucketObjectImport imports an object by the ID <bucket>:<key>.
+func resourceAlicloudOssBucketObjectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "bucket", "key")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("bucket", parts[0])
+	d.Set("key", parts[1])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAlicloudOssBucketObjectDelete(d *schema.ResourceData, meta interface{}) error {
-	bucket, err := meta.(*AliyunClient).ossconn.Bucket(d.Get("bucket").(string))
+	client := meta.(*AliyunClient)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return err
+	}
+	bucket, err := ossconn.Bucket(d.Get("bucket").(string))
 	if err != nil {
 		return fmt.Errorf("Error getting bucket: %#v", err)
 	}
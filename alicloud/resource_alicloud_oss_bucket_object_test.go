@@ -142,7 +142,11 @@ func testAccCheckOssBucketObjectExistsWithProviders(n string, bucket string, obj
 			if provider.Meta() == nil {
 				continue
 			}
-			client, err := provider.Meta().(*AliyunClient).ossconn.Bucket(bucket)
+			ossconn, err := provider.Meta().(*AliyunClient).ossConn()
+			if err != nil {
+				return err
+			}
+			client, err := ossconn.Bucket(bucket)
 			if err != nil {
 				return fmt.Errorf("Error getting bucket: %#v", err)
 			}
@@ -192,6 +192,48 @@ func TestAccAlicloudOssBucketLifecycle(t *testing.T) {
 		},
 	})
 }
+func TestUnitAlicloudOssBucketAcl(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_oss_bucket.basic",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.buckets) > 0 {
+				return fmt.Errorf("OSS buckets still exist: %d", len(server.buckets))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testAccAlicloudOssBucketBasicConfig(1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_oss_bucket.basic", "id", "test-bucket-basic-1"),
+					resource.TestCheckResourceAttr("alicloud_oss_bucket.basic", "location", "oss-cn-beijing"),
+					resource.TestCheckResourceAttr("alicloud_oss_bucket.basic", "acl", "public-read"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitAlicloudOssBucketAclConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_oss_bucket.basic", "acl", "private"),
+					func(*terraform.State) error {
+						server.lock.Lock()
+						defer server.lock.Unlock()
+						if bucket, ok := server.buckets["test-bucket-basic-1"]; !ok || bucket.acl != "private" {
+							return fmt.Errorf("The ACL of the bucket should be private: %#v", bucket)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckOssBucketExists(n string, b *oss.BucketInfo) resource.TestCheckFunc {
 	providers := []*schema.Provider{testAccProvider}
 	return testAccCheckOssBucketExistsWithProviders(n, b, &providers)
@@ -386,3 +428,10 @@ resource "alicloud_oss_bucket" "lifecycle"{
 }
 `, randInt)
 }
+
+const testUnitAlicloudOssBucketAclConfig = `
+resource "alicloud_oss_bucket" "basic" {
+  bucket = "test-bucket-basic-1"
+  acl = "private"
+}
+`
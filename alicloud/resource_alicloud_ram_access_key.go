@@ -39,7 +39,7 @@ func resourceAlicloudRamAccessKey() *schema.Resource {
 }
 
 func resourceAlicloudRamAccessKeyCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserQueryRequest{}
 	if v, ok := d.GetOk("user_name"); ok && v.(string) != "" {
@@ -61,7 +61,7 @@ func resourceAlicloudRamAccessKeyCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAlicloudRamAccessKeyUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	d.Partial(true)
 
@@ -85,7 +85,7 @@ func resourceAlicloudRamAccessKeyUpdate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAlicloudRamAccessKeyRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserQueryRequest{}
 	if v, ok := d.GetOk("user_name"); ok && v.(string) != "" {
@@ -114,7 +114,7 @@ func resourceAlicloudRamAccessKeyRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAlicloudRamAccessKeyDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UpdateAccessKeyRequest{
 		UserAccessKeyId: d.Id(),
@@ -129,7 +129,7 @@ func resourceAlicloudRamAccessKeyDelete(d *schema.ResourceData, meta interface{}
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeleteAccessKey(args); err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting access key: %#v", err))
@@ -137,7 +137,7 @@ func resourceAlicloudRamAccessKeyDelete(d *schema.ResourceData, meta interface{}
 
 		response, err := conn.ListAccessKeys(queryArgs)
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
@@ -54,7 +54,7 @@ func testAccCheckRamAccessKeyExists(n string, ak *ram.AccessKey) resource.TestCh
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -86,7 +86,7 @@ func testAccCheckRamAccessKeyDestroy(s *terraform.State) error {
 
 		// Try to find the ak
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -102,7 +102,7 @@ func testAccCheckRamAccessKeyDestroy(s *terraform.State) error {
 			}
 		}
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -113,7 +113,7 @@ func testAccCheckRamAccessKeyDestroy(s *terraform.State) error {
 
 const testAccRamAccessKeyConfig = `
 resource "alicloud_ram_user" "user" {
-  name = "username"
+  name = "tf_test_username"
   display_name = "displayname"
   mobile = "86-18888888888"
   email = "hello.uuu@aaa.com"
@@ -25,7 +25,7 @@ func resourceAlicloudRamAccountAlias() *schema.Resource {
 }
 
 func resourceAlicloudRamAccountAliasCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.AccountAliasRequest{
 		AccountAlias: d.Get("account_alias").(string),
@@ -40,7 +40,7 @@ func resourceAlicloudRamAccountAliasCreate(d *schema.ResourceData, meta interfac
 }
 
 func resourceAlicloudRamAccountAliasRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	response, err := conn.GetAccountAlias()
 	if err != nil {
@@ -52,7 +52,7 @@ func resourceAlicloudRamAccountAliasRead(d *schema.ResourceData, meta interface{
 }
 
 func resourceAlicloudRamAccountAliasDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	if _, err := conn.ClearAccountAlias(); err != nil {
 		return fmt.Errorf("ClearAccountAlias got an error: %#v", err)
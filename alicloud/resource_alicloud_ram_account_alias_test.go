@@ -50,7 +50,7 @@ func testAccCheckRamAccountAliasExists(n string, alias *string) resource.TestChe
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		response, err := conn.GetAccountAlias()
 
@@ -71,12 +71,12 @@ func testAccCheckRamAccountAliasDestroy(s *terraform.State) error {
 
 		// Try to find the alias
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		_, err := conn.GetAccountAlias()
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -40,7 +40,7 @@ func resourceAlicloudRamGroup() *schema.Resource {
 }
 
 func resourceAlicloudRamGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.GroupRequest{
 		Group: ram.Group{
@@ -58,7 +58,7 @@ func resourceAlicloudRamGroupCreate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAlicloudRamGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	d.Partial(true)
 
@@ -92,7 +92,7 @@ func resourceAlicloudRamGroupUpdate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceAlicloudRamGroupRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.GroupQueryRequest{
 		GroupName: d.Id(),
@@ -100,7 +100,7 @@ func resourceAlicloudRamGroupRead(d *schema.ResourceData, meta interface{}) erro
 
 	response, err := conn.GetGroup(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 		}
 		return fmt.Errorf("GetGroup got an error: %#v", err)
@@ -113,7 +113,7 @@ func resourceAlicloudRamGroupRead(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAlicloudRamGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.GroupQueryRequest{
 		GroupName: d.Id(),
@@ -132,7 +132,7 @@ func resourceAlicloudRamGroupDelete(d *schema.ResourceData, meta interface{}) er
 					UserName:  v.UserName,
 					GroupName: args.GroupName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error while deleting user %s from group %s: %#v", v.UserName, d.Id(), err)
 				}
 			}
@@ -153,7 +153,7 @@ func resourceAlicloudRamGroupDelete(d *schema.ResourceData, meta interface{}) er
 					},
 					GroupName: args.GroupName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error while detaching policy %s from group %s: %#v", v.PolicyName, d.Id(), err)
 				}
 			}
@@ -162,7 +162,7 @@ func resourceAlicloudRamGroupDelete(d *schema.ResourceData, meta interface{}) er
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeleteGroup(args); err != nil {
-			if IsExceptedError(err, DeleteConflictGroupUser) || IsExceptedError(err, DeleteConflictGroupPolicy) {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("The group can not has any user member or any attached policy while deleting the group.- you can set force with true to force delete the group."))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting group %s: %#v, you can set force with true to force delete the group.", d.Id(), err))
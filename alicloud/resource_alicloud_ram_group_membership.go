@@ -16,6 +16,9 @@ func resourceAlicloudRamGroupMembership() *schema.Resource {
 		Read:   resourceAlicloudRamGroupMembershipRead,
 		Update: resourceAlicloudRamGroupMembershipUpdate,
 		Delete: resourceAlicloudRamGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudRamGroupMembershipImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"group_name": &schema.Schema{
@@ -38,27 +41,48 @@ func resourceAlicloudRamGroupMembership() *schema.Resource {
 }
 
 func resourceAlicloudRamGroupMembershipCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	client := meta.(*AliyunClient)
 
 	group := d.Get("group_name").(string)
 	users := expandStringList(d.Get("user_names").(*schema.Set).List())
 
-	err := addUsersToGroup(conn, users, group)
+	err := addUsersToGroup(client, users, group)
 	if err != nil {
 		return fmt.Errorf("AddUserToGroup got an error: %#v", err)
 	}
 
+	d.SetId(ramGroupMembershipId(group, users))
+
+	return resourceAlicloudRamGroupMembershipUpdate(d, meta)
+}
+
+func ramGroupMembershipId(group string, users []string) string {
 	var buf bytes.Buffer
 	for _, user := range users {
 		buf.WriteString(fmt.Sprintf("%s-", user))
 	}
-	d.SetId(group + strconv.Itoa(hashcode.String(buf.String())))
+	return group + strconv.Itoa(hashcode.String(buf.String()))
+}
 
-	return resourceAlicloudRamGroupMembershipUpdate(d, meta)
+// resourceAlicloudRamGroupMembershipImport imports the membership of a group by the group's name,
+// and sets the ID which a fresh create would by the group's current users.
+func resourceAlicloudRamGroupMembershipImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	group := d.Id()
+	d.Set("group_name", group)
+	if err := resourceAlicloudRamGroupMembershipRead(d, meta); err != nil {
+		return nil, err
+	}
+
+	users := expandStringList(d.Get("user_names").(*schema.Set).List())
+	if len(users) == 0 {
+		return nil, fmt.Errorf("The RAM group %s has no users to import.", group)
+	}
+	d.SetId(ramGroupMembershipId(group, users))
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceAlicloudRamGroupMembershipUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	client := meta.(*AliyunClient)
 
 	d.Partial(true)
 
@@ -78,11 +102,11 @@ func resourceAlicloudRamGroupMembershipUpdate(d *schema.ResourceData, meta inter
 		add := expandStringList(newSet.Difference(oldSet).List())
 		group := d.Get("group_name").(string)
 
-		if err := removeUsersFromGroup(conn, remove, group); err != nil {
+		if err := removeUsersFromGroup(client, remove, group); err != nil {
 			return fmt.Errorf("removeUsersFromGroup got an error: %#v", err)
 		}
 
-		if err := addUsersToGroup(conn, add, group); err != nil {
+		if err := addUsersToGroup(client, add, group); err != nil {
 			return fmt.Errorf("addUsersToGroup got an error: %#v", err)
 		}
 	}
@@ -92,7 +116,7 @@ func resourceAlicloudRamGroupMembershipUpdate(d *schema.ResourceData, meta inter
 }
 
 func resourceAlicloudRamGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.GroupQueryRequest{
 		GroupName: d.Get("group_name").(string),
@@ -100,7 +124,7 @@ func resourceAlicloudRamGroupMembershipRead(d *schema.ResourceData, meta interfa
 
 	response, err := conn.ListUsersForGroup(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 		}
 		return fmt.Errorf("ListUsersForGroup got an error: %#v", err)
@@ -122,19 +146,20 @@ func resourceAlicloudRamGroupMembershipRead(d *schema.ResourceData, meta interfa
 }
 
 func resourceAlicloudRamGroupMembershipDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	client := meta.(*AliyunClient)
 
 	users := expandStringList(d.Get("user_names").(*schema.Set).List())
 	group := d.Get("group_name").(string)
 
-	if err := removeUsersFromGroup(conn, users, group); err != nil {
+	if err := removeUsersFromGroup(client, users, group); err != nil {
 		return fmt.Errorf("removeUsersFromGroup got an error: %#v", err)
 	}
 
 	return nil
 }
 
-func addUsersToGroup(conn ram.RamClientInterface, users []string, group string) error {
+func addUsersToGroup(client *AliyunClient, users []string, group string) error {
+	conn := client.ramConn()
 	for _, u := range users {
 		_, err := conn.AddUserToGroup(ram.UserRelateGroupRequest{
 			UserName:  u,
@@ -148,14 +173,15 @@ func addUsersToGroup(conn ram.RamClientInterface, users []string, group string)
 	return nil
 }
 
-func removeUsersFromGroup(conn ram.RamClientInterface, users []string, group string) error {
+func removeUsersFromGroup(client *AliyunClient, users []string, group string) error {
+	conn := client.ramConn()
 	for _, u := range users {
 		_, err := conn.RemoveUserFromGroup(ram.UserRelateGroupRequest{
 			UserName:  u,
 			GroupName: group,
 		})
 
-		if err != nil && !RamEntityNotExist(err) {
+		if err != nil && !NotFoundError(err) {
 			return err
 		}
 	}
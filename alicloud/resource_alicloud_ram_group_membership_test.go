@@ -54,7 +54,7 @@ func testAccCheckRamGroupMembershipExists(n string, user *ram.User, user1 *ram.U
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.GroupQueryRequest{
 			GroupName: group.GroupName,
@@ -94,7 +94,7 @@ func testAccCheckRamGroupMembershipDestroy(s *terraform.State) error {
 
 		// Try to find the membership
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.GroupQueryRequest{
 			GroupName: rs.Primary.Attributes["group_name"],
@@ -103,7 +103,7 @@ func testAccCheckRamGroupMembershipDestroy(s *terraform.State) error {
 		response, err := conn.ListUsersForGroup(request)
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -124,7 +124,7 @@ func testAccCheckRamGroupMembershipDestroy(s *terraform.State) error {
 
 const testAccRamGroupMembershipConfig = `
 resource "alicloud_ram_user" "user" {
-  name = "username"
+  name = "tf_test_username"
   display_name = "displayname"
   mobile = "86-18888888888"
   email = "hello.uuu@aaa.com"
@@ -132,7 +132,7 @@ resource "alicloud_ram_user" "user" {
 }
 
 resource "alicloud_ram_user" "user1" {
-  name = "username1"
+  name = "tf_test_username1"
   display_name = "displayname1"
   mobile = "86-18888888888"
   email = "hello.uuuu@aaa.com"
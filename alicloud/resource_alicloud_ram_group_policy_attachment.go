@@ -14,6 +14,9 @@ func resourceAlicloudRamGroupPolicyAtatchment() *schema.Resource {
 		Create: resourceAlicloudRamGroupPolicyAttachmentCreate,
 		Read:   resourceAlicloudRamGroupPolicyAttachmentRead,
 		Delete: resourceAlicloudRamGroupPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudRamGroupPolicyAttachmentImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"group_name": &schema.Schema{
@@ -39,7 +42,7 @@ func resourceAlicloudRamGroupPolicyAtatchment() *schema.Resource {
 }
 
 func resourceAlicloudRamGroupPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.AttachPolicyToGroupRequest{
 		PolicyRequest: ram.PolicyRequest{
@@ -58,7 +61,7 @@ func resourceAlicloudRamGroupPolicyAttachmentCreate(d *schema.ResourceData, meta
 }
 
 func resourceAlicloudRamGroupPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.GroupQueryRequest{
 		GroupName: d.Get("group_name").(string),
@@ -66,7 +69,7 @@ func resourceAlicloudRamGroupPolicyAttachmentRead(d *schema.ResourceData, meta i
 
 	response, err := conn.ListPoliciesForGroup(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 		}
 		return fmt.Errorf("Get list policies for group got an error: %#v", err)
@@ -87,8 +90,22 @@ func resourceAlicloudRamGroupPolicyAttachmentRead(d *schema.ResourceData, meta i
 	return nil
 }
 
+// resourceAlicloudRamGroupPolicyAttachmentImport imports an attachment by the ID <group_name>:<policy_name>:<policy_type>.
+func resourceAlicloudRamGroupPolicyAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "group_name", "policy_name", "policy_type")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("group_name", parts[0])
+	d.Set("policy_name", parts[1])
+	d.Set("policy_type", parts[2])
+	d.SetId("group" + parts[1] + parts[2] + parts[0])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAlicloudRamGroupPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.AttachPolicyToGroupRequest{
 		PolicyRequest: ram.PolicyRequest{
@@ -100,7 +117,7 @@ func resourceAlicloudRamGroupPolicyAttachmentDelete(d *schema.ResourceData, meta
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DetachPolicyFromGroup(args); err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting group policy attachment: %#v", err))
@@ -108,7 +125,7 @@ func resourceAlicloudRamGroupPolicyAttachmentDelete(d *schema.ResourceData, meta
 
 		response, err := conn.ListPoliciesForGroup(ram.GroupQueryRequest{GroupName: args.GroupName})
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 
@@ -56,7 +56,7 @@ func testAccCheckRamGroupExists(n string, group *ram.Group) resource.TestCheckFu
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.GroupQueryRequest{
 			GroupName: rs.Primary.Attributes["name"],
@@ -82,7 +82,7 @@ func testAccCheckRamGroupDestroy(s *terraform.State) error {
 
 		// Try to find the group
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.GroupQueryRequest{
 			GroupName: rs.Primary.Attributes["name"],
@@ -91,7 +91,7 @@ func testAccCheckRamGroupDestroy(s *terraform.State) error {
 		_, err := conn.GetGroup(request)
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
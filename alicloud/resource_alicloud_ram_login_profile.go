@@ -46,7 +46,7 @@ func resourceAlicloudRamLoginProfile() *schema.Resource {
 }
 
 func resourceAlicloudRamLoginProfileCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.ProfileRequest{
 		UserName:              d.Get("user_name").(string),
@@ -64,7 +64,7 @@ func resourceAlicloudRamLoginProfileCreate(d *schema.ResourceData, meta interfac
 }
 
 func resourceAlicloudRamLoginProfileUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	d.Partial(true)
 
@@ -102,7 +102,7 @@ func resourceAlicloudRamLoginProfileUpdate(d *schema.ResourceData, meta interfac
 }
 
 func resourceAlicloudRamLoginProfileRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserQueryRequest{
 		UserName: d.Id(),
@@ -110,7 +110,7 @@ func resourceAlicloudRamLoginProfileRead(d *schema.ResourceData, meta interface{
 
 	response, err := conn.GetLoginProfile(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 		}
 		return fmt.Errorf("GetLoginProfile got an error: %#v", err)
@@ -124,7 +124,7 @@ func resourceAlicloudRamLoginProfileRead(d *schema.ResourceData, meta interface{
 }
 
 func resourceAlicloudRamLoginProfileDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserQueryRequest{
 		UserName: d.Id(),
@@ -132,7 +132,7 @@ func resourceAlicloudRamLoginProfileDelete(d *schema.ResourceData, meta interfac
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeleteLoginProfile(args); err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting login profile: %#v", err))
@@ -140,7 +140,7 @@ func resourceAlicloudRamLoginProfileDelete(d *schema.ResourceData, meta interfac
 
 		response, err := conn.GetLoginProfile(args)
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 
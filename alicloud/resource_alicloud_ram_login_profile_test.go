@@ -50,7 +50,7 @@ func testAccCheckRamLoginProfileExists(n string, profile *ram.LoginProfile) reso
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -75,7 +75,7 @@ func testAccCheckRamLoginProfileDestroy(s *terraform.State) error {
 
 		// Try to find the login profile
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -84,7 +84,7 @@ func testAccCheckRamLoginProfileDestroy(s *terraform.State) error {
 		_, err := conn.GetLoginProfile(request)
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -95,7 +95,7 @@ func testAccCheckRamLoginProfileDestroy(s *terraform.State) error {
 
 const testAccRamLoginProfileConfig = `
 resource "alicloud_ram_user" "user" {
-  name = "username"
+  name = "tf_test_username"
   display_name = "displayname"
   mobile = "86-18888888888"
   email = "hello.uuu@aaa.com"
@@ -106,7 +106,7 @@ func resourceAlicloudRamPolicy() *schema.Resource {
 }
 
 func resourceAlicloudRamPolicyCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args, err := buildAlicloudRamPolicyCreateArgs(d, meta)
 	if err != nil {
@@ -123,7 +123,7 @@ func resourceAlicloudRamPolicyCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudRamPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	d.Partial(true)
 
 	args, attributeUpdate, err := buildAlicloudRamPolicyUpdateArgs(d, meta)
@@ -143,7 +143,7 @@ func resourceAlicloudRamPolicyUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAlicloudRamPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.PolicyRequest{
 		PolicyName: d.Id(),
@@ -152,7 +152,7 @@ func resourceAlicloudRamPolicyRead(d *schema.ResourceData, meta interface{}) err
 
 	policyResp, err := conn.GetPolicy(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 		}
 		return fmt.Errorf("GetPolicy got an error: %#v", err)
@@ -182,7 +182,7 @@ func resourceAlicloudRamPolicyRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudRamPolicyDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.PolicyRequest{
 		PolicyName: d.Id(),
@@ -203,7 +203,7 @@ func resourceAlicloudRamPolicyDelete(d *schema.ResourceData, meta interface{}) e
 					PolicyRequest: args,
 					UserName:      v.UserName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error detaching policy %s from user %s:%#v", d.Id(), v.UserId, err)
 				}
 			}
@@ -215,7 +215,7 @@ func resourceAlicloudRamPolicyDelete(d *schema.ResourceData, meta interface{}) e
 					PolicyRequest: args,
 					GroupName:     v.GroupName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error detaching policy %s from group %s:%#v", d.Id(), v.GroupName, err)
 				}
 			}
@@ -227,7 +227,7 @@ func resourceAlicloudRamPolicyDelete(d *schema.ResourceData, meta interface{}) e
 					PolicyRequest: args,
 					RoleName:      v.RoleName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error detaching policy %s from role %s:%#v", d.Id(), v.RoleId, err)
 				}
 			}
@@ -242,7 +242,7 @@ func resourceAlicloudRamPolicyDelete(d *schema.ResourceData, meta interface{}) e
 			for _, v := range pvResp.PolicyVersions.PolicyVersion {
 				if !v.IsDefaultVersion {
 					args.VersionId = v.VersionId
-					if _, err = conn.DeletePolicyVersion(args); err != nil && !RamEntityNotExist(err) {
+					if _, err = conn.DeletePolicyVersion(args); err != nil && !NotFoundError(err) {
 						return fmt.Errorf("Error delete policy version %s for policy %s:%#v", v.VersionId, d.Id(), err)
 					}
 				}
@@ -252,12 +252,12 @@ func resourceAlicloudRamPolicyDelete(d *schema.ResourceData, meta interface{}) e
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeletePolicy(args); err != nil {
-			if IsExceptedError(err, DeleteConflictPolicyUser) || IsExceptedError(err, DeleteConflictPolicyGroup) || IsExceptedError(err, DeleteConflictRolePolicy) {
-				return resource.RetryableError(fmt.Errorf("The policy can not been attached to any user or group or role while deleting the policy. - you can set force with true to force delete the policy."))
-			}
 			if IsExceptedError(err, DeleteConflictPolicyVersion) {
 				return resource.RetryableError(fmt.Errorf("The policy can not has any version except the defaul version. - you can set force with true to force delete the policy."))
 			}
+			if IsConflictError(err) {
+				return resource.RetryableError(fmt.Errorf("The policy can not been attached to any user or group or role while deleting the policy. - you can set force with true to force delete the policy."))
+			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting policy %s: %#v", d.Id(), err))
 		}
 		return nil
@@ -79,7 +79,7 @@ func resourceAlicloudRamRole() *schema.Resource {
 }
 
 func resourceAlicloudRamRoleCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args, err := buildAlicloudRamRoleCreateArgs(d, meta)
 	if err != nil {
@@ -96,7 +96,7 @@ func resourceAlicloudRamRoleCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudRamRoleUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	d.Partial(true)
 
@@ -116,7 +116,7 @@ func resourceAlicloudRamRoleUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudRamRoleRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.RoleQueryRequest{
 		RoleName: d.Id(),
@@ -124,7 +124,7 @@ func resourceAlicloudRamRoleRead(d *schema.ResourceData, meta interface{}) error
 
 	response, err := conn.GetRole(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 		}
 		return fmt.Errorf("GetRole got an error: %v", err)
@@ -150,7 +150,7 @@ func resourceAlicloudRamRoleRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAlicloudRamRoleDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.RoleQueryRequest{
 		RoleName: d.Id(),
@@ -172,7 +172,7 @@ func resourceAlicloudRamRoleDelete(d *schema.ResourceData, meta interface{}) err
 					},
 					RoleName: d.Id(),
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error detach Policy from Role %s: %#v", d.Id(), err)
 				}
 			}
@@ -180,7 +180,7 @@ func resourceAlicloudRamRoleDelete(d *schema.ResourceData, meta interface{}) err
 	}
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeleteRole(args); err != nil {
-			if IsExceptedError(err, DeleteConflictRolePolicy) {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("The role can not has any attached policy while deleting the role. - you can set force with true to force delete the role."))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting role %s: %#v, you can set force with true to force delete the role.", d.Id(), err))
@@ -17,6 +17,9 @@ func resourceAlicloudRamRoleAttachment() *schema.Resource {
 		Create: resourceAlicloudInstanceRoleAttachmentCreate,
 		Read:   resourceAlicloudInstanceRoleAttachmentRead,
 		Delete: resourceAlicloudInstanceRoleAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudInstanceRoleAttachmentImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role_name": &schema.Schema{
@@ -37,7 +40,7 @@ func resourceAlicloudRamRoleAttachment() *schema.Resource {
 
 func resourceAlicloudInstanceRoleAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.ecsconn
+	conn := client.ecsConn()
 
 	instanceIds := convertListToJsonString(d.Get("instance_ids").(*schema.Set).List())
 
@@ -65,7 +68,7 @@ func resourceAlicloudInstanceRoleAttachmentCreate(d *schema.ResourceData, meta i
 }
 
 func resourceAlicloudInstanceRoleAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	roleName := strings.Split(d.Id(), ":")[0]
 	instanceIds := strings.Split(d.Id(), ":")[1]
 
@@ -80,7 +83,7 @@ func resourceAlicloudInstanceRoleAttachmentRead(d *schema.ResourceData, meta int
 			if IsExceptedError(err, RoleAttachmentUnExpectedJson) {
 				return resource.RetryableError(fmt.Errorf("Please trying again."))
 			}
-			if IsExceptedError(err, InvalidRamRoleNotFound) {
+			if NotFoundError(err) {
 				d.SetId("")
 				return nil
 			}
@@ -108,8 +111,29 @@ func resourceAlicloudInstanceRoleAttachmentRead(d *schema.ResourceData, meta int
 	})
 }
 
+// resourceAlicloudInstanceRoleAttachmentImport imports an attachment by the ID <role_name>:<instance_id>,<instance_id>...
+func resourceAlicloudInstanceRoleAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "role_name", "instance_ids")
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []interface{}
+	for _, id := range strings.Split(strings.Trim(strings.Replace(parts[1], "\"", "", -1), "[]"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("Invalid import ID %q. Expected format: <role_name>:<instance_id>,<instance_id>.", d.Id())
+	}
+
+	d.SetId(parts[0] + ":" + convertListToJsonString(ids))
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAlicloudInstanceRoleAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	roleName := strings.Split(d.Id(), ":")[0]
 	instanceIds := strings.Split(d.Id(), ":")[1]
 
@@ -57,7 +57,7 @@ func testAccCheckRamRoleAttachmentExists(n string, instanceA *ecs.InstanceAttrib
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		request := &ecs.AttachInstancesArgs{
 			RegionId:    client.Region,
@@ -93,7 +93,7 @@ func testAccCheckRamRoleAttachmentDestroy(s *terraform.State) error {
 
 		// Try to find the attachment
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 
 		request := &ecs.AttachInstancesArgs{
 			RegionId:    client.Region,
@@ -105,7 +105,7 @@ func testAccCheckRamRoleAttachmentDestroy(s *terraform.State) error {
 			if IsExceptedError(err, RoleAttachmentUnExpectedJson) {
 				continue
 			}
-			if IsExceptedError(err, InvalidInstanceIdNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			if err == nil {
@@ -136,6 +136,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+ 	name = "tf_test_foo"
  	vpc_id = "${alicloud_vpc.foo.id}"
  	cidr_block = "172.16.0.0/21"
  	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -161,7 +162,7 @@ resource "alicloud_instance" "instance" {
 	internet_max_bandwidth_out = 5
 	allocate_public_ip = true
 	security_groups = ["${alicloud_security_group.tf_test_foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 }
 
 resource "alicloud_ram_role" "role" {
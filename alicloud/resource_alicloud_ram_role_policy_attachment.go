@@ -15,6 +15,9 @@ func resourceAlicloudRamRolePolicyAttachment() *schema.Resource {
 		Read:   resourceAlicloudRamRolePolicyAttachmentRead,
 		//Update: resourceAlicloudRamRolePolicyAttachmentUpdate,
 		Delete: resourceAlicloudRamRolePolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudRamRolePolicyAttachmentImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"role_name": &schema.Schema{
@@ -40,7 +43,7 @@ func resourceAlicloudRamRolePolicyAttachment() *schema.Resource {
 }
 
 func resourceAlicloudRamRolePolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 	args := ram.AttachPolicyToRoleRequest{
 		PolicyRequest: ram.PolicyRequest{
 			PolicyName: d.Get("policy_name").(string),
@@ -58,7 +61,7 @@ func resourceAlicloudRamRolePolicyAttachmentCreate(d *schema.ResourceData, meta
 }
 
 func resourceAlicloudRamRolePolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.RoleQueryRequest{
 		RoleName: d.Get("role_name").(string),
@@ -84,8 +87,22 @@ func resourceAlicloudRamRolePolicyAttachmentRead(d *schema.ResourceData, meta in
 	return nil
 }
 
+// resourceAlicloudRamRolePolicyAttachmentImport imports an attachment by the ID <role_name>:<policy_name>:<policy_type>.
+func resourceAlicloudRamRolePolicyAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "role_name", "policy_name", "policy_type")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("role_name", parts[0])
+	d.Set("policy_name", parts[1])
+	d.Set("policy_type", parts[2])
+	d.SetId("role" + parts[1] + parts[2] + parts[0])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAlicloudRamRolePolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.AttachPolicyToRoleRequest{
 		PolicyRequest: ram.PolicyRequest{
@@ -97,7 +114,7 @@ func resourceAlicloudRamRolePolicyAttachmentDelete(d *schema.ResourceData, meta
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DetachPolicyFromRole(args); err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting role policy attachment: %#v", err))
@@ -105,7 +122,7 @@ func resourceAlicloudRamRolePolicyAttachmentDelete(d *schema.ResourceData, meta
 
 		response, err := conn.ListPoliciesForRole(ram.RoleQueryRequest{RoleName: args.RoleName})
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
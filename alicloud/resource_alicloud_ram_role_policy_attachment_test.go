@@ -52,7 +52,7 @@ func testAccCheckRamRolePolicyAttachmentExists(n string, policy *ram.Policy, rol
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.RoleQueryRequest{
 			RoleName: role.RoleName,
@@ -82,7 +82,7 @@ func testAccCheckRamRolePolicyAttachmentDestroy(s *terraform.State) error {
 
 		// Try to find the attachment
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.RoleQueryRequest{
 			RoleName: rs.Primary.Attributes["role_name"],
@@ -91,7 +91,7 @@ func testAccCheckRamRolePolicyAttachmentDestroy(s *terraform.State) error {
 		response, err := conn.ListPoliciesForRole(request)
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -53,7 +53,7 @@ func resourceAlicloudRamUser() *schema.Resource {
 }
 
 func resourceAlicloudRamUserCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserRequest{
 		User: ram.User{
@@ -71,7 +71,7 @@ func resourceAlicloudRamUserCreate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudRamUserUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	d.Partial(true)
 
@@ -124,7 +124,7 @@ func resourceAlicloudRamUserUpdate(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceAlicloudRamUserRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserQueryRequest{
 		UserName: d.Id(),
@@ -132,7 +132,7 @@ func resourceAlicloudRamUserRead(d *schema.ResourceData, meta interface{}) error
 
 	response, err := conn.GetUser(args)
 	if err != nil {
-		if RamEntityNotExist(err) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -149,7 +149,7 @@ func resourceAlicloudRamUserRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAlicloudRamUserDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	userName := d.Id()
 	args := ram.UserQueryRequest{
@@ -168,7 +168,7 @@ func resourceAlicloudRamUserDelete(d *schema.ResourceData, meta interface{}) err
 					UserAccessKeyId: v.AccessKeyId,
 					UserName:        userName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error deleting access key %s: %#v", v.AccessKeyId, err)
 				}
 			}
@@ -188,7 +188,7 @@ func resourceAlicloudRamUserDelete(d *schema.ResourceData, meta interface{}) err
 					},
 					UserName: userName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error deleting policy %s: %#v", v.PolicyName, err)
 				}
 			}
@@ -205,19 +205,19 @@ func resourceAlicloudRamUserDelete(d *schema.ResourceData, meta interface{}) err
 					UserName:  userName,
 					GroupName: v.GroupName,
 				})
-				if err != nil && !RamEntityNotExist(err) {
+				if err != nil && !NotFoundError(err) {
 					return fmt.Errorf("Error deleting group %s: %#v", v.GroupName, err)
 				}
 			}
 		}
 
 		// delete login profile for this user
-		if _, err = conn.DeleteLoginProfile(args); err != nil && !RamEntityNotExist(err) {
+		if _, err = conn.DeleteLoginProfile(args); err != nil && !NotFoundError(err) {
 			return fmt.Errorf("Error deleting login profile for User (%s): %#v", d.Id(), err)
 		}
 
 		// unbind MFA device for this user
-		if _, err = conn.UnbindMFADevice(args); err != nil && !RamEntityNotExist(err) {
+		if _, err = conn.UnbindMFADevice(args); err != nil && !NotFoundError(err) {
 			return fmt.Errorf("Error deleting login profile for User (%s): %#v", d.Id(), err)
 		}
 
@@ -225,9 +225,7 @@ func resourceAlicloudRamUserDelete(d *schema.ResourceData, meta interface{}) err
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeleteUser(args); err != nil {
-			if IsExceptedError(err, DeleteConflictUserAccessKey) || IsExceptedError(err, DeleteConflictUserGroup) ||
-				IsExceptedError(err, DeleteConflictUserPolicy) || IsExceptedError(err, DeleteConflictUserLoginProfile) ||
-				IsExceptedError(err, DeleteConflictUserMFADevice) {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("The user can not has any access keys or login profile or attached group or attached policies or attached mfa device while deleting the user.- you can set force with true to force delete the user."))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting user %s: %#v, you can set force with true to force delete the user.", d.Id(), err))
@@ -14,6 +14,9 @@ func resourceAlicloudRamUserPolicyAtatchment() *schema.Resource {
 		Create: resourceAlicloudRamUserPolicyAttachmentCreate,
 		Read:   resourceAlicloudRamUserPolicyAttachmentRead,
 		Delete: resourceAlicloudRamUserPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudRamUserPolicyAttachmentImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"user_name": &schema.Schema{
@@ -39,7 +42,7 @@ func resourceAlicloudRamUserPolicyAtatchment() *schema.Resource {
 }
 
 func resourceAlicloudRamUserPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.AttachPolicyRequest{
 		PolicyRequest: ram.PolicyRequest{
@@ -58,7 +61,7 @@ func resourceAlicloudRamUserPolicyAttachmentCreate(d *schema.ResourceData, meta
 }
 
 func resourceAlicloudRamUserPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.UserQueryRequest{
 		UserName: d.Get("user_name").(string),
@@ -84,8 +87,22 @@ func resourceAlicloudRamUserPolicyAttachmentRead(d *schema.ResourceData, meta in
 	return nil
 }
 
+// resourceAlicloudRamUserPolicyAttachmentImport imports an attachment by the ID <user_name>:<policy_name>:<policy_type>.
+func resourceAlicloudRamUserPolicyAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "user_name", "policy_name", "policy_type")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("user_name", parts[0])
+	d.Set("policy_name", parts[1])
+	d.Set("policy_type", parts[2])
+	d.SetId("user" + parts[1] + parts[2] + parts[0])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAlicloudRamUserPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ramconn
+	conn := meta.(*AliyunClient).ramConn()
 
 	args := ram.AttachPolicyRequest{
 		PolicyRequest: ram.PolicyRequest{
@@ -97,7 +114,7 @@ func resourceAlicloudRamUserPolicyAttachmentDelete(d *schema.ResourceData, meta
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DetachPolicyFromUser(args); err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting user policy attachment: %#v", err))
@@ -105,7 +122,7 @@ func resourceAlicloudRamUserPolicyAttachmentDelete(d *schema.ResourceData, meta
 
 		response, err := conn.ListPoliciesForUser(ram.UserQueryRequest{UserName: args.UserName})
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 
@@ -52,7 +52,7 @@ func testAccCheckRamUserPolicyAttachmentExists(n string, policy *ram.Policy, use
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: user.UserName,
@@ -82,7 +82,7 @@ func testAccCheckRamUserPolicyAttachmentDestroy(s *terraform.State) error {
 
 		// Try to find the attachment
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -91,7 +91,7 @@ func testAccCheckRamUserPolicyAttachmentDestroy(s *terraform.State) error {
 		response, err := conn.ListPoliciesForUser(request)
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -126,7 +126,7 @@ resource "alicloud_ram_policy" "policy" {
 }
 
 resource "alicloud_ram_user" "user" {
-  name = "username"
+  name = "tf_test_username"
   display_name = "displayname"
   mobile = "86-18888888888"
   email = "hello.uuu@aaa.com"
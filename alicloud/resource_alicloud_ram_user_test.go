@@ -10,6 +10,49 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_ram_user", &resource.Sweeper{
+		Name: "alicloud_ram_user",
+		F:    testSweepRamUsers,
+	})
+}
+
+func testSweepRamUsers(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var users []ram.User
+	args := ram.ListUserRequest{MaxItems: 100}
+	for {
+		resp, err := client.ramConn().ListUsers(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving RAM Users: %#v", err)
+		}
+		users = append(users, resp.Users.User...)
+		if !resp.IsTruncated {
+			break
+		}
+		args.Marker = resp.Marker
+	}
+
+	for _, v := range users {
+		if !isTestSweepName(v.UserName) {
+			log.Printf("[INFO] Skipping RAM User: %s", v.UserName)
+			continue
+		}
+		log.Printf("[INFO] Deleting RAM User: %s", v.UserName)
+		// force deletes the access keys, policies, groups and login profile of the user along with it
+		if err := testSweepResource(resourceAlicloudRamUser(), client, v.UserName, map[string]interface{}{
+			"force": true,
+		}); err != nil {
+			log.Printf("[ERROR] Failed to delete RAM User (%s): %s", v.UserName, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudRamUser_basic(t *testing.T) {
 	var v ram.User
 
@@ -32,7 +75,7 @@ func TestAccAlicloudRamUser_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_ram_user.user",
 						"name",
-						"username"),
+						"tf_test_username"),
 					resource.TestCheckResourceAttr(
 						"alicloud_ram_user.user",
 						"display_name",
@@ -48,6 +91,43 @@ func TestAccAlicloudRamUser_basic(t *testing.T) {
 
 }
 
+func TestUnitAlicloudRamUser_update(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_ram_user.user",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.ramUsers) > 0 {
+				return fmt.Errorf("RAM users still exist: %d", len(server.ramUsers))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testAccRamUserConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "name", "tf_test_username"),
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "display_name", "displayname"),
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "mobile", "86-18888888888"),
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "comments", "yoyoyo"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitRamUserConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "display_name", "displayname2"),
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "email", "hello.new@aaa.com"),
+					resource.TestCheckResourceAttr("alicloud_ram_user.user", "comments", "yoyoyo"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckRamUserExists(n string, user *ram.User) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -60,7 +140,7 @@ func testAccCheckRamUserExists(n string, user *ram.User) resource.TestCheckFunc
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -86,7 +166,7 @@ func testAccCheckRamUserDestroy(s *terraform.State) error {
 
 		// Try to find the user
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ramconn
+		conn := client.ramConn()
 
 		request := ram.UserQueryRequest{
 			UserName: rs.Primary.Attributes["user_name"],
@@ -95,7 +175,7 @@ func testAccCheckRamUserDestroy(s *terraform.State) error {
 		_, err := conn.GetUser(request)
 
 		if err != nil {
-			if RamEntityNotExist(err) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return err
@@ -106,9 +186,18 @@ func testAccCheckRamUserDestroy(s *terraform.State) error {
 
 const testAccRamUserConfig = `
 resource "alicloud_ram_user" "user" {
-  name = "username"
+  name = "tf_test_username"
   display_name = "displayname"
   mobile = "86-18888888888"
   email = "hello.uuu@aaa.com"
   comments = "yoyoyo"
 }`
+
+const testUnitRamUserConfigUpdate = `
+resource "alicloud_ram_user" "user" {
+  name = "tf_test_username"
+  display_name = "displayname2"
+  mobile = "86-18888888888"
+  email = "hello.new@aaa.com"
+  comments = "yoyoyo"
+}`
@@ -16,6 +16,9 @@ func resourceAlicloudRouterInterface() *schema.Resource {
 		Read:   resourceAlicloudRouterInterfaceRead,
 		Update: resourceAlicloudRouterInterfaceUpdate,
 		Delete: resourceAlicloudRouterInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"opposite_region": &schema.Schema{
@@ -100,7 +103,7 @@ func resourceAlicloudRouterInterface() *schema.Resource {
 }
 
 func resourceAlicloudRouterInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	args, err := buildAlicloudRouterInterfaceCreateArgs(d, meta)
 	if err != nil {
 		return err
@@ -121,7 +124,7 @@ func resourceAlicloudRouterInterfaceCreate(d *schema.ResourceData, meta interfac
 }
 
 func resourceAlicloudRouterInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	d.Partial(true)
 
@@ -152,7 +155,7 @@ func resourceAlicloudRouterInterfaceUpdate(d *schema.ResourceData, meta interfac
 }
 
 func resourceAlicloudRouterInterfaceRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	filter := ecs.Filter{Key: "RouterInterfaceId", Value: []string{d.Id()}}
 	args := &ecs.DescribeRouterInterfacesArgs{
@@ -171,6 +174,7 @@ func resourceAlicloudRouterInterfaceRead(d *schema.ResourceData, meta interface{
 
 	for _, ri := range routerInterface {
 		if ri.RouterInterfaceId == d.Id() {
+			d.Set("opposite_region", ri.OppositeRegionId)
 			d.Set("role", ri.Role)
 			d.Set("specification", ri.Spec)
 			d.Set("name", ri.Name)
@@ -194,7 +198,7 @@ func resourceAlicloudRouterInterfaceRead(d *schema.ResourceData, meta interface{
 }
 
 func resourceAlicloudRouterInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	args := &ecs.OperateRouterInterfaceArgs{
 		RegionId:          getRegion(d, meta),
@@ -203,7 +207,7 @@ func resourceAlicloudRouterInterfaceDelete(d *schema.ResourceData, meta interfac
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := conn.DeleteRouterInterface(args); err != nil {
-			if IsExceptedError(err, RouterInterfaceIncorrectStatus) || IsExceptedError(err, DependencyViolationRouterInterfaceReferedByRouteEntry) {
+			if IsConflictError(err) {
 				time.Sleep(5 * time.Second)
 				return resource.RetryableError(fmt.Errorf("Delete router interface timeout and got an error: %#v.", err))
 			}
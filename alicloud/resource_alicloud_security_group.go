@@ -37,12 +37,14 @@ func resourceAliyunSecurityGroup() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAliyunSecurityGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	args, err := buildAliyunSecurityGroupArgs(d, meta)
 	if err != nil {
@@ -59,7 +61,8 @@ func resourceAliyunSecurityGroupCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAliyunSecurityGroupRead(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	args := &ecs.DescribeSecurityGroupAttributeArgs{
 		SecurityGroupId: d.Id(),
@@ -70,7 +73,7 @@ func resourceAliyunSecurityGroupRead(d *schema.ResourceData, meta interface{}) e
 	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
 		group, e := conn.DescribeSecurityGroupAttribute(args)
 		if e != nil {
-			if IsExceptedError(e, InvalidSecurityGroupIdNotFound) {
+			if NotFoundError(e) {
 				sg = nil
 				return nil
 			}
@@ -95,14 +98,24 @@ func resourceAliyunSecurityGroupRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("description", sg.Description)
 	d.Set("vpc_id", sg.VpcId)
 
+	if err := readTags(client, ecsTags(TagResourceSecurityGroup), d); err != nil {
+		return fmt.Errorf("Describe tags for security group got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAliyunSecurityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	d.Partial(true)
+
+	if err := setTags(client, ecsTags(TagResourceSecurityGroup), d); err != nil {
+		return fmt.Errorf("Set tags for security group got error: %#v", err)
+	}
+	d.SetPartial("tags")
 	attributeUpdate := false
 	args := &ecs.ModifySecurityGroupAttributeArgs{
 		SecurityGroupId: d.Id(),
@@ -135,13 +148,13 @@ func resourceAliyunSecurityGroupUpdate(d *schema.ResourceData, meta interface{})
 
 func resourceAliyunSecurityGroupDelete(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := conn.DeleteSecurityGroup(getRegion(d, meta), d.Id())
 
 		if err != nil {
-			if IsExceptedError(err, SgDependencyViolation) {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Delete security group timeout and got an error: %#v", err))
 			}
 		}
@@ -152,7 +165,7 @@ func resourceAliyunSecurityGroupDelete(d *schema.ResourceData, meta interface{})
 		})
 
 		if err != nil {
-			if IsExceptedError(err, InvalidSecurityGroupIdNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
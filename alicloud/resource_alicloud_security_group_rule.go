@@ -16,6 +16,9 @@ func resourceAliyunSecurityGroupRule() *schema.Resource {
 		Create: resourceAliyunSecurityGroupRuleCreate,
 		Read:   resourceAliyunSecurityGroupRuleRead,
 		Delete: resourceAliyunSecurityGroupRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAliyunSecurityGroupRuleImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"type": &schema.Schema{
@@ -92,8 +95,7 @@ func resourceAliyunSecurityGroupRule() *schema.Resource {
 }
 
 func resourceAliyunSecurityGroupRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*AliyunClient)
-	conn := client.ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	direction := d.Get("type").(string)
 	sgId := d.Get("security_group_id").(string)
@@ -109,6 +111,9 @@ func resourceAliyunSecurityGroupRuleCreate(d *schema.ResourceData, meta interfac
 		}
 	}
 
+	unlock := lockParent(SecurityGroupLock, sgId)
+	defer unlock()
+
 	var autherr error
 	switch ecs.Direction(direction) {
 	case ecs.DirectionIngress:
@@ -166,7 +171,7 @@ func resourceAliyunSecurityGroupRuleRead(d *schema.ResourceData, meta interface{
 
 	rule, err := client.DescribeSecurityGroupRule(sgId, direction, parts[2], parts[3], parts[4], parts[5], policy, priority)
 	if err != nil {
-		if NotFoundError(err) || IsExceptedError(err, InvalidSecurityGroupIdNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -193,6 +198,27 @@ func resourceAliyunSecurityGroupRuleRead(d *schema.ResourceData, meta interface{
 	return nil
 }
 
+// resourceAliyunSecurityGroupRuleImport imports a rule by the ID
+// <security_group_id>:<type>:<ip_protocol>:<port_range>:<nic_type>:<cidr_ip>:<policy>:<priority>,
+// e.g. sg-id:ingress:tcp:22/22:intranet:0.0.0.0/0:accept:1. The cidr_ip can also be put at the end,
+// as sg-id:ingress:tcp:22/22:intranet:accept:1:0.0.0.0/0, and it is the source_security_group_id for a group rule.
+func resourceAliyunSecurityGroupRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "security_group_id", "type", "ip_protocol", "port_range", "nic_type", "cidr_ip", "policy", "priority")
+	if err != nil {
+		return nil, err
+	}
+
+	if policy := GroupRulePolicy(parts[5]); policy == GroupRulePolicyAccept || policy == GroupRulePolicyDrop {
+		parts = []string{parts[0], parts[1], parts[2], parts[3], parts[4], parts[7], parts[5], parts[6]}
+	}
+	if _, err := strconv.Atoi(parts[7]); err != nil {
+		return nil, fmt.Errorf("Invalid priority %q of the security group rule %q.", parts[7], d.Id())
+	}
+
+	d.SetId(strings.Join(parts, COLON_SEPARATED))
+	return []*schema.ResourceData{d}, nil
+}
+
 func deleteSecurityGroupRule(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
 	ruleType := d.Get("type").(string)
@@ -237,11 +263,14 @@ func resourceAliyunSecurityGroupRuleDelete(d *schema.ResourceData, meta interfac
 		priority = prior
 	}
 
+	unlock := lockParent(SecurityGroupLock, parts[0])
+	defer unlock()
+
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := deleteSecurityGroupRule(d, meta)
 
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidSecurityGroupIdNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			resource.RetryableError(fmt.Errorf("Delete security group rule timeout and got an error: %#v", err))
@@ -249,7 +278,7 @@ func resourceAliyunSecurityGroupRuleDelete(d *schema.ResourceData, meta interfac
 
 		_, err = client.DescribeSecurityGroupRule(parts[0], parts[1], parts[2], parts[3], parts[4], parts[5], policy, priority)
 		if err != nil {
-			if NotFoundError(err) || IsExceptedError(err, InvalidSecurityGroupIdNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(err)
@@ -261,7 +290,7 @@ func resourceAliyunSecurityGroupRuleDelete(d *schema.ResourceData, meta interfac
 }
 
 func buildAliyunSecurityIngressArgs(d *schema.ResourceData, meta interface{}) (*ecs.AuthorizeSecurityGroupArgs, error) {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	args := &ecs.AuthorizeSecurityGroupArgs{
 		RegionId: getRegion(d, meta),
@@ -322,7 +351,7 @@ func buildAliyunSecurityIngressArgs(d *schema.ResourceData, meta interface{}) (*
 }
 
 func buildAliyunSecurityEgressArgs(d *schema.ResourceData, meta interface{}) (*ecs.AuthorizeSecurityGroupEgressArgs, error) {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	args := &ecs.AuthorizeSecurityGroupEgressArgs{
 		RegionId: getRegion(d, meta),
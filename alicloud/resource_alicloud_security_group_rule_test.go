@@ -311,6 +311,45 @@ func TestAccAlicloudSecurityGroupRule_MultiAttri(t *testing.T) {
 
 }
 
+func TestUnitAlicloudSecurityGroupRule_concurrent(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	noConflicts := func(*terraform.State) error {
+		if n := server.conflicted(); n > 0 {
+			return fmt.Errorf("The rules of one security group should be changed one by one, but %d changes conflicted.", n)
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: resource.ComposeTestCheckFunc(noConflicts, func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if n := len(server.securityGroups[fakeSecurityGroupId].Permissions.Permission); n > 0 {
+				return fmt.Errorf("Security group rules still exist: %d", n)
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSecurityGroupRuleConcurrent, fakeSecurityGroupId),
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.foo.9", "port_range", "8009/8009"),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/AuthorizeSecurityGroup"); n != 10 {
+							return fmt.Errorf("Expected 10 rules authorized, got %d.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSecurityGroupRuleExists(n string, m *ecs.PermissionType) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -379,7 +418,7 @@ func testAccCheckSecurityGroupRuleDestroy(s *terraform.State) error {
 
 const testAccSecurityGroupRuleIngress = `
 resource "alicloud_security_group" "foo" {
-  name = "sg_foo"
+  name = "tf_test_sg_foo"
 }
 
 resource "alicloud_security_group_rule" "ingress" {
@@ -398,7 +437,7 @@ resource "alicloud_security_group_rule" "ingress" {
 
 const testAccSecurityGroupRuleEgress = `
 resource "alicloud_security_group" "foo" {
-  name = "sg_foo"
+  name = "tf_test_sg_foo"
 }
 
 
@@ -417,7 +456,7 @@ resource "alicloud_security_group_rule" "egress" {
 
 const testAccSecurityGroupRuleEgress_emptyNicType = `
 resource "alicloud_security_group" "foo" {
-  name = "sg_foo"
+  name = "tf_test_sg_foo"
 }
 
 resource "alicloud_security_group_rule" "egress" {
@@ -435,10 +474,11 @@ resource "alicloud_security_group_rule" "egress" {
 const testAccSecurityGroupRuleVpcIngress = `
 resource "alicloud_security_group" "foo" {
   vpc_id = "${alicloud_vpc.vpc.id}"
-  name = "sg_foo"
+  name = "tf_test_sg_foo"
 }
 
 resource "alicloud_vpc" "vpc" {
+  name = "tf_test_vpc"
   cidr_block = "10.1.0.0/21"
 }
 
@@ -456,7 +496,7 @@ resource "alicloud_security_group_rule" "ingress" {
 `
 const testAccSecurityGroupRule_missingSourceCidrIp = `
 resource "alicloud_security_group" "foo" {
-  name = "sg_foo"
+  name = "tf_test_sg_foo"
 }
 
 resource "alicloud_security_group_rule" "egress" {
@@ -481,10 +521,12 @@ variable "cidr_ip_list_2" {
   default = ["10.159.6.18/12", "127.0.1.18/16"]
 }
 resource "alicloud_vpc" "main" {
+  name = "tf_test_main"
   cidr_block = "10.1.0.0/21"
 }
 
 resource "alicloud_vswitch" "main" {
+  name = "tf_test_main"
   vpc_id = "${alicloud_vpc.main.id}"
   cidr_block = "10.1.1.0/24"
   availability_zone = "cn-beijing-a"
@@ -493,7 +535,7 @@ resource "alicloud_vswitch" "main" {
 }
 
 resource "alicloud_security_group" "foo" {
-  name = "test_rules"
+  name = "tf_test_rules"
   description = "Security group for rules"
   vpc_id = "${alicloud_vpc.main.id}"
 }
@@ -527,11 +569,11 @@ resource "alicloud_security_group_rule" "egresses" {
 
 const testAccSecurityGroupRuleSourceSecurityGroup = `
 resource "alicloud_security_group" "foo" {
-  name = "sg_foo"
+  name = "tf_test_sg_foo"
 }
 
 resource "alicloud_security_group" "bar" {
-  name = "sg_bar"
+  name = "tf_test_sg_bar"
 }
 
 resource "alicloud_security_group_rule" "ingress" {
@@ -547,7 +589,7 @@ resource "alicloud_security_group_rule" "ingress" {
 `
 const testAccSecurityGroupRuleMultiAttri = `
 variable "name" {
-  default = "test_ssh"
+  default = "tf_test_ssh"
 }
 
 variable "source_cidr_blocks" {
@@ -557,7 +599,7 @@ variable "source_cidr_blocks" {
 
 
 resource "alicloud_vpc" "vpc" {
-  name =  "test-ssh"
+  name =  "tf-test-ssh"
   cidr_block = "172.16.0.0/24"
 }
 
@@ -625,3 +667,17 @@ resource "alicloud_security_group_rule" "ingress_deny_tcp_22_prior" {
   cidr_ip = "0.0.0.0/0"
 }
 `
+
+const testUnitSecurityGroupRuleConcurrent = `
+resource "alicloud_security_group_rule" "foo" {
+  count = 10
+  type = "ingress"
+  ip_protocol = "tcp"
+  nic_type = "internet"
+  policy = "accept"
+  port_range = "${8000 + count.index}/${8000 + count.index}"
+  priority = 1
+  security_group_id = "%s"
+  cidr_ip = "10.159.6.${count.index}/32"
+}
+`
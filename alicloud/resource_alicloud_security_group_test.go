@@ -10,6 +10,54 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_security_group", &resource.Sweeper{
+		Name: "alicloud_security_group",
+		F:    testSweepSecurityGroups,
+		// The security groups can't be deleted until the instances in them are deleted.
+		Dependencies: []string{
+			"alicloud_instance",
+		},
+	})
+}
+
+func testSweepSecurityGroups(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var groups []ecs.SecurityGroupItemType
+	args := &ecs.DescribeSecurityGroupsArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		page, pagination, err := client.ecsConn().DescribeSecurityGroups(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Security Groups: %#v", err)
+		}
+		groups = append(groups, page...)
+		next := pagination.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range groups {
+		if !isTestSweepName(v.SecurityGroupName) {
+			log.Printf("[INFO] Skipping Security Group: %s (%s)", v.SecurityGroupName, v.SecurityGroupId)
+			continue
+		}
+		log.Printf("[INFO] Deleting Security Group: %s (%s)", v.SecurityGroupName, v.SecurityGroupId)
+		if err := testSweepResource(resourceAliyunSecurityGroup(), client, v.SecurityGroupId, nil); err != nil {
+			log.Printf("[ERROR] Failed to delete Security Group (%s (%s)): %s", v.SecurityGroupName, v.SecurityGroupId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudSecurityGroup_basic(t *testing.T) {
 	var sg ecs.DescribeSecurityGroupAttributeResponse
 
@@ -32,7 +80,7 @@ func TestAccAlicloudSecurityGroup_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"alicloud_security_group.foo",
 						"name",
-						"sg_test"),
+						"tf_test_sg_test"),
 				),
 			},
 		},
@@ -81,7 +129,7 @@ func testAccCheckSecurityGroupExists(n string, sg *ecs.DescribeSecurityGroupAttr
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		conn := client.ecsconn
+		conn := client.ecsConn()
 		args := &ecs.DescribeSecurityGroupAttributeArgs{
 			RegionId:        client.Region,
 			SecurityGroupId: rs.Primary.ID,
@@ -105,7 +153,7 @@ func testAccCheckSecurityGroupExists(n string, sg *ecs.DescribeSecurityGroupAttr
 
 func testAccCheckSecurityGroupDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*AliyunClient)
-	conn := client.ecsconn
+	conn := client.ecsConn()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "alicloud_security_group" {
@@ -136,16 +184,18 @@ func testAccCheckSecurityGroupDestroy(s *terraform.State) error {
 
 const testAccSecurityGroupConfig = `
 resource "alicloud_security_group" "foo" {
-  name = "sg_test"
+  name = "tf_test_sg_test"
 }
 `
 
 const testAccSecurityGroupConfig_withVpc = `
 resource "alicloud_security_group" "foo" {
+  name = "tf_test_foo"
   vpc_id = "${alicloud_vpc.vpc.id}"
 }
 
 resource "alicloud_vpc" "vpc" {
+  name = "tf_test_vpc"
   cidr_block = "10.1.0.0/21"
 }
 `
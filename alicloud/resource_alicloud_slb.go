@@ -19,6 +19,12 @@ func resourceAliyunSlb() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:         schema.TypeString,
@@ -202,12 +208,15 @@ func resourceAliyunSlb() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAliyunSlbCreate(d *schema.ResourceData, meta interface{}) error {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 	args := &slb.CreateLoadBalancerArgs{
 		RegionId:           getRegion(d, meta),
 		LoadBalancerName:   d.Get("name").(string),
@@ -241,7 +250,7 @@ func resourceAliyunSlbCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(lb.LoadBalancerId)
 
-	if err := slbconn.WaitForLoadBalancerAsyn(lb.LoadBalancerId, slb.ActiveStatus, defaultTimeout); err != nil {
+	if err := slbconn.WaitForLoadBalancerAsyn(lb.LoadBalancerId, slb.ActiveStatus, timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
 		return fmt.Errorf("WaitForListener %s got error: %#v", slb.ActiveStatus, err)
 	}
 
@@ -249,7 +258,8 @@ func resourceAliyunSlbCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAliyunSlbRead(d *schema.ResourceData, meta interface{}) error {
-	loadBalancer, err := meta.(*AliyunClient).DescribeLoadBalancerAttribute(d.Id())
+	client := meta.(*AliyunClient)
+	loadBalancer, err := client.DescribeLoadBalancerAttribute(d.Id())
 	if err != nil {
 		return err
 	}
@@ -271,15 +281,25 @@ func resourceAliyunSlbRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("vswitch_id", loadBalancer.VSwitchId)
 	d.Set("address", loadBalancer.Address)
 
+	if err := readTags(client, slbTags{}, d); err != nil {
+		return fmt.Errorf("Describe tags for load balancer got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAliyunSlbUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 
 	d.Partial(true)
 
+	if err := setTags(client, slbTags{}, d); err != nil {
+		return fmt.Errorf("Set tags for load balancer got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
 	if d.HasChange("name") && !d.IsNewResource() {
 		if err := slbconn.SetLoadBalancerName(d.Id(), d.Get("name").(string)); err != nil {
 			return fmt.Errorf("SetLoadBalancerName got an error: %#v", err)
@@ -309,6 +329,9 @@ func resourceAliyunSlbUpdate(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("ModifyLoadBalancerInternetSpec got an error: %#v", err)
 		}
 
+		if err := slbconn.WaitForLoadBalancerAsyn(d.Id(), slb.ActiveStatus, timeoutInSeconds(d, schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("WaitForLoadBalancer %s got error: %#v", slb.ActiveStatus, err)
+		}
 	}
 
 	// If we currently have instances, or did have instances,
@@ -347,13 +370,13 @@ func resourceAliyunSlbUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAliyunSlbDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).slbconn
+	conn := meta.(*AliyunClient).slbConn()
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		err := conn.DeleteLoadBalancer(d.Id())
 
 		if err != nil {
-			if IsExceptedError(err, LoadBalancerNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error deleting slb failed: %#v", err))
@@ -361,7 +384,7 @@ func resourceAliyunSlbDelete(d *schema.ResourceData, meta interface{}) error {
 
 		loadBalancer, err := conn.DescribeLoadBalancerAttribute(d.Id())
 		if err != nil {
-			if IsExceptedError(err, LoadBalancerNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("Error describing slb failed when deleting SLB: %#v", err))
@@ -17,6 +17,9 @@ func resourceAliyunSlbAttachment() *schema.Resource {
 		Read:   resourceAliyunSlbAttachmentRead,
 		Update: resourceAliyunSlbAttachmentUpdate,
 		Delete: resourceAliyunSlbAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -59,7 +62,7 @@ func resourceAliyunSlbAttachmentCreate(d *schema.ResourceData, meta interface{})
 
 func resourceAliyunSlbAttachmentRead(d *schema.ResourceData, meta interface{}) error {
 
-	loadBalancer, err := meta.(*AliyunClient).DescribeLoadBalancerAttribute(d.Get("slb_id").(string))
+	loadBalancer, err := meta.(*AliyunClient).DescribeLoadBalancerAttribute(d.Id())
 	if err != nil {
 		return err
 	}
@@ -90,7 +93,12 @@ func resourceAliyunSlbAttachmentRead(d *schema.ResourceData, meta interface{}) e
 
 func resourceAliyunSlbAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
+
+	unlock := lockParent(LoadBalancerLock, d.Id())
+	defer unlock()
+
 	if d.HasChange("instances") {
 		o, n := d.GetChange("instances")
 		os := o.(*schema.Set)
@@ -122,6 +130,8 @@ func resourceAliyunSlbAttachmentUpdate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceAliyunSlbAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	unlock := lockParent(LoadBalancerLock, d.Id())
+	defer unlock()
 
 	o := d.Get("instances")
 	os := o.(*schema.Set)
@@ -131,7 +141,8 @@ func resourceAliyunSlbAttachmentDelete(d *schema.ResourceData, meta interface{})
 }
 
 func removeBackendServers(d *schema.ResourceData, meta interface{}, servers []slb.BackendServerType) error {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 	if len(servers) > 0 {
 		removeBackendServers := make([]string, 0, len(servers))
 		for _, e := range servers {
@@ -113,7 +113,7 @@ resource "alicloud_instance" "foo" {
 	system_disk_category = "cloud_efficiency"
 
 	security_groups = ["${alicloud_security_group.foo.id}"]
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 }
 
 resource "alicloud_slb" "foo" {
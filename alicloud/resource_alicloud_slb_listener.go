@@ -14,14 +14,18 @@ import (
 
 func resourceAliyunSlbListener() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAliyunSlbListenerCreate,
-		Read:   resourceAliyunSlbListenerRead,
-		Update: resourceAliyunSlbListenerUpdate,
-		Delete: resourceAliyunSlbListenerDelete,
+		Create:        resourceAliyunSlbListenerCreate,
+		Read:          resourceAliyunSlbListenerRead,
+		Update:        resourceAliyunSlbListenerUpdate,
+		Delete:        resourceAliyunSlbListenerDelete,
+		CustomizeDiff: slbListenerCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
 
+		SchemaVersion: 1,
+		MigrateState:  resourceAlicloudSlbListenerMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"load_balancer_id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -214,7 +218,8 @@ func resourceAliyunSlbListener() *schema.Resource {
 
 func resourceAliyunSlbListenerCreate(d *schema.ResourceData, meta interface{}) error {
 
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 
 	protocol := d.Get("protocol").(string)
 	lb_id := d.Get("load_balancer_id").(string)
@@ -277,7 +282,8 @@ func resourceAliyunSlbListenerCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAliyunSlbListenerRead(d *schema.ResourceData, meta interface{}) error {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 	lb_id, protocol, port, err := parseListenerId(d, meta)
 	if err != nil {
 		return fmt.Errorf("Get slb listener got an error: %#v", err)
@@ -308,7 +314,8 @@ func resourceAliyunSlbListenerRead(d *schema.ResourceData, meta interface{}) err
 
 func resourceAliyunSlbListenerUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 	protocol := Protocol(d.Get("protocol").(string))
 
 	d.Partial(true)
@@ -468,7 +475,8 @@ func resourceAliyunSlbListenerUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceAliyunSlbListenerDelete(d *schema.ResourceData, meta interface{}) error {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 	lb_id, protocol, port, err := parseListenerId(d, meta)
 	if err != nil {
 		return fmt.Errorf("Get slb listener got an error: %#v", err)
@@ -482,9 +490,6 @@ func resourceAliyunSlbListenerDelete(d *schema.ResourceData, meta interface{}) e
 		err := slbconn.DeleteLoadBalancerListener(lb_id, port)
 
 		if err != nil {
-			if IsExceptedError(err, SystemBusy) {
-				resource.RetryableError(fmt.Errorf("Delete load balancer listener timeout and got an error: %#v.", err))
-			}
 			return resource.NonRetryableError(err)
 		}
 
@@ -574,7 +579,8 @@ func buildUdpListenerArgs(d *schema.ResourceData) slb.CreateLoadBalancerUDPListe
 }
 
 func parseListenerId(d *schema.ResourceData, meta interface{}) (string, string, int, error) {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 	parts := strings.Split(d.Id(), ":")
 	port, err := strconv.Atoi(parts[1])
 	if err != nil {
@@ -582,7 +588,7 @@ func parseListenerId(d *schema.ResourceData, meta interface{}) (string, string,
 	}
 	loadBalancer, err := slbconn.DescribeLoadBalancerAttribute(parts[0])
 	if err != nil {
-		if IsExceptedError(err, LoadBalancerNotFound) {
+		if NotFoundError(err) {
 			return "", "", 0, nil
 		}
 		return "", "", 0, fmt.Errorf("DescribeLoadBalancerAttribute got an error: %#v", parts[0])
@@ -599,7 +605,7 @@ func readListenerAttribute(d *schema.ResourceData, protocol string, listen inter
 	v := reflect.ValueOf(listen).Elem()
 
 	if err != nil {
-		if IsExceptedError(err, ListenerNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -687,7 +693,7 @@ func ensureListenerAbsent(d *schema.ResourceData, protocol string, listen interf
 	v := reflect.ValueOf(listen).Elem()
 
 	if err != nil {
-		if IsExceptedError(err, ListenerNotFound) {
+		if NotFoundError(err) {
 			d.SetId("")
 			return nil
 		}
@@ -92,6 +92,63 @@ func TestAccAlicloudSlbListener_udp(t *testing.T) {
 	})
 }
 
+func TestUnitAlicloudSlbListener_http(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_slb_listener.http",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.listeners) > 0 {
+				return fmt.Errorf("SLB listeners still exist: %d", len(server.listeners))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSlbListenerHttp, "wrr", 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_slb_listener.http", "id", fakeLoadBalancerId+":80"),
+					resource.TestCheckResourceAttr("alicloud_slb_listener.http", "protocol", "http"),
+					resource.TestCheckResourceAttr("alicloud_slb_listener.http", "scheduler", "wrr"),
+					resource.TestCheckResourceAttr("alicloud_slb_listener.http", "health_check_timeout", "5"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSlbListenerHttp, "wlc", 8),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_slb_listener.http", "scheduler", "wlc"),
+					resource.TestCheckResourceAttr("alicloud_slb_listener.http", "health_check_timeout", "8"),
+					func(*terraform.State) error {
+						if n := server.calls("slb/CreateLoadBalancerHTTPListener"); n != 1 {
+							return fmt.Errorf("The listener should be updated in place, but it was created %d times.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSlbListener_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitSlbListenerInvalid, []fakeInvalidPlan{
+		{`protocol = "https"`, "'ssl_certificate_id': required field is not set when the protocol is 'https'"},
+		{`protocol = "http"
+  sticky_session = "on"`, "'sticky_session_type': required field is not set"},
+		{`protocol = "http"
+  sticky_session = "on"
+  sticky_session_type = "insert"`, "'cookie_timeout': required field is not set"},
+		{`protocol = "https"
+  ssl_certificate_id = "cert-fake"
+  sticky_session = "on"
+  sticky_session_type = "server"`, "'cookie': required field is not set"},
+	})
+}
+
 func testAccCheckSlbListenerExists(n string, port int) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -135,7 +192,7 @@ func testAccCheckSlbListenerDestroy(s *terraform.State) error {
 		}
 		loadBalancer, err := client.DescribeLoadBalancerAttribute(parts[0])
 		if err != nil {
-			if IsExceptedError(err, LoadBalancerNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return fmt.Errorf("DescribeLoadBalancerAttribute got an error: %#v", err)
@@ -225,3 +282,27 @@ resource "alicloud_slb_listener" "udp" {
   health_check_connect_port = 20
 }
 `
+
+const testUnitSlbListenerHttp = `
+resource "alicloud_slb_listener" "http" {
+  load_balancer_id = "lb-fake"
+  backend_port = 80
+  frontend_port = 80
+  protocol = "http"
+  bandwidth = 10
+  scheduler = "%s"
+  health_check = "on"
+  health_check_connect_port = 20
+  health_check_timeout = %d
+}
+`
+
+const testUnitSlbListenerInvalid = `
+resource "alicloud_slb_listener" "invalid" {
+  load_balancer_id = "lb-fake"
+  backend_port = 80
+  frontend_port = 80
+  bandwidth = 10
+  %s
+}
+`
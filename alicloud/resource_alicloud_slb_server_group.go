@@ -80,25 +80,28 @@ func resourceAliyunSlbServerGroup() *schema.Resource {
 
 func resourceAliyunSlbServerGroupCreate(d *schema.ResourceData, meta interface{}) error {
 
-	var groupId string
-	if group, err := meta.(*AliyunClient).slbconn.CreateVServerGroup(&slb.CreateVServerGroupArgs{
+	client := meta.(*AliyunClient)
+	// Not deferred, since the update below locks the load balancer again.
+	unlock := lockParent(LoadBalancerLock, d.Get("load_balancer_id").(string))
+	group, err := client.slbConn().CreateVServerGroup(&slb.CreateVServerGroupArgs{
 		RegionId:         getRegion(d, meta),
 		LoadBalancerId:   d.Get("load_balancer_id").(string),
 		VServerGroupName: d.Get("name").(string),
 		BackendServers:   convertServersToString(d.Get("servers").(*schema.Set).List()),
-	}); err != nil {
+	})
+	unlock()
+	if err != nil {
 		return fmt.Errorf("CreateVServerGroup got an error: %#v", err)
-	} else {
-		groupId = group.VServerGroupId
 	}
 
-	d.SetId(groupId)
+	d.SetId(group.VServerGroupId)
 
 	return resourceAliyunSlbServerGroupUpdate(d, meta)
 }
 
 func resourceAliyunSlbServerGroupRead(d *schema.ResourceData, meta interface{}) error {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 
 	group, err := slbconn.DescribeVServerGroupAttribute(&slb.DescribeVServerGroupAttributeArgs{
 		RegionId:       getRegion(d, meta),
@@ -106,7 +109,7 @@ func resourceAliyunSlbServerGroupRead(d *schema.ResourceData, meta interface{})
 	})
 
 	if err != nil {
-		if IsExceptedError(err, VServerGroupNotFoundMessage) || IsExceptedError(err, InvalidParameter) {
+		if NotFoundError(err) || IsExceptedError(err, InvalidParameter) {
 			d.SetId("")
 			return nil
 		}
@@ -143,11 +146,14 @@ func resourceAliyunSlbServerGroupRead(d *schema.ResourceData, meta interface{})
 
 func resourceAliyunSlbServerGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
 
 	d.Partial(true)
 
 	slb_id := d.Get("load_balancer_id").(string)
+	unlock := lockParent(LoadBalancerLock, slb_id)
+	defer unlock()
 	name := d.Get("name").(string)
 	update := false
 
@@ -211,7 +217,11 @@ func resourceAliyunSlbServerGroupUpdate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceAliyunSlbServerGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	slbconn := meta.(*AliyunClient).slbconn
+	client := meta.(*AliyunClient)
+	slbconn := client.slbConn()
+
+	unlock := lockParent(LoadBalancerLock, d.Get("load_balancer_id").(string))
+	defer unlock()
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		if _, err := slbconn.DeleteVServerGroup(&slb.DeleteVServerGroupArgs{
@@ -226,7 +236,7 @@ func resourceAliyunSlbServerGroupDelete(d *schema.ResourceData, meta interface{}
 			VServerGroupId: d.Id(),
 		})
 		if err != nil {
-			if IsExceptedError(err, VServerGroupNotFoundMessage) || IsExceptedError(err, InvalidParameter) {
+			if NotFoundError(err) || IsExceptedError(err, InvalidParameter) {
 				return nil
 			}
 			return resource.NonRetryableError(fmt.Errorf("While deleting VServer Group, DescribeVServerGroupAttribute got an error: %#v", err))
@@ -61,6 +61,53 @@ func TestAccAlicloudSlbServerGroup_vpc(t *testing.T) {
 	})
 }
 
+func TestUnitAlicloudSlbServerGroup_concurrent(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	noConflicts := func(*terraform.State) error {
+		if n := server.conflicted(); n > 0 {
+			return fmt.Errorf("The VServer groups of one load balancer should be changed one by one, but %d changes conflicted.", n)
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: resource.ComposeTestCheckFunc(noConflicts, func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if n := len(server.serverGroups); n > 0 {
+				return fmt.Errorf("VServer groups still exist: %d", n)
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitSlbServerGroupConcurrent,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_slb_server_group.foo.9", "name", "tf-testAccSlbServerGroup-9"),
+					resource.TestCheckResourceAttr("alicloud_slb_server_group.foo.9", "servers.#", "1"),
+					func(*terraform.State) error {
+						if n := server.calls("slb/CreateVServerGroup"); n != 10 {
+							return fmt.Errorf("Expected 10 VServer groups created, got %d.", n)
+						}
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitSlbServerGroupConcurrentUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_slb_server_group.foo.9", "servers.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSlbServerGroupExists(n string, group *slb.DescribeVServerGroupAttributeResponse) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -73,7 +120,7 @@ func testAccCheckSlbServerGroupExists(n string, group *slb.DescribeVServerGroupA
 		}
 
 		client := testAccProvider.Meta().(*AliyunClient)
-		gr, err := client.slbconn.DescribeVServerGroupAttribute(&slb.DescribeVServerGroupAttributeArgs{
+		gr, err := client.slbConn().DescribeVServerGroupAttribute(&slb.DescribeVServerGroupAttributeArgs{
 			RegionId:       client.Region,
 			VServerGroupId: rs.Primary.ID,
 		})
@@ -99,7 +146,7 @@ func testAccCheckSlbServerGroupDestroy(s *terraform.State) error {
 		}
 
 		// Try to find the Slb server group
-		group, err := client.slbconn.DescribeVServerGroupAttribute(&slb.DescribeVServerGroupAttributeArgs{
+		group, err := client.slbConn().DescribeVServerGroupAttribute(&slb.DescribeVServerGroupAttributeArgs{
 			RegionId:       client.Region,
 			VServerGroupId: rs.Primary.ID,
 		})
@@ -128,10 +175,12 @@ data "alicloud_images" "image" {
 data "alicloud_zones" "zone" {}
 
 resource "alicloud_vpc" "main" {
+  name = "tf_test_main"
   cidr_block = "172.16.0.0/16"
 }
 
 resource "alicloud_vswitch" "main" {
+  name = "tf_test_main"
   vpc_id = "${alicloud_vpc.main.id}"
   cidr_block = "172.16.0.0/16"
   availability_zone = "${data.alicloud_zones.zone.zones.0.id}"
@@ -139,10 +188,12 @@ resource "alicloud_vswitch" "main" {
     "alicloud_vpc.main"]
 }
 resource "alicloud_security_group" "group" {
+  name = "tf_test_group"
   vpc_id = "${alicloud_vpc.main.id}"
 }
 
 resource "alicloud_instance" "vpc" {
+  instance_name = "tf_test_vpc"
   image_id = "${data.alicloud_images.image.images.0.id}"
   instance_type = "ecs.n4.small"
   count = "2"
@@ -155,9 +206,12 @@ resource "alicloud_instance" "vpc" {
   vswitch_id = "${alicloud_vswitch.main.id}"
 }
 
-resource "alicloud_security_group" "classic" {}
+resource "alicloud_security_group" "classic" {
+	name = "tf_test_classic"
+}
 
 resource "alicloud_instance" "classic" {
+  instance_name = "tf_test_classic"
   image_id = "${data.alicloud_images.image.images.0.id}"
   instance_type = "ecs.n4.small"
   security_groups = ["${alicloud_security_group.classic.*.id}"]
@@ -169,6 +223,7 @@ resource "alicloud_instance" "classic" {
 }
 
 resource "alicloud_slb" "instance" {
+  name = "tf_test_instance"
   internet = true
 }
 
@@ -204,10 +259,12 @@ data "alicloud_images" "image" {
 data "alicloud_zones" "zone" {}
 
 resource "alicloud_vpc" "main" {
+  name = "tf_test_main"
   cidr_block = "172.16.0.0/16"
 }
 
 resource "alicloud_vswitch" "main" {
+  name = "tf_test_main"
   vpc_id = "${alicloud_vpc.main.id}"
   cidr_block = "172.16.0.0/16"
   availability_zone = "${data.alicloud_zones.zone.zones.0.id}"
@@ -215,10 +272,12 @@ resource "alicloud_vswitch" "main" {
     "alicloud_vpc.main"]
 }
 resource "alicloud_security_group" "group" {
+  name = "tf_test_group"
   vpc_id = "${alicloud_vpc.main.id}"
 }
 
 resource "alicloud_instance" "instance" {
+  instance_name = "tf_test_instance"
   image_id = "${data.alicloud_images.image.images.0.id}"
   instance_type = "ecs.n4.small"
   count = "2"
@@ -232,6 +291,7 @@ resource "alicloud_instance" "instance" {
 }
 
 resource "alicloud_slb" "instance" {
+  name = "tf_test_instance"
   vswitch_id = "${alicloud_vswitch.main.id}"
 }
 
@@ -251,3 +311,32 @@ resource "alicloud_slb_server_group" "group" {
   ]
 }
 `
+
+const testUnitSlbServerGroupConcurrent = `
+resource "alicloud_slb_server_group" "foo" {
+  count = 10
+  load_balancer_id = "lb-fake"
+  name = "tf-testAccSlbServerGroup-${count.index}"
+  servers {
+    server_ids = ["i-fake${count.index}"]
+    port = 80
+  }
+}
+`
+
+const testUnitSlbServerGroupConcurrentUpdate = `
+resource "alicloud_slb_server_group" "foo" {
+  count = 10
+  load_balancer_id = "lb-fake"
+  name = "tf-testAccSlbServerGroup-${count.index}"
+  servers {
+    server_ids = ["i-fake${count.index}"]
+    port = 80
+  }
+  servers {
+    server_ids = ["i-fake${count.index}"]
+    port = 8080
+    weight = 50
+  }
+}
+`
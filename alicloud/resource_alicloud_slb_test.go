@@ -2,7 +2,6 @@ package alicloud
 
 import (
 	"fmt"
-
 	"log"
 	"testing"
 
@@ -11,6 +10,39 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_slb", &resource.Sweeper{
+		Name: "alicloud_slb",
+		F:    testSweepSlbs,
+	})
+}
+
+func testSweepSlbs(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	loadBalancers, err := client.slbConn().DescribeLoadBalancers(&slb.DescribeLoadBalancersArgs{
+		RegionId: client.Region,
+	})
+	if err != nil {
+		return fmt.Errorf("Error retrieving SLBs: %#v", err)
+	}
+
+	for _, v := range loadBalancers {
+		if !isTestSweepName(v.LoadBalancerName) {
+			log.Printf("[INFO] Skipping SLB: %s (%s)", v.LoadBalancerName, v.LoadBalancerId)
+			continue
+		}
+		log.Printf("[INFO] Deleting SLB: %s (%s)", v.LoadBalancerName, v.LoadBalancerId)
+		if err := testSweepResource(resourceAliyunSlb(), client, v.LoadBalancerId, nil); err != nil {
+			log.Printf("[ERROR] Failed to delete SLB (%s (%s)): %s", v.LoadBalancerName, v.LoadBalancerId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudSlb_basic(t *testing.T) {
 	var slb slb.LoadBalancerType
 
@@ -147,7 +179,7 @@ func testAccCheckSlbDestroy(s *terraform.State) error {
 		}
 
 		if err != nil {
-			if IsExceptedError(err, LoadBalancerNotFound) {
+			if NotFoundError(err) {
 				return nil
 			}
 			return fmt.Errorf("DescribeLoadBalancerAttribute got an error: %#v", err)
@@ -184,6 +216,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  name = "tf_test_foo"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "172.16.0.0/21"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -0,0 +1,182 @@
+package alicloud
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlicloudSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudSnapshotCreate,
+		Read:   resourceAlicloudSnapshotRead,
+		Update: resourceAlicloudSnapshotUpdate,
+		Delete: resourceAlicloudSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"disk_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnapshotName,
+			},
+
+			"description": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDiskDescription,
+			},
+
+			"source_disk_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"source_disk_size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAlicloudSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AliyunClient).ecsConn()
+
+	args := &ecs.CreateSnapshotArgs{
+		DiskId:       d.Get("disk_id").(string),
+		SnapshotName: d.Get("name").(string),
+		Description:  d.Get("description").(string),
+	}
+
+	// A disk takes one snapshot at a time, so the snapshots of the same disk are created one after another.
+	var snapshotId string
+	err := resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		var err error
+		snapshotId, err = conn.CreateSnapshot(args)
+		if err != nil {
+			if IsExceptedError(err, DiskCreatingSnapshot) {
+				return resource.RetryableError(fmt.Errorf("Disk %s is busy - trying again while it takes another snapshot.", args.DiskId))
+			}
+			return resource.NonRetryableError(fmt.Errorf("CreateSnapshot got an error: %#v", err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(snapshotId)
+
+	if err := conn.WaitForSnapShotReady(getRegion(d, meta), d.Id(), timeoutInSeconds(d, schema.TimeoutCreate)); err != nil {
+		return fmt.Errorf("WaitForSnapShotReady got an error: %#v", err)
+	}
+
+	return resourceAlicloudSnapshotUpdate(d, meta)
+}
+
+func resourceAlicloudSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	snapshot, err := client.DescribeSnapshot(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("DescribeSnapshot got an error: %#v", err)
+	}
+
+	d.Set("disk_id", snapshot.SourceDiskId)
+	d.Set("name", snapshot.SnapshotName)
+	d.Set("description", snapshot.Description)
+	d.Set("source_disk_type", snapshot.SourceDiskType)
+	d.Set("source_disk_size", snapshot.SourceDiskSize)
+	d.Set("status", snapshot.Status)
+
+	if err := readTags(client, ecsTags(ecs.TagResourceSnapshot), d); err != nil {
+		log.Printf("[DEBUG] DescribeTags for snapshot got error: %#v", err)
+	}
+
+	return nil
+}
+
+func resourceAlicloudSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	d.Partial(true)
+
+	if err := setTags(client, ecsTags(ecs.TagResourceSnapshot), d); err != nil {
+		return fmt.Errorf("Set tags for snapshot got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
+	if !d.IsNewResource() && (d.HasChange("name") || d.HasChange("description")) {
+		args := &ModifySnapshotAttributeArgs{
+			SnapshotId:   d.Id(),
+			SnapshotName: d.Get("name").(string),
+			Description:  d.Get("description").(string),
+		}
+		if err := client.ecsConn().Invoke("ModifySnapshotAttribute", args, &common.Response{}); err != nil {
+			return fmt.Errorf("ModifySnapshotAttribute got an error: %#v", err)
+		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+	}
+
+	d.Partial(false)
+
+	return resourceAlicloudSnapshotRead(d, meta)
+}
+
+func resourceAlicloudSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		err := conn.DeleteSnapshot(d.Id())
+		if err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			if IsConflictError(err) {
+				return resource.RetryableError(fmt.Errorf("Snapshot in use - trying again while it is deleted."))
+			}
+			return resource.NonRetryableError(fmt.Errorf("DeleteSnapshot got an error: %#v", err))
+		}
+
+		if _, err := client.DescribeSnapshot(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(fmt.Errorf("DescribeSnapshot got an error: %#v", err))
+		}
+		return resource.RetryableError(fmt.Errorf("Snapshot in use - trying again while it is deleted."))
+	})
+}
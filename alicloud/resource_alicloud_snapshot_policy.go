@@ -0,0 +1,166 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlicloudSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudSnapshotPolicyCreate,
+		Read:   resourceAlicloudSnapshotPolicyRead,
+		Update: resourceAlicloudSnapshotPolicyUpdate,
+		Delete: resourceAlicloudSnapshotPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnapshotPolicyName,
+			},
+
+			// The days of the week to take the snapshots, from 1 for Monday to 7 for Sunday.
+			"repeat_weekdays": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateAllowedStringValue([]string{"1", "2", "3", "4", "5", "6", "7"}),
+				},
+			},
+
+			// The hours of the day to take the snapshots, from 0 to 23.
+			"time_points": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validateAllowedStringValue([]string{
+						"0", "1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11",
+						"12", "13", "14", "15", "16", "17", "18", "19", "20", "21", "22", "23",
+					}),
+				},
+			},
+
+			"retention_days": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateSnapshotRetentionDays,
+			},
+		},
+	}
+}
+
+// snapshotPolicyArgs returns the parameters of the policy, which CreateAutoSnapshotPolicy
+// and ModifyAutoSnapshotPolicyEx take in lower camel case.
+func snapshotPolicyArgs(d *schema.ResourceData, meta interface{}) url.Values {
+	args := url.Values{}
+	args.Set("RegionId", string(getRegion(d, meta)))
+	if v, ok := d.GetOk("name"); ok {
+		args.Set("autoSnapshotPolicyName", v.(string))
+	}
+	args.Set("repeatWeekdays", convertListToJsonString(sortedSetList(d.Get("repeat_weekdays").(*schema.Set))))
+	args.Set("timePoints", convertListToJsonString(sortedSetList(d.Get("time_points").(*schema.Set))))
+	args.Set("retentionDays", strconv.Itoa(d.Get("retention_days").(int)))
+	return args
+}
+
+// sortedSetList returns the numbers of the set in order, so the policy is sent the same way every time.
+func sortedSetList(set *schema.Set) []interface{} {
+	list := set.List()
+	sort.Slice(list, func(i, j int) bool {
+		a, _ := strconv.Atoi(list[i].(string))
+		b, _ := strconv.Atoi(list[j].(string))
+		return a < b
+	})
+	return list
+}
+
+func resourceAlicloudSnapshotPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	var response CreateAutoSnapshotPolicyResponse
+	err := client.ecsConn().Invoke("CreateAutoSnapshotPolicy", snapshotPolicyArgs(d, meta), &response)
+	if err != nil {
+		return fmt.Errorf("CreateAutoSnapshotPolicy got an error: %#v", err)
+	}
+
+	d.SetId(response.AutoSnapshotPolicyId)
+
+	return resourceAlicloudSnapshotPolicyRead(d, meta)
+}
+
+func resourceAlicloudSnapshotPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	policy, err := client.DescribeAutoSnapshotPolicy(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("DescribeAutoSnapshotPolicyEx got an error: %#v", err)
+	}
+
+	var weekdays, timePoints []string
+	if err := json.Unmarshal([]byte(policy.RepeatWeekdays), &weekdays); err != nil {
+		return fmt.Errorf("Parsing the repeat weekdays %s got an error: %#v", policy.RepeatWeekdays, err)
+	}
+	if err := json.Unmarshal([]byte(policy.TimePoints), &timePoints); err != nil {
+		return fmt.Errorf("Parsing the time points %s got an error: %#v", policy.TimePoints, err)
+	}
+
+	d.Set("name", policy.AutoSnapshotPolicyName)
+	d.Set("repeat_weekdays", weekdays)
+	d.Set("time_points", timePoints)
+	d.Set("retention_days", policy.RetentionDays)
+
+	return nil
+}
+
+func resourceAlicloudSnapshotPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	args := snapshotPolicyArgs(d, meta)
+	args.Set("autoSnapshotPolicyId", d.Id())
+	if err := client.ecsConn().Invoke("ModifyAutoSnapshotPolicyEx", args, &common.Response{}); err != nil {
+		return fmt.Errorf("ModifyAutoSnapshotPolicyEx got an error: %#v", err)
+	}
+
+	return resourceAlicloudSnapshotPolicyRead(d, meta)
+}
+
+func resourceAlicloudSnapshotPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	args := url.Values{}
+	args.Set("RegionId", string(getRegion(d, meta)))
+	args.Set("autoSnapshotPolicyId", d.Id())
+
+	// The policy can't be deleted until it is canceled from all of the disks, e.g. by the attachments being destroyed.
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		err := client.ecsConn().Invoke("DeleteAutoSnapshotPolicy", args, &common.Response{})
+		if err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			if IsConflictError(err) {
+				return resource.RetryableError(fmt.Errorf("Automatic snapshot policy in use - trying again while it is deleted."))
+			}
+			return resource.NonRetryableError(fmt.Errorf("DeleteAutoSnapshotPolicy got an error: %#v", err))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,147 @@
+package alicloud
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAlicloudSnapshotPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAlicloudSnapshotPolicyAttachmentCreate,
+		Read:   resourceAlicloudSnapshotPolicyAttachmentRead,
+		Update: resourceAlicloudSnapshotPolicyAttachmentUpdate,
+		Delete: resourceAlicloudSnapshotPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAlicloudSnapshotPolicyAttachmentImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"snapshot_policy_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// A disk has one automatic snapshot policy at most, and applying another one replaces it.
+			"disk_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+	policyId := d.Get("snapshot_policy_id").(string)
+
+	if err := applySnapshotPolicy(client, policyId, d.Get("disk_ids").(*schema.Set).List()); err != nil {
+		return err
+	}
+
+	d.SetId(policyId)
+
+	return resourceAlicloudSnapshotPolicyAttachmentRead(d, meta)
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	if _, err := client.DescribeAutoSnapshotPolicy(d.Id()); err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("DescribeAutoSnapshotPolicyEx got an error: %#v", err)
+	}
+
+	diskIds, err := client.DescribeAutoSnapshotPolicyDisks(d.Id())
+	if err != nil {
+		return fmt.Errorf("DescribeDisks of the automatic snapshot policy %s got an error: %#v", d.Id(), err)
+	}
+
+	// The policy can be applied to other disks by other attachments or out of band,
+	// so only the disks of this attachment which still have the policy are kept.
+	attached := d.Get("disk_ids").(*schema.Set)
+	var ids []string
+	for _, id := range diskIds {
+		if attached.Contains(id) {
+			ids = append(ids, id)
+		}
+	}
+
+	d.Set("snapshot_policy_id", d.Id())
+	d.Set("disk_ids", ids)
+
+	return nil
+}
+
+// resourceAlicloudSnapshotPolicyAttachmentImport imports all the disks which the policy is applied to.
+func resourceAlicloudSnapshotPolicyAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	diskIds, err := meta.(*AliyunClient).DescribeAutoSnapshotPolicyDisks(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("DescribeDisks of the automatic snapshot policy %s got an error: %#v", d.Id(), err)
+	}
+
+	d.Set("snapshot_policy_id", d.Id())
+	d.Set("disk_ids", diskIds)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	if d.HasChange("disk_ids") {
+		o, n := d.GetChange("disk_ids")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if err := cancelSnapshotPolicy(client, os.Difference(ns).List()); err != nil {
+			return err
+		}
+		if err := applySnapshotPolicy(client, d.Id(), ns.Difference(os).List()); err != nil {
+			return err
+		}
+	}
+
+	return resourceAlicloudSnapshotPolicyAttachmentRead(d, meta)
+}
+
+func resourceAlicloudSnapshotPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*AliyunClient)
+
+	return cancelSnapshotPolicy(client, d.Get("disk_ids").(*schema.Set).List())
+}
+
+func applySnapshotPolicy(client *AliyunClient, policyId string, diskIds []interface{}) error {
+	if len(diskIds) < 1 {
+		return nil
+	}
+
+	args := url.Values{}
+	args.Set("RegionId", string(client.Region))
+	args.Set("autoSnapshotPolicyId", policyId)
+	args.Set("diskIds", convertListToJsonString(diskIds))
+	if err := client.ecsConn().Invoke("ApplyAutoSnapshotPolicy", args, &common.Response{}); err != nil {
+		return fmt.Errorf("ApplyAutoSnapshotPolicy got an error: %#v", err)
+	}
+	return nil
+}
+
+func cancelSnapshotPolicy(client *AliyunClient, diskIds []interface{}) error {
+	if len(diskIds) < 1 {
+		return nil
+	}
+
+	args := url.Values{}
+	args.Set("RegionId", string(client.Region))
+	args.Set("diskIds", convertListToJsonString(diskIds))
+	if err := client.ecsConn().Invoke("CancelAutoSnapshotPolicy", args, &common.Response{}); err != nil && !NotFoundError(err) {
+		return fmt.Errorf("CancelAutoSnapshotPolicy got an error: %#v", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudSnapshotPolicyAttachment_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_snapshot_policy_attachment.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapshotPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSnapshotPolicyAttachmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("alicloud_snapshot_policy_attachment.foo", "snapshot_policy_id", "alicloud_snapshot_policy.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy_attachment.foo", "disk_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSnapshotPolicyAttachment_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	// applied checks the number of the disks which the policy is applied to.
+	applied := func(count int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+
+			policyId := s.RootModule().Resources["alicloud_snapshot_policy.foo"].Primary.ID
+			n := 0
+			for _, id := range server.diskPolicies {
+				if id == policyId {
+					n++
+				}
+			}
+			if n != count {
+				return fmt.Errorf("Expected the policy applied to %d disks, got %d.", count, n)
+			}
+			return nil
+		}
+	}
+
+	// outOfBand is the disk which the policy is applied to without the attachment.
+	var outOfBand string
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.diskPolicies) > 0 || len(server.policies) > 0 {
+				return fmt.Errorf("Automatic snapshot policies are still applied: %v", server.diskPolicies)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotPolicyAttachmentConfig, `"${alicloud_disk.foo.0.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("alicloud_snapshot_policy_attachment.foo", "snapshot_policy_id", "alicloud_snapshot_policy.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy_attachment.foo", "disk_ids.#", "1"),
+					applied(1),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotPolicyAttachmentConfig, `"${alicloud_disk.foo.1.id}", "${alicloud_disk.foo.2.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy_attachment.foo", "disk_ids.#", "2"),
+					applied(2),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CancelAutoSnapshotPolicy"); n != 1 {
+							return fmt.Errorf("Expected the policy canceled from the removed disk, got %d calls.", n)
+						}
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				// The policy applied to another disk out of band isn't a change of the attachment.
+				PreConfig: func() {
+					server.lock.Lock()
+					defer server.lock.Unlock()
+					for id := range server.disks {
+						if _, ok := server.diskPolicies[id]; !ok {
+							outOfBand = id
+						}
+					}
+					for _, policyId := range server.diskPolicies {
+						server.diskPolicies[outOfBand] = policyId
+					}
+				},
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotPolicyAttachmentConfig, `"${alicloud_disk.foo.1.id}", "${alicloud_disk.foo.2.id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy_attachment.foo", "disk_ids.#", "2"),
+					applied(3),
+					func(*terraform.State) error {
+						server.lock.Lock()
+						defer server.lock.Unlock()
+						delete(server.diskPolicies, outOfBand)
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				Config:            server.providerConfig() + fmt.Sprintf(testUnitSnapshotPolicyAttachmentConfig, `"${alicloud_disk.foo.1.id}", "${alicloud_disk.foo.2.id}"`),
+				ResourceName:      "alicloud_snapshot_policy_attachment.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccSnapshotPolicyAttachmentConfig = `
+data "alicloud_zones" "default" {
+	"available_disk_category"= "cloud_efficiency"
+}
+
+resource "alicloud_disk" "foo" {
+	count = 2
+	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+	category = "cloud_efficiency"
+	size = "20"
+}
+
+resource "alicloud_snapshot_policy" "foo" {
+	name = "tf-testAccSnapshotPolicyAttachment"
+	repeat_weekdays = ["1"]
+	time_points = ["0"]
+	retention_days = 7
+}
+
+resource "alicloud_snapshot_policy_attachment" "foo" {
+	snapshot_policy_id = "${alicloud_snapshot_policy.foo.id}"
+	disk_ids = ["${alicloud_disk.foo.*.id}"]
+}
+`
+
+const testUnitSnapshotPolicyAttachmentConfig = `
+resource "alicloud_disk" "foo" {
+  count = 3
+  availability_zone = "cn-beijing-a"
+  size = 20
+}
+
+resource "alicloud_snapshot_policy" "foo" {
+  repeat_weekdays = ["1"]
+  time_points = ["0"]
+  retention_days = 7
+}
+
+resource "alicloud_snapshot_policy_attachment" "foo" {
+  snapshot_policy_id = "${alicloud_snapshot_policy.foo.id}"
+  disk_ids = [%s]
+}
+`
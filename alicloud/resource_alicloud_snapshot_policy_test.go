@@ -0,0 +1,170 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudSnapshotPolicy_basic(t *testing.T) {
+	var v AutoSnapshotPolicyType
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_snapshot_policy.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapshotPolicyDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSnapshotPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapshotPolicyExists("alicloud_snapshot_policy.foo", &v),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "name", "tf-testAccSnapshotPolicy"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "repeat_weekdays.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "time_points.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "retention_days", "7"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSnapshotPolicy_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.policies) > 0 {
+				return fmt.Errorf("Automatic snapshot policies still exist: %d", len(server.policies))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotPolicyConfig, `"1", "5"`, `"12", "0"`, 7),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "name", "tf-testAccSnapshotPolicy"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "repeat_weekdays.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "time_points.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "retention_days", "7"),
+					testUnitCheckSnapshotPolicy(server, `["1","5"]`, `["0","12"]`),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotPolicyConfig, `"1", "3", "5"`, `"2"`, -1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "repeat_weekdays.#", "3"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "time_points.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "retention_days", "-1"),
+					testUnitCheckSnapshotPolicy(server, `["1","3","5"]`, `["2"]`),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSnapshotPolicy_invalidPlan(t *testing.T) {
+	testUnitInvalidPlans(t, testUnitSnapshotPolicyConfigInvalid, []fakeInvalidPlan{
+		{`repeat_weekdays = ["0"]
+  time_points = ["1"]
+  retention_days = 7`, "must contain a valid string value"},
+		{`repeat_weekdays = ["1"]
+  time_points = ["24"]
+  retention_days = 7`, "must contain a valid string value"},
+		{`repeat_weekdays = ["1"]
+  time_points = ["1"]
+  retention_days = 0`, "must be -1 or between 1 and 65536"},
+	})
+}
+
+// testUnitCheckSnapshotPolicy checks the policy is sent to the API in order.
+func testUnitCheckSnapshotPolicy(server *fakeServer, weekdays, timePoints string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		server.lock.Lock()
+		defer server.lock.Unlock()
+
+		policy, ok := server.policies[s.RootModule().Resources["alicloud_snapshot_policy.foo"].Primary.ID]
+		if !ok {
+			return fmt.Errorf("The automatic snapshot policy isn't found.")
+		}
+		if policy.RepeatWeekdays != weekdays || policy.TimePoints != timePoints {
+			return fmt.Errorf("Expected the policy on %s at %s, got %s at %s.", weekdays, timePoints, policy.RepeatWeekdays, policy.TimePoints)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSnapshotPolicyExists(n string, policy *AutoSnapshotPolicyType) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Snapshot Policy ID is set")
+		}
+
+		client := testAccProvider.Meta().(*AliyunClient)
+		v, err := client.DescribeAutoSnapshotPolicy(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding Snapshot Policy %s: %#v", rs.Primary.ID, err)
+		}
+		*policy = *v
+		return nil
+	}
+}
+
+func testAccCheckSnapshotPolicyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*AliyunClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_snapshot_policy" {
+			continue
+		}
+
+		if _, err := client.DescribeAutoSnapshotPolicy(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Snapshot Policy %s still exist", rs.Primary.ID)
+		} else if !NotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccSnapshotPolicyConfig = `
+resource "alicloud_snapshot_policy" "foo" {
+	name = "tf-testAccSnapshotPolicy"
+	repeat_weekdays = ["1", "5"]
+	time_points = ["0", "12"]
+	retention_days = 7
+}
+`
+
+const testUnitSnapshotPolicyConfig = `
+resource "alicloud_snapshot_policy" "foo" {
+  name = "tf-testAccSnapshotPolicy"
+  repeat_weekdays = [%s]
+  time_points = [%s]
+  retention_days = %d
+}
+`
+
+const testUnitSnapshotPolicyConfigInvalid = `
+resource "alicloud_snapshot_policy" "invalid" {
+  name = "tf-testAccSnapshotPolicy"
+  %s
+}
+`
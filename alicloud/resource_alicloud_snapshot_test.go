@@ -0,0 +1,201 @@
+package alicloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/denverdino/aliyungo/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudSnapshot_basic(t *testing.T) {
+	var v ecs.SnapshotType
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_snapshot.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapshotDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccSnapshotConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapshotExists("alicloud_snapshot.foo", &v),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "name", "tf-testAccSnapshot"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "status", "accomplished"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "source_disk_size", "20"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "tags.Name", "TerraformTest"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSnapshot_basic(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.snapshots) > 0 {
+				return fmt.Errorf("Snapshots still exist: %d", len(server.snapshots))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotConfig, "tf-testAccSnapshot", "Snapshot of the disk.", "TerraformTest"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("alicloud_snapshot.foo", "disk_id", "alicloud_disk.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "name", "tf-testAccSnapshot"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "description", "Snapshot of the disk."),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "status", "accomplished"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "source_disk_type", "Data"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "source_disk_size", "20"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "tags.Name", "TerraformTest"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotConfig, "tf-testAccSnapshot-renamed", "Renamed snapshot.", "TerraformTestRenamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "name", "tf-testAccSnapshot-renamed"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "description", "Renamed snapshot."),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "tags.Name", "TerraformTestRenamed"),
+					func(*terraform.State) error {
+						// The name and the description are changed in place.
+						if n := server.calls("ecs/CreateSnapshot"); n != 1 {
+							return fmt.Errorf("Expected the snapshot created once, got %d.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSnapshot_diskBusy(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+	server.snapshotErrors = []string{DiskCreatingSnapshot, DiskCreatingSnapshot}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + fmt.Sprintf(testUnitSnapshotConfig, "tf-testAccSnapshot", "Snapshot of the disk.", "TerraformTest"),
+				Check: func(*terraform.State) error {
+					if n := server.calls("ecs/CreateSnapshot"); n != 3 {
+						return fmt.Errorf("The snapshot should be taken again while the disk is busy, but it was taken %d times.", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSnapshot_incorrectDiskStatus(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+	server.snapshotErrors = []string{DiskIncorrectStatus}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      server.providerConfig() + fmt.Sprintf(testUnitSnapshotConfig, "tf-testAccSnapshot", "Snapshot of the disk.", "TerraformTest"),
+				ExpectError: regexp.MustCompile(DiskIncorrectStatus),
+			},
+		},
+	})
+	if n := server.calls("ecs/CreateSnapshot"); n != 1 {
+		t.Fatalf("The snapshot of a disk in an incorrect status shouldn't be taken again, but it was taken %d times.", n)
+	}
+}
+
+func testAccCheckSnapshotExists(n string, snapshot *ecs.SnapshotType) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Snapshot ID is set")
+		}
+
+		client := testAccProvider.Meta().(*AliyunClient)
+		v, err := client.DescribeSnapshot(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding Snapshot %s: %#v", rs.Primary.ID, err)
+		}
+		*snapshot = *v
+		return nil
+	}
+}
+
+func testAccCheckSnapshotDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*AliyunClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_snapshot" {
+			continue
+		}
+
+		if _, err := client.DescribeSnapshot(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Snapshot %s still exist", rs.Primary.ID)
+		} else if !NotFoundError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testAccSnapshotConfig = `
+data "alicloud_zones" "default" {
+	"available_disk_category"= "cloud_efficiency"
+}
+
+resource "alicloud_disk" "foo" {
+	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+	category = "cloud_efficiency"
+	size = "20"
+}
+
+resource "alicloud_snapshot" "foo" {
+	disk_id = "${alicloud_disk.foo.id}"
+	name = "tf-testAccSnapshot"
+	description = "Snapshot of the disk."
+	tags {
+		Name = "TerraformTest"
+	}
+}
+`
+
+const testUnitSnapshotConfig = `
+resource "alicloud_disk" "foo" {
+  availability_zone = "cn-beijing-a"
+  size = 20
+}
+
+resource "alicloud_snapshot" "foo" {
+  disk_id = "${alicloud_disk.foo.id}"
+  name = "%s"
+  description = "%s"
+  tags = {
+    Name = "%s"
+  }
+}
+`
@@ -13,6 +13,9 @@ func resourceAliyunSnatEntry() *schema.Resource {
 		Read:   resourceAliyunSnatEntryRead,
 		Update: resourceAliyunSnatEntryUpdate,
 		Delete: resourceAliyunSnatEntryDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAliyunSnatEntryImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"snat_table_id": &schema.Schema{
@@ -34,7 +37,10 @@ func resourceAliyunSnatEntry() *schema.Resource {
 }
 
 func resourceAliyunSnatEntryCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).vpcconn
+	conn := meta.(*AliyunClient).vpcConn()
+
+	unlock := lockParent(SnatTableLock, d.Get("snat_table_id").(string))
+	defer unlock()
 
 	args := &ecs.CreateSnatEntryArgs{
 		RegionId:        getRegion(d, meta),
@@ -61,6 +67,7 @@ func resourceAliyunSnatEntryRead(d *schema.ResourceData, meta interface{}) error
 
 	if err != nil {
 		if NotFoundError(err) {
+			d.SetId("")
 			return nil
 		}
 		return err
@@ -75,7 +82,10 @@ func resourceAliyunSnatEntryRead(d *schema.ResourceData, meta interface{}) error
 
 func resourceAliyunSnatEntryUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := client.vpcConn()
+
+	unlock := lockParent(SnatTableLock, d.Get("snat_table_id").(string))
+	defer unlock()
 
 	snatEntry, err := client.DescribeSnatEntry(d.Get("snat_table_id").(string), d.Id())
 	if err != nil {
@@ -114,13 +124,27 @@ func resourceAliyunSnatEntryUpdate(d *schema.ResourceData, meta interface{}) err
 	return resourceAliyunSnatEntryRead(d, meta)
 }
 
+// resourceAliyunSnatEntryImport imports a snat entry by the ID <snat_table_id>:<snat_entry_id>.
+func resourceAliyunSnatEntryImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportId(d.Id(), "snat_table_id", "snat_entry_id")
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("snat_table_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceAliyunSnatEntryDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*AliyunClient)
-	conn := client.vpcconn
+	conn := meta.(*AliyunClient).vpcConn()
 
 	snatEntryId := d.Id()
 	snatTableId := d.Get("snat_table_id").(string)
 
+	unlock := lockParent(SnatTableLock, snatTableId)
+	defer unlock()
+
 	args := &ecs.DeleteSnatEntryArgs{
 		RegionId:    getRegion(d, meta),
 		SnatTableId: snatTableId,
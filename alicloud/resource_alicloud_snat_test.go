@@ -41,6 +41,53 @@ func TestAccAlicloudSnat_basic(t *testing.T) {
 
 }
 
+func TestUnitAlicloudSnatEntry_concurrent(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	noConflicts := func(*terraform.State) error {
+		if n := server.conflicted(); n > 0 {
+			return fmt.Errorf("The entries of one SNAT table should be changed one by one, but %d changes conflicted.", n)
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: resource.ComposeTestCheckFunc(noConflicts, func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if n := len(server.snatEntries[fakeSnatTableId]); n > 0 {
+				return fmt.Errorf("SNAT entries still exist: %d", n)
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitSnatEntryConcurrent,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo.9", "source_vswitch_id", "vsw-fake9"),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo.9", "snat_ip", "47.0.0.1"),
+					func(*terraform.State) error {
+						if n := server.calls("vpc/CreateSnatEntry"); n != 10 {
+							return fmt.Errorf("Expected 10 SNAT entries created, got %d.", n)
+						}
+						return nil
+					},
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitSnatEntryConcurrentUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo.9", "snat_ip", "47.0.0.2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSnatEntryDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*AliyunClient)
 
@@ -108,6 +155,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.2.id}"
@@ -116,7 +164,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Small"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 2
 	  bandwidth = 5
@@ -147,6 +195,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "172.16.0.0/21"
 	availability_zone = "${data.alicloud_zones.default.zones.2.id}"
@@ -155,7 +204,7 @@ resource "alicloud_vswitch" "foo" {
 resource "alicloud_nat_gateway" "foo" {
 	vpc_id = "${alicloud_vpc.foo.id}"
 	spec = "Small"
-	name = "test_foo"
+	name = "tf_test_foo"
 	bandwidth_packages = [{
 	  ip_count = 2
 	  bandwidth = 5
@@ -174,3 +223,21 @@ resource "alicloud_snat_entry" "foo"{
 	snat_ip = "${alicloud_nat_gateway.foo.bandwidth_packages.1.public_ip_addresses}"
 }
 `
+
+const testUnitSnatEntryConcurrent = `
+resource "alicloud_snat_entry" "foo" {
+  count = 10
+  snat_table_id = "stb-fake"
+  source_vswitch_id = "vsw-fake${count.index}"
+  snat_ip = "47.0.0.1"
+}
+`
+
+const testUnitSnatEntryConcurrentUpdate = `
+resource "alicloud_snat_entry" "foo" {
+  count = 10
+  snat_table_id = "stb-fake"
+  source_vswitch_id = "vsw-fake${count.index}"
+  snat_ip = "47.0.0.2"
+}
+`
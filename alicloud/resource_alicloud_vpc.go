@@ -20,6 +20,9 @@ func resourceAliyunVpc() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		SchemaVersion: 1,
+		MigrateState:  resourceAlicloudVpcMigrateState,
+
 		Schema: map[string]*schema.Schema{
 			"cidr_block": &schema.Schema{
 				Type:         schema.TypeString,
@@ -68,13 +71,16 @@ func resourceAliyunVpc() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAliyunVpcCreate(d *schema.ResourceData, meta interface{}) error {
 
-	ecsconn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	ecsconn := client.ecsConn()
 
 	var vpc *ecs.CreateVpcResponse
 	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
@@ -84,7 +90,7 @@ func resourceAliyunVpcCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 		resp, err := ecsconn.CreateVpc(args)
 		if err != nil {
-			if IsExceptedError(err, VpcQuotaExceeded) {
+			if IsQuotaExceededError(err) {
 				return resource.NonRetryableError(fmt.Errorf("The number of VPC has quota has reached the quota limit in your account, and please use existing VPCs or remove some of them."))
 			}
 			if IsExceptedError(err, UnknownError) {
@@ -127,7 +133,7 @@ func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", vpc.VpcName)
 	d.Set("description", vpc.Description)
 	d.Set("router_id", vpc.VRouterId)
-	vrouters, _, err := client.vpcconn.DescribeVRouters(&ecs.DescribeVRoutersArgs{
+	vrouters, _, err := client.vpcConn().DescribeVRouters(&ecs.DescribeVRoutersArgs{
 		VRouterId: vpc.VRouterId,
 		RegionId:  getRegion(d, meta),
 	})
@@ -139,15 +145,25 @@ func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("route_table_id", "")
 	}
 
+	if err := readTags(client, VpcTagResourceVpc, d); err != nil {
+		return fmt.Errorf("Describe tags for vpc got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	d.Partial(true)
 
+	if err := setTags(client, VpcTagResourceVpc, d); err != nil {
+		return fmt.Errorf("Set tags for vpc got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
 	attributeUpdate := false
 	args := &ecs.ModifyVpcAttributeArgs{
 		VpcId: d.Id(),
@@ -179,7 +195,7 @@ func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceAliyunVpcDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := conn.DeleteVpc(d.Id())
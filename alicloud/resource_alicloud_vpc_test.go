@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"fmt"
+	"log"
 	"testing"
 
 	"github.com/denverdino/aliyungo/common"
@@ -10,6 +11,56 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_vpc", &resource.Sweeper{
+		Name: "alicloud_vpc",
+		F:    testSweepVpcs,
+		// The VPCs can't be deleted until the resources in them are deleted.
+		Dependencies: []string{
+			"alicloud_vswitch",
+			"alicloud_security_group",
+			"alicloud_nat_gateway",
+		},
+	})
+}
+
+func testSweepVpcs(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var vpcs []ecs.VpcSetType
+	args := &ecs.DescribeVpcsArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		page, pagination, err := client.ecsConn().DescribeVpcs(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving VPCs: %#v", err)
+		}
+		vpcs = append(vpcs, page...)
+		next := pagination.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range vpcs {
+		if !isTestSweepName(v.VpcName) {
+			log.Printf("[INFO] Skipping VPC: %s (%s)", v.VpcName, v.VpcId)
+			continue
+		}
+		log.Printf("[INFO] Deleting VPC: %s (%s)", v.VpcName, v.VpcId)
+		if err := testSweepResource(resourceAliyunVpc(), client, v.VpcId, nil); err != nil {
+			log.Printf("[ERROR] Failed to delete VPC (%s (%s)): %s", v.VpcName, v.VpcId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudVpc_basic(t *testing.T) {
 	var vpc ecs.VpcSetType
 
@@ -98,6 +149,43 @@ func TestAccAlicloudVpc_multi(t *testing.T) {
 	})
 }
 
+func TestUnitAlicloudVpc_update(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_vpc.foo",
+		Providers:     testUnitProviders(),
+		CheckDestroy: func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.vpcs) > 0 {
+				return fmt.Errorf("VPCs still exist: %d", len(server.vpcs))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testAccVpcConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "cidr_block", "172.16.0.0/12"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "name", "tf_test_foo"),
+					resource.TestCheckResourceAttrSet("alicloud_vpc.foo", "router_id"),
+					resource.TestCheckResourceAttrSet("alicloud_vpc.foo", "route_table_id"),
+				),
+			},
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitVpcConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "name", "tf_test_bar"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.env", "test"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVpcExists(n string, vpc *ecs.VpcSetType) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -181,3 +269,14 @@ resource "alicloud_vpc" "bar_3" {
 	name = "tf_test_bar_3"
 }
 `
+
+const testUnitVpcConfigUpdate = `
+resource "alicloud_vpc" "foo" {
+  name = "tf_test_bar"
+  cidr_block = "172.16.0.0/12"
+
+  tags = {
+    env = "test"
+  }
+}
+`
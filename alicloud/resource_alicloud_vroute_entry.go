@@ -52,14 +52,18 @@ func resourceAliyunRouteEntry() *schema.Resource {
 }
 
 func resourceAliyunRouteEntryCreate(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	rtId := d.Get("route_table_id").(string)
 	cidr := d.Get("destination_cidrblock").(string)
 	nt := d.Get("nexthop_type").(string)
 	ni := d.Get("nexthop_id").(string)
 
-	table, err := meta.(*AliyunClient).QueryRouteTableById(rtId)
+	unlock := lockParent(RouteTableLock, rtId)
+	defer unlock()
+
+	table, err := client.QueryRouteTableById(rtId)
 
 	if err != nil {
 		if NotFoundError(err) {
@@ -84,12 +88,8 @@ func resourceAliyunRouteEntryCreate(d *schema.ResourceData, meta interface{}) er
 			// Route Entry does not support concurrence when creating or deleting it;
 			// Route Entry does not support creating or deleting within 5 seconds frequently
 			// It must ensure all the route entries and vswitches' status must be available before creating or deleting route entry.
-			if IsExceptedError(err, TaskConflict) || IsExceptedError(err, IncorrectRouteEntryStatus) {
-				time.Sleep(5 * time.Second)
-				return resource.RetryableError(fmt.Errorf("Create route entry timeout and got an error: %#v", err))
-			}
 			if IsExceptedError(err, RouterEntryConflictDuplicated) {
-				en, err := meta.(*AliyunClient).QueryRouteEntry(rtId, cidr, nt, ni)
+				en, err := client.QueryRouteEntry(rtId, cidr, nt, ni)
 				if err != nil {
 					return resource.NonRetryableError(err)
 				}
@@ -97,6 +97,10 @@ func resourceAliyunRouteEntryCreate(d *schema.ResourceData, meta interface{}) er
 					"Please import it using ID '%s:%s:%s:%s:%s' or specify a new 'destination_cidrblock' and try again.",
 					en.DestinationCidrBlock, en.RouteTableId, table.VRouterId, en.DestinationCidrBlock, en.NextHopType, en.NextHopId))
 			}
+			if IsRetryableError(err) || IsExceptedError(err, TaskConflict) || IsExceptedError(err, IncorrectRouteEntryStatus) {
+				time.Sleep(5 * time.Second)
+				return resource.RetryableError(fmt.Errorf("Create route entry timeout and got an error: %#v", err))
+			}
 			return resource.NonRetryableError(fmt.Errorf("Creating Route entry got an error: %#v", err))
 		}
 		return nil
@@ -142,7 +146,7 @@ func resourceAliyunRouteEntryRead(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceAliyunRouteEntryDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 	args, err := buildAliyunRouteEntryDeleteArgs(d, meta)
 
 	if err != nil {
@@ -155,6 +159,9 @@ func resourceAliyunRouteEntryDelete(d *schema.ResourceData, meta interface{}) er
 	nexthop_type := parts[3]
 	nexthop_id := parts[4]
 
+	unlock := lockParent(RouteTableLock, rtId)
+	defer unlock()
+
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		en, err := client.QueryRouteEntry(rtId, cidr, nexthop_type, nexthop_id)
 		if err != nil {
@@ -169,8 +176,8 @@ func resourceAliyunRouteEntryDelete(d *schema.ResourceData, meta interface{}) er
 		}
 
 		if err := conn.DeleteRouteEntry(args); err != nil {
-			if IsExceptedError(err, TaskConflict) || IsExceptedError(err, IncorrectRouteEntryStatus) ||
-				IsExceptedError(err, RouterEntryForbbiden) || IsExceptedError(err, UnknownError) {
+			if IsRetryableError(err) || IsExceptedError(err, TaskConflict) || IsExceptedError(err, IncorrectRouteEntryStatus) ||
+				IsExceptedError(err, RouterEntryForbbiden) {
 				// Route Entry does not support creating or deleting within 5 seconds frequently
 				time.Sleep(5 * time.Second)
 				return resource.RetryableError(fmt.Errorf("Delete route entry timeout and got an error: %#v.", err))
@@ -66,6 +66,45 @@ func TestAccAlicloudRouteEntry_RouteInterface(t *testing.T) {
 
 }
 
+func TestUnitAlicloudRouteEntry_concurrent(t *testing.T) {
+	server := newFakeServer()
+	defer server.Close()
+
+	noConflicts := func(*terraform.State) error {
+		if n := server.conflicted(); n > 0 {
+			return fmt.Errorf("The entries of one route table should be changed one by one, but %d changes conflicted.", n)
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testUnitProviders(),
+		CheckDestroy: resource.ComposeTestCheckFunc(noConflicts, func(*terraform.State) error {
+			server.lock.Lock()
+			defer server.lock.Unlock()
+			if len(server.routeTables) > 0 {
+				return fmt.Errorf("Route tables still exist: %d", len(server.routeTables))
+			}
+			return nil
+		}),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: server.providerConfig() + testUnitRouteEntryConcurrent,
+				Check: resource.ComposeTestCheckFunc(
+					noConflicts,
+					resource.TestCheckResourceAttr("alicloud_route_entry.foo.9", "destination_cidrblock", "10.0.9.0/24"),
+					func(*terraform.State) error {
+						if n := server.calls("ecs/CreateRouteEntry"); n != 10 {
+							return fmt.Errorf("Expected 10 route entries created, got %d.", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckRouteTableExists(rtId string, t *ecs.RouteTableSetType) error {
 	client := testAccProvider.Meta().(*AliyunClient)
 	//query route table
@@ -161,6 +200,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+	name = "tf_test_foo"
 	vpc_id = "${alicloud_vpc.foo.id}"
 	cidr_block = "10.1.1.0/24"
 	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -205,7 +245,7 @@ resource "alicloud_instance" "foo" {
 
 	system_disk_category = "cloud_efficiency"
 	image_id = "ubuntu_140405_64_40G_cloudinit_20161115.vhd"
-	instance_name = "test_foo"
+	instance_name = "tf_test_foo"
 }`
 
 const testAccRouteEntryInterfaceConfig = `
@@ -219,6 +259,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  name = "tf_test_foo"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "10.1.1.0/24"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -257,3 +298,18 @@ resource "alicloud_router_interface" "interface" {
   name = "test1"
   description = "test1"
 }`
+
+const testUnitRouteEntryConcurrent = `
+resource "alicloud_vpc" "foo" {
+  name = "tf_test_foo"
+  cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_route_entry" "foo" {
+  count = 10
+  route_table_id = "${alicloud_vpc.foo.route_table_id}"
+  destination_cidrblock = "10.0.${count.index}.0/24"
+  nexthop_type = "Instance"
+  nexthop_id = "i-fake"
+}
+`
@@ -46,13 +46,15 @@ func resourceAliyunSubnet() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+
+			"tags": tagsSchema(),
 		},
 	}
 }
 
 func resourceAliyunSwitchCreate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	var vswitchID, vpcID string
 	if err := resource.Retry(3*time.Minute, func() *resource.RetryError {
@@ -85,7 +87,8 @@ func resourceAliyunSwitchCreate(d *schema.ResourceData, meta interface{}) error
 
 func resourceAliyunSwitchRead(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	args := &ecs.DescribeVSwitchesArgs{
 		RegionId:  getRegion(d, meta),
@@ -116,15 +119,25 @@ func resourceAliyunSwitchRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", vswitch.VSwitchName)
 	d.Set("description", vswitch.Description)
 
+	if err := readTags(client, VpcTagResourceVSwitch, d); err != nil {
+		return fmt.Errorf("Describe tags for vswitch got error: %#v", err)
+	}
+
 	return nil
 }
 
 func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error {
 
-	conn := meta.(*AliyunClient).ecsconn
+	client := meta.(*AliyunClient)
+	conn := client.ecsConn()
 
 	d.Partial(true)
 
+	if err := setTags(client, VpcTagResourceVSwitch, d); err != nil {
+		return fmt.Errorf("Set tags for vswitch got error: %#v", err)
+	}
+	d.SetPartial("tags")
+
 	attributeUpdate := false
 	args := &ecs.ModifyVSwitchAttributeArgs{
 		VSwitchId: d.Id(),
@@ -156,7 +169,7 @@ func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceAliyunSwitchDelete(d *schema.ResourceData, meta interface{}) error {
-	conn := meta.(*AliyunClient).ecsconn
+	conn := meta.(*AliyunClient).ecsConn()
 
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 		err := conn.DeleteVSwitch(d.Id())
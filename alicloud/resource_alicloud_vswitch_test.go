@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"fmt"
+	"log"
 	"testing"
 
 	"github.com/denverdino/aliyungo/common"
@@ -10,6 +11,58 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 )
 
+func init() {
+	resource.AddTestSweepers("alicloud_vswitch", &resource.Sweeper{
+		Name: "alicloud_vswitch",
+		F:    testSweepVSwitches,
+		// The VSwitches can't be deleted until the instances in them are deleted.
+		Dependencies: []string{
+			"alicloud_instance",
+			"alicloud_slb",
+			"alicloud_db_instance",
+		},
+	})
+}
+
+func testSweepVSwitches(region string) error {
+	client, err := sharedClientForRegion(region)
+	if err != nil {
+		return fmt.Errorf("error getting Alicloud client: %s", err)
+	}
+
+	var vswitches []ecs.VSwitchSetType
+	args := &ecs.DescribeVSwitchesArgs{
+		RegionId:   client.Region,
+		Pagination: getPagination(1, 50),
+	}
+	for {
+		page, pagination, err := client.ecsConn().DescribeVSwitches(args)
+		if err != nil {
+			return fmt.Errorf("Error retrieving VSwitches: %#v", err)
+		}
+		vswitches = append(vswitches, page...)
+		next := pagination.NextPage()
+		if next == nil {
+			break
+		}
+		args.Pagination = *next
+	}
+
+	for _, v := range vswitches {
+		if !isTestSweepName(v.VSwitchName) {
+			log.Printf("[INFO] Skipping VSwitch: %s (%s)", v.VSwitchName, v.VSwitchId)
+			continue
+		}
+		log.Printf("[INFO] Deleting VSwitch: %s (%s)", v.VSwitchName, v.VSwitchId)
+		if err := testSweepResource(resourceAliyunSubnet(), client, v.VSwitchId, map[string]interface{}{
+			"vpc_id": v.VpcId,
+		}); err != nil {
+			log.Printf("[ERROR] Failed to delete VSwitch (%s (%s)): %s", v.VSwitchName, v.VSwitchId, err)
+		}
+	}
+	return nil
+}
+
 func TestAccAlicloudVswitch_basic(t *testing.T) {
 	var vsw ecs.VSwitchSetType
 
@@ -133,6 +186,7 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo" {
+  name = "tf_test_foo"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "172.16.0.0/21"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -150,16 +204,19 @@ resource "alicloud_vpc" "foo" {
 }
 
 resource "alicloud_vswitch" "foo_0" {
+  name = "tf_test_foo_0"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "172.16.0.0/24"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 resource "alicloud_vswitch" "foo_1" {
+  name = "tf_test_foo_1"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "172.16.1.0/24"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
 }
 resource "alicloud_vswitch" "foo_2" {
+  name = "tf_test_foo_2"
   vpc_id = "${alicloud_vpc.foo.id}"
   cidr_block = "172.16.2.0/24"
   availability_zone = "${data.alicloud_zones.default.zones.0.id}"
@@ -0,0 +1,254 @@
+package alicloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/denverdino/aliyungo/util"
+)
+
+// The number of times a throttled or failed API call is retried by default.
+const DefaultMaxRetries = 5
+
+// The delay before the first retry. It doubles on every attempt, up to the max one.
+const (
+	DefaultRetryBaseDelay = 1 * time.Second
+	MaxRetryDelay         = 30 * time.Second
+)
+
+// IsRetryableError reports whether the error returned by an API call is a transient one.
+func IsRetryableError(err error) bool {
+	category := ErrorCategoryOf(err)
+	return category == ErrorCategoryThrottled || category == ErrorCategoryRetryable
+}
+
+// retryBackoff returns the delay before the retry attempt, starting from 0.
+// It grows exponentially from the base delay, and a random jitter spreads out the calls
+// of the concurrent resources which are throttled at the same time.
+func retryBackoff(attempt int, base time.Duration) time.Duration {
+	delay := MaxRetryDelay
+	if attempt < 16 {
+		if d := base << uint(attempt); d > 0 && d < MaxRetryDelay {
+			delay = d
+		}
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// Like the API logging and the rate limits, the API calls are retried by the default transport of net/http, so every
+// call of the SDK clients is retried in one place, including the ones made by the waiters of the SDKs. A call is signed
+// again before it is retried, since Alibaba Cloud rejects a signature nonce which it has seen before.
+
+var installApiRetriesOnce sync.Once
+
+// installApiRetries makes the API calls which fail with a retryable error be retried with exponential backoff.
+// It is installed over the rate limits, so every attempt waits for the limiter and is logged.
+func installApiRetries() {
+	installApiRetriesOnce.Do(func() {
+		http.DefaultTransport = &apiRetryTransport{transport: http.DefaultTransport, retries: apiRetries}
+	})
+}
+
+// apiRetries holds the retry policies of all of the provider configurations.
+var apiRetries = newApiRetryRegistry()
+
+type apiRetryPolicy struct {
+	secretKey  string
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+type apiRetryRegistry struct {
+	lock sync.Mutex
+
+	// The policies by the access key, which signs the requests to be retried by the secret key.
+	policies map[string]apiRetryPolicy
+}
+
+func newApiRetryRegistry() *apiRetryRegistry {
+	return &apiRetryRegistry{policies: make(map[string]apiRetryPolicy)}
+}
+
+// register sets up the retries of the calls signed by the access key of the config. It is called again with the
+// renewed credentials, since the temporary ones come with a new access key.
+func (r *apiRetryRegistry) register(c Config) {
+	base := c.retryBaseDelay
+	if base == 0 {
+		base = DefaultRetryBaseDelay
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.policies[c.AccessKey] = apiRetryPolicy{
+		secretKey:  c.SecretKey,
+		maxRetries: c.MaxRetries,
+		baseDelay:  base,
+	}
+}
+
+// policy returns the retry policy of the request, or false if it isn't signed by a registered access key.
+func (r *apiRetryRegistry) policy(req *http.Request) (apiRetryPolicy, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	policy, ok := r.policies[apiRequestAccessKey(req)]
+	return policy, ok
+}
+
+// apiRetryTransport sends the API calls again while they fail with a retryable error, at most max_retries times.
+// A request whose body can't be read again, e.g. an upload to OSS, is sent once, and so is a call making a new resource
+// without a ClientToken which got an ambiguous error.
+type apiRetryTransport struct {
+	transport http.RoundTripper
+	retries   *apiRetryRegistry
+}
+
+func (t *apiRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	policy, ok := t.retries.policy(req)
+	if !ok || policy.maxRetries <= 0 || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return t.transport.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.transport.RoundTrip(req)
+		if err != nil || attempt >= policy.maxRetries || resp.StatusCode < http.StatusBadRequest {
+			return resp, err
+		}
+		code, message := apiResponseError(resp)
+		category := classifyError(code, message)
+		if category != ErrorCategoryThrottled && category != ErrorCategoryRetryable {
+			return resp, nil
+		}
+		if category == ErrorCategoryRetryable && ambiguousErrorCodes[code] && !isIdempotentApiRequest(req) {
+			return resp, nil
+		}
+
+		delay := retryBackoff(attempt, policy.baseDelay)
+		log.Printf("[DEBUG] AliCloud API %s %s got a retryable error %s. Retrying it in %s (%d/%d).",
+			req.URL.Host, apiRequestAction(req), code, delay, attempt+1, policy.maxRetries)
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+
+		if req, err = signApiRequest(req, policy.secretKey); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ambiguousErrorCodes are the retryable errors which don't tell whether the call has taken effect.
+var ambiguousErrorCodes = map[string]bool{
+	UnknownError:      true,
+	DiskInternalError: true,
+}
+
+// nonIdempotentActionPrefixes are the prefixes of the actions which make a new resource each time they are called.
+var nonIdempotentActionPrefixes = []string{"Create", "Allocate", "Copy"}
+
+// isIdempotentApiRequest returns whether the call can be sent again after an ambiguous error.
+// A call making a new resource can only if it has a ClientToken, by which the API tells the calls sent again apart.
+func isIdempotentApiRequest(req *http.Request) bool {
+	action := apiRequestAction(req)
+	for _, prefix := range nonIdempotentActionPrefixes {
+		if strings.HasPrefix(action, prefix) {
+			return apiRequestParams(req).Get("ClientToken") != ""
+		}
+	}
+	return true
+}
+
+// apiResponseError returns the error code and message of the response, which are read from the JSON or XML body,
+// and leaves the body to be read again by the SDK.
+func apiResponseError(resp *http.Response) (code, message string) {
+	if resp.Body == nil {
+		return
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	var e struct {
+		Code    string
+		Message string
+	}
+	// A body which isn't an API error, e.g. of a gateway, leaves the code and message empty.
+	if strings.Contains(resp.Header.Get("Content-Type"), "xml") {
+		_ = xml.Unmarshal(body, &e)
+	} else {
+		_ = json.Unmarshal(body, &e)
+	}
+	return e.Code, e.Message
+}
+
+// signApiRequest returns a copy of the request to be sent again, which is signed with a new nonce and time.
+// An RPC style request is signed in its query, and a ROA style one, e.g. of the container service, in its headers.
+// The other ones, e.g. of OSS, are signed without a nonce and are sent again as they are.
+func signApiRequest(req *http.Request, secretKey string) (*http.Request, error) {
+	retry := req.WithContext(req.Context())
+	retry.Header = req.Header.Clone()
+	u := *req.URL
+	retry.URL = &u
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+
+	query := req.URL.Query()
+	switch auth := req.Header.Get("Authorization"); {
+	case query.Get("SignatureNonce") != "":
+		query.Del("Signature")
+		query.Set("SignatureNonce", util.CreateRandomString())
+		query.Set("Timestamp", util.NewISO6801Time(time.Now().UTC()).String())
+		// The RPC style APIs take the secret key followed by "&" as the key of the HMAC.
+		signature := util.CreateSignatureForRequest(req.Method, &query, secretKey+"&")
+		retry.URL.RawQuery = query.Encode() + "&Signature=" + url.QueryEscape(signature)
+	case strings.HasPrefix(auth, "acs "):
+		retry.Header.Set("Date", util.GetGMTime())
+		retry.Header["x-acs-signature-nonce"] = []string{util.CreateRandomString()}
+		signature := util.CreateSignature(roaStringToSign(retry), secretKey)
+		retry.Header.Set("Authorization", "acs "+apiRequestAccessKey(req)+":"+signature)
+	}
+	return retry, nil
+}
+
+// roaStringToSign returns the string to sign of a ROA style request, made of the standard headers,
+// the ones starting with x-acs- in order, and the path with the query.
+func roaStringToSign(req *http.Request) string {
+	var names []string
+	for name := range req.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-acs-") {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return strings.ToLower(names[i]) < strings.ToLower(names[j]) })
+
+	var canonicalized string
+	for _, name := range names {
+		canonicalized += strings.ToLower(name) + ":" + req.Header[name][0] + "\n"
+	}
+	return req.Method + "\n" + req.Header.Get("Accept") + "\n" + req.Header.Get("Content-Md5") + "\n" +
+		req.Header.Get("Content-Type") + "\n" + req.Header.Get("Date") + "\n" + canonicalized + req.URL.RequestURI()
+}
@@ -0,0 +1,358 @@
+package alicloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/denverdino/aliyungo/common"
+	"github.com/denverdino/aliyungo/util"
+)
+
+func testApiError(code string) error {
+	return &common.Error{
+		ErrorResponse: common.ErrorResponse{
+			Code:    code,
+			Message: fmt.Sprintf("The request failed with %s.", code),
+		},
+		StatusCode: 400,
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	cases := []struct {
+		err       error
+		retryable bool
+	}{
+		{testApiError(Throttling), true},
+		{testApiError(ThrottlingUser), true},
+		{testApiError(ThrottlingApi), true},
+		{testApiError(SystemBusy), true},
+		{testApiError(ServiceBusy), true},
+		{testApiError(KeyPairServiceUnavailable), true},
+		{testApiError(DiskInternalError), true},
+		{testApiError(UnknownError), true},
+		{testApiError(ServiceIsConfiguring), true},
+		{testApiError(BackendServerconfiguring), true},
+		{testApiError(TaskConflict), true},
+		{testApiError(DiskOperationConflict), true},
+		{oss.ServiceError{Code: SystemBusy, StatusCode: 503}, true},
+		{&oss.ServiceError{Code: SystemBusy, StatusCode: 503}, true},
+		{testApiError(InstanceNotFound), false},
+		{testApiError(InvalidParameter), false},
+		{testApiError(AliyunGoClientFailure), false},
+		{oss.ServiceError{Code: OssBucketNotFound, StatusCode: 404}, false},
+		{fmt.Errorf(Throttling), false},
+		{nil, false},
+	}
+
+	for _, c := range cases {
+		if retryable := IsRetryableError(c.err); retryable != c.retryable {
+			t.Fatalf("expected IsRetryableError(%#v) to be %t, got %t", c.err, c.retryable, retryable)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	base := 100 * time.Millisecond
+	for attempt := 0; attempt < 100; attempt++ {
+		expected := MaxRetryDelay
+		if attempt < 9 {
+			expected = base << uint(attempt)
+		}
+		for i := 0; i < 10; i++ {
+			delay := retryBackoff(attempt, base)
+			if delay < expected/2 || delay > expected {
+				t.Fatalf("expected the delay of attempt %d in [%s, %s], got %s", attempt, expected/2, expected, delay)
+			}
+		}
+	}
+}
+
+// testRetryServer answers the API calls with the error codes in order, and succeeds once they are used up.
+// It fails a request whose signature is wrong or whose nonce has been seen before, like Alibaba Cloud does.
+type testRetryServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	codes    []string
+	success  string
+	requests int
+	nonces   map[string]bool
+}
+
+func newTestRetryServer(success string, codes ...string) *testRetryServer {
+	s := &testRetryServer{codes: codes, success: success, nonces: make(map[string]bool)}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+func (s *testRetryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests++
+
+	w.Header().Set("Content-Type", "application/json")
+	nonce, valid := testCheckSignature(r)
+	if !valid || s.nonces[nonce] {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"RequestId": "test-request-id", "Code": "SignatureNonceUsed", "Message": "The nonce %s is used or the signature is wrong."}`, nonce)
+		return
+	}
+	s.nonces[nonce] = true
+
+	if s.requests <= len(s.codes) {
+		code := s.codes[s.requests-1]
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"RequestId": "test-request-id", "Code": %q, "Message": "The request failed with %s."}`, code, code)
+		return
+	}
+	w.Write([]byte(s.success))
+}
+
+// testCheckSignature returns the nonce of an RPC or ROA style request, and whether it is signed by SecretKey.
+func testCheckSignature(r *http.Request) (string, bool) {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "acs ") {
+		expected := "acs AccessKey:" + util.CreateSignature(roaStringToSign(r), "SecretKey")
+		return r.Header.Get("x-acs-signature-nonce"), auth == expected
+	}
+
+	query := r.URL.Query()
+	signature := query.Get("Signature")
+	query.Del("Signature")
+	return query.Get("SignatureNonce"), signature == util.CreateSignatureForRequest(r.Method, &query, "SecretKey&")
+}
+
+func testRetryRequest(t *testing.T, endpoint string) *http.Request {
+	return testRetryActionRequest(t, endpoint, url.Values{"Action": {"DescribeRegions"}})
+}
+
+func testRetryActionRequest(t *testing.T, endpoint string, params url.Values) *http.Request {
+	query := url.Values{}
+	for name, values := range params {
+		query[name] = values
+	}
+	query.Set("AccessKeyId", "AccessKey")
+	query.Set("SignatureNonce", util.CreateRandomString())
+	query.Set("Timestamp", util.NewISO6801Time(time.Now().UTC()).String())
+	signature := util.CreateSignatureForRequest(http.MethodGet, &query, "SecretKey&")
+	req, err := http.NewRequest(http.MethodGet, endpoint+"?"+query.Encode()+"&Signature="+url.QueryEscape(signature), nil)
+	if err != nil {
+		t.Fatalf("building the request got an error: %#v", err)
+	}
+	return req
+}
+
+func TestApiRetryTransport(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxRetries int
+		codes      []string
+		requests   int
+		status     int
+	}{
+		{
+			name:       "success",
+			maxRetries: 3,
+			requests:   1,
+			status:     http.StatusOK,
+		},
+		{
+			name:       "throttled and then succeeded",
+			maxRetries: 3,
+			codes:      []string{Throttling, ServiceIsConfiguring},
+			requests:   3,
+			status:     http.StatusOK,
+		},
+		{
+			name:       "throttled more than max retries",
+			maxRetries: 2,
+			codes:      []string{Throttling, Throttling, Throttling},
+			requests:   3,
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "retry disabled",
+			maxRetries: 0,
+			codes:      []string{SystemBusy},
+			requests:   1,
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "non-retryable error",
+			maxRetries: 3,
+			codes:      []string{InvalidParameter},
+			requests:   1,
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "non-retryable error after retries",
+			maxRetries: 3,
+			codes:      []string{TaskConflict, InstanceNotFound},
+			requests:   2,
+			status:     http.StatusBadRequest,
+		},
+	}
+
+	for _, c := range cases {
+		server := newTestRetryServer(`{"RequestId": "test-request-id"}`, c.codes...)
+		retries := newApiRetryRegistry()
+		retries.register(Config{AccessKey: "AccessKey", SecretKey: "SecretKey", MaxRetries: c.maxRetries, retryBaseDelay: time.Millisecond})
+		transport := &apiRetryTransport{transport: &http.Transport{}, retries: retries}
+
+		resp, err := transport.RoundTrip(testRetryRequest(t, server.URL))
+		server.Close()
+		if err != nil {
+			t.Fatalf("%s: sending the request got an error: %#v", c.name, err)
+		}
+		code, _ := apiResponseError(resp)
+		resp.Body.Close()
+		if server.requests != c.requests {
+			t.Fatalf("%s: expected %d requests, got %d", c.name, c.requests, server.requests)
+		}
+		if resp.StatusCode != c.status {
+			t.Fatalf("%s: expected status %d, got %d %s", c.name, c.status, resp.StatusCode, code)
+		}
+		if c.status != http.StatusOK && code != c.codes[c.requests-1] {
+			t.Fatalf("%s: expected the last error %s, got %s", c.name, c.codes[c.requests-1], code)
+		}
+	}
+}
+
+func TestApiRetryTransport_create(t *testing.T) {
+	cases := []struct {
+		name     string
+		params   url.Values
+		code     string
+		requests int
+	}{
+		{
+			name:     "unknown error of a describe call",
+			params:   url.Values{"Action": {"DescribeVpcs"}},
+			code:     UnknownError,
+			requests: 2,
+		},
+		{
+			name:     "unknown error of a create call",
+			params:   url.Values{"Action": {"CreateVpc"}},
+			code:     UnknownError,
+			requests: 1,
+		},
+		{
+			name:     "internal error of an allocate call",
+			params:   url.Values{"Action": {"AllocateEipAddress"}},
+			code:     DiskInternalError,
+			requests: 1,
+		},
+		{
+			name:     "unknown error of a create call with a client token",
+			params:   url.Values{"Action": {"CreateInstance"}, "ClientToken": {"test-client-token"}},
+			code:     UnknownError,
+			requests: 2,
+		},
+		{
+			name:     "throttled create call",
+			params:   url.Values{"Action": {"CreateSnapshot"}},
+			code:     Throttling,
+			requests: 2,
+		},
+	}
+
+	for _, c := range cases {
+		server := newTestRetryServer(`{"RequestId": "test-request-id"}`, c.code)
+		retries := newApiRetryRegistry()
+		retries.register(Config{AccessKey: "AccessKey", SecretKey: "SecretKey", MaxRetries: 3, retryBaseDelay: time.Millisecond})
+		transport := &apiRetryTransport{transport: &http.Transport{}, retries: retries}
+
+		resp, err := transport.RoundTrip(testRetryActionRequest(t, server.URL, c.params))
+		server.Close()
+		if err != nil {
+			t.Fatalf("%s: sending the request got an error: %#v", c.name, err)
+		}
+		resp.Body.Close()
+		if server.requests != c.requests {
+			t.Fatalf("%s: expected %d requests, got %d", c.name, c.requests, server.requests)
+		}
+	}
+}
+
+func TestApiRetryTransport_canceled(t *testing.T) {
+	server := newTestRetryServer(`{"RequestId": "test-request-id"}`, Throttling)
+	defer server.Close()
+	retries := newApiRetryRegistry()
+	retries.register(Config{AccessKey: "AccessKey", SecretKey: "SecretKey", MaxRetries: 3, retryBaseDelay: time.Minute})
+	transport := &apiRetryTransport{transport: &http.Transport{}, retries: retries}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := transport.RoundTrip(testRetryRequest(t, server.URL).WithContext(ctx)); err != context.DeadlineExceeded {
+		t.Fatalf("expected the retry to be canceled, got %#v", err)
+	}
+}
+
+func TestApiRetryTransport_unknownAccessKey(t *testing.T) {
+	server := newTestRetryServer(`{"RequestId": "test-request-id"}`, Throttling)
+	defer server.Close()
+	retries := newApiRetryRegistry()
+	retries.register(Config{AccessKey: "OtherAccessKey", SecretKey: "SecretKey", MaxRetries: 3, retryBaseDelay: time.Millisecond})
+	transport := &apiRetryTransport{transport: &http.Transport{}, retries: retries}
+
+	resp, err := transport.RoundTrip(testRetryRequest(t, server.URL))
+	if err != nil {
+		t.Fatalf("sending the request got an error: %#v", err)
+	}
+	resp.Body.Close()
+	if server.requests != 1 {
+		t.Fatalf("expected the request of an unknown access key not to be retried, got %d requests", server.requests)
+	}
+}
+
+func TestApiRetryTransport_sdkClients(t *testing.T) {
+	rpc := newTestRetryServer(`{"RequestId": "test-request-id", "Regions": {"Region": [{"RegionId": "cn-beijing", "LocalName": "Beijing"}]}}`,
+		Throttling, SystemBusy)
+	defer rpc.Close()
+	roa := newTestRetryServer(`[{"cluster_id": "c-test", "name": "tf-testAccCluster"}]`, Throttling, ServiceBusy)
+	defer roa.Close()
+
+	config := Config{
+		AccessKey:      "AccessKey",
+		SecretKey:      "SecretKey",
+		Region:         common.Beijing,
+		Endpoints:      map[ServiceCode]string{EcsCode: rpc.URL, CsCode: roa.URL},
+		MaxRetries:     DefaultMaxRetries,
+		retryBaseDelay: time.Millisecond,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client got an error: %#v", err)
+	}
+	defer client.Close()
+
+	regions, err := client.ecsConn().DescribeRegions()
+	if err != nil {
+		t.Fatalf("describing regions got an error: %#v", err)
+	}
+	if len(regions) != 1 || regions[0].RegionId != common.Beijing {
+		t.Fatalf("expected region %s, got %#v", common.Beijing, regions)
+	}
+	if rpc.requests != 3 {
+		t.Fatalf("expected 3 requests of the RPC style API, got %d", rpc.requests)
+	}
+
+	clusters, err := client.csConn().DescribeClusters("")
+	if err != nil {
+		t.Fatalf("describing clusters got an error: %#v", err)
+	}
+	if len(clusters) != 1 || clusters[0].ClusterID != "c-test" {
+		t.Fatalf("expected cluster c-test, got %#v", clusters)
+	}
+	if roa.requests != 3 {
+		t.Fatalf("expected 3 requests of the ROA style API, got %d", roa.requests)
+	}
+}
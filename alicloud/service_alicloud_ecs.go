@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/denverdino/aliyungo/common"
@@ -25,7 +28,7 @@ func (client *AliyunClient) DescribeImage(imageId string) (*ecs.ImageType, error
 	var allImages []ecs.ImageType
 
 	for {
-		images, _, err := client.ecsconn.DescribeImages(&args)
+		images, _, err := client.ecsConn().DescribeImages(&args)
 		if err != nil {
 			break
 		}
@@ -61,7 +64,7 @@ func (client *AliyunClient) DescribeImage(imageId string) (*ecs.ImageType, error
 
 // DescribeZone validate zoneId is valid in region
 func (client *AliyunClient) DescribeZone(zoneID string) (*ecs.ZoneType, error) {
-	zones, err := client.ecsconn.DescribeZones(client.Region)
+	zones, err := client.describeZones(client.Region)
 	if err != nil {
 		return nil, fmt.Errorf("error to list zones not found")
 	}
@@ -89,7 +92,7 @@ func (client *AliyunClient) DescribeZone(zoneID string) (*ecs.ZoneType, error) {
 
 // return multiIZ list of current region
 func (client *AliyunClient) DescribeMultiIZByRegion() (izs []string, err error) {
-	resp, err := client.rdsconn.DescribeRegions()
+	resp, err := client.rdsConn().DescribeRegions()
 	if err != nil {
 		return nil, fmt.Errorf("error to list regions not found")
 	}
@@ -116,7 +119,7 @@ func (client *AliyunClient) QueryInstancesByIds(ids []string) (instances []ecs.I
 		InstanceIds: string(idsStr),
 	}
 
-	instances, _, errs := client.ecsconn.DescribeInstances(&args)
+	instances, _, errs := client.ecsConn().DescribeInstances(&args)
 
 	if errs != nil {
 		return nil, errs
@@ -146,7 +149,7 @@ func (client *AliyunClient) QueryInstanceSystemDisk(id string) (disk *ecs.DiskIt
 		InstanceId: string(id),
 		DiskType:   ecs.DiskTypeAllSystem,
 	}
-	disks, _, err := client.ecsconn.DescribeDisks(&args)
+	disks, _, err := client.ecsConn().DescribeDisks(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +160,33 @@ func (client *AliyunClient) QueryInstanceSystemDisk(id string) (disk *ecs.DiskIt
 	return &disks[0], nil
 }
 
+// QueryInstanceDataDisks returns the data disks attached to the instance, in the order of their devices.
+func (client *AliyunClient) QueryInstanceDataDisks(id string) ([]ecs.DiskItemType, error) {
+	args := ecs.DescribeDisksArgs{
+		RegionId:   client.Region,
+		InstanceId: id,
+		DiskType:   ecs.DiskTypeAllData,
+		Pagination: getPagination(1, 50),
+	}
+	var disks []ecs.DiskItemType
+	for {
+		page, _, err := client.ecsConn().DescribeDisks(&args)
+		if err != nil {
+			return nil, err
+		}
+		disks = append(disks, page...)
+		if len(page) < args.PageSize {
+			break
+		}
+		args.PageNumber += 1
+	}
+
+	sort.Slice(disks, func(i, j int) bool {
+		return disks[i].Device < disks[j].Device
+	})
+	return disks, nil
+}
+
 // ResourceAvailable check resource available for zone
 func (client *AliyunClient) ResourceAvailable(zone *ecs.ZoneType, resourceType ecs.ResourceType) error {
 	available := false
@@ -188,7 +218,7 @@ func (client *AliyunClient) DiskAvailable(zone *ecs.ZoneType, diskCategory ecs.D
 // todo: support syc
 func (client *AliyunClient) JoinSecurityGroups(instanceId string, securityGroupIds []string) error {
 	for _, sid := range securityGroupIds {
-		err := client.ecsconn.JoinSecurityGroup(instanceId, sid)
+		err := client.ecsConn().JoinSecurityGroup(instanceId, sid)
 		if err != nil {
 			e, _ := err.(*common.Error)
 			if e.ErrorResponse.Code != InvalidInstanceIdAlreadyExists {
@@ -202,7 +232,7 @@ func (client *AliyunClient) JoinSecurityGroups(instanceId string, securityGroupI
 
 func (client *AliyunClient) LeaveSecurityGroups(instanceId string, securityGroupIds []string) error {
 	for _, sid := range securityGroupIds {
-		err := client.ecsconn.LeaveSecurityGroup(instanceId, sid)
+		err := client.ecsConn().LeaveSecurityGroup(instanceId, sid)
 		if err != nil {
 			e, _ := err.(*common.Error)
 			if e.ErrorResponse.Code != InvalidSecurityGroupIdNotFound {
@@ -221,11 +251,12 @@ func (client *AliyunClient) DescribeSecurity(securityGroupId string) (*ecs.Descr
 		SecurityGroupId: securityGroupId,
 	}
 
-	return client.ecsconn.DescribeSecurityGroupAttribute(args)
+	group, err := client.ecsConn().DescribeSecurityGroupAttribute(args)
+	return group, err
 }
 
 func (client *AliyunClient) DescribeSecurityGroupRule(groupId, direction, ipProtocol, portRange, nicType, cidr_ip, policy string, priority int) (*ecs.PermissionType, error) {
-	rules, err := client.ecsconn.DescribeSecurityGroupAttribute(&ecs.DescribeSecurityGroupAttributeArgs{
+	rules, err := client.ecsConn().DescribeSecurityGroupAttribute(&ecs.DescribeSecurityGroupAttributeArgs{
 		RegionId:        client.Region,
 		SecurityGroupId: groupId,
 		Direction:       ecs.Direction(direction),
@@ -260,19 +291,18 @@ func (client *AliyunClient) DescribeSecurityGroupRule(groupId, direction, ipProt
 
 func (client *AliyunClient) RevokeSecurityGroup(args *ecs.RevokeSecurityGroupArgs) error {
 	//when the rule is not exist, api will return success(200)
-	return client.ecsconn.RevokeSecurityGroup(args)
+	return client.ecsConn().RevokeSecurityGroup(args)
 }
 
 func (client *AliyunClient) RevokeSecurityGroupEgress(args *ecs.RevokeSecurityGroupEgressArgs) error {
 	//when the rule is not exist, api will return success(200)
-	return client.ecsconn.RevokeSecurityGroupEgress(args)
+	return client.ecsConn().RevokeSecurityGroupEgress(args)
 }
 
 func (client *AliyunClient) CheckParameterValidity(d *schema.ResourceData, meta interface{}) (map[ResourceKeyType]interface{}, error) {
 	// Before creating resources, check input parameters validity according available zone.
 	// If availability zone is nil, it will return all of supported resources in the current.
-	conn := meta.(*AliyunClient).ecsconn
-	zones, err := conn.DescribeZones(getRegion(d, meta))
+	zones, err := client.describeZones(getRegion(d, meta))
 	if err != nil {
 		return nil, fmt.Errorf("Error DescribeZone: %#v", err)
 	}
@@ -511,7 +541,7 @@ func (client *AliyunClient) FetchSpecifiedInstanceTypeFamily(regionId common.Reg
 	// Describe specified series instance type families
 	mapOutdatedInstanceFamilies := make(map[string]ecs.InstanceTypeFamily)
 	mapUpgradedInstanceFamilies := make(map[string]ecs.InstanceTypeFamily)
-	response, err := client.ecsconn.DescribeInstanceTypeFamilies(&ecs.DescribeInstanceTypeFamiliesArgs{
+	response, err := client.ecsConn().DescribeInstanceTypeFamilies(&ecs.DescribeInstanceTypeFamiliesArgs{
 		RegionId: regionId,
 	})
 	if err != nil {
@@ -561,7 +591,7 @@ func (client *AliyunClient) FetchSpecifiedInstanceTypeFamily(regionId common.Reg
 
 func (client *AliyunClient) FetchSpecifiedInstanceTypesByFamily(zoneId, instanceTypeFamily string, all_zones []ecs.ZoneType) (map[string]ecs.InstanceTypeItemType, error) {
 	// Describe all instance types of specified families
-	types, err := client.ecsconn.DescribeInstanceTypesNew(&ecs.DescribeInstanceTypesArgs{
+	types, err := client.ecsConn().DescribeInstanceTypesNew(&ecs.DescribeInstanceTypesArgs{
 		InstanceTypeFamily: instanceTypeFamily,
 	})
 	if err != nil {
@@ -594,6 +624,35 @@ func (client *AliyunClient) FetchSpecifiedInstanceTypesByFamily(zoneId, instance
 	return instanceTypes, nil
 }
 
+// CheckInstanceTypeInZone checks the instance type is available in the zone, before an instance is changed to it.
+func (client *AliyunClient) CheckInstanceTypeInZone(zoneId, instanceType string) error {
+	parts := strings.Split(instanceType, DOT_SEPARATED)
+	if len(parts) < 3 {
+		return fmt.Errorf("Invalid instance_type: %s. Please modify it and try again.", instanceType)
+	}
+	family := parts[0] + DOT_SEPARATED + parts[1]
+
+	zones, err := client.describeZones(client.Region)
+	if err != nil {
+		return err
+	}
+	instanceTypes, err := client.FetchSpecifiedInstanceTypesByFamily(zoneId, family, zones)
+	if err != nil {
+		return err
+	}
+	if _, ok := instanceTypes[instanceType]; ok {
+		return nil
+	}
+
+	var validInstanceTypes []string
+	for key := range instanceTypes {
+		validInstanceTypes = append(validInstanceTypes, key)
+	}
+	sort.Strings(validInstanceTypes)
+	return fmt.Errorf("Instance type %s is not supported in the availability zone %s. Expected instance types of family %s: %s.",
+		instanceType, zoneId, family, strings.Join(validInstanceTypes, ", "))
+}
+
 func getExpectInstanceTypesAndFormatOut(zoneId, instanceTypeFamily string, regionId common.Region, mapInstanceFamilies map[string]ecs.InstanceTypeFamily) error {
 	var validFamilies []string
 
@@ -616,7 +675,7 @@ func (client *AliyunClient) QueryInstancesWithKeyPair(region common.Region, inst
 	var instance_ids []interface{}
 	var instanceList []ecs.InstanceAttributesType
 
-	conn := client.ecsconn
+	conn := client.ecsConn()
 	args := &ecs.DescribeInstancesArgs{
 		RegionId: region,
 	}
@@ -643,3 +702,107 @@ func (client *AliyunClient) QueryInstancesWithKeyPair(region common.Region, inst
 	}
 	return instance_ids, instanceList, nil
 }
+
+func (client *AliyunClient) DescribeSnapshot(snapshotId string) (*ecs.SnapshotType, error) {
+	snapshots, _, err := client.ecsConn().DescribeSnapshots(&ecs.DescribeSnapshotsArgs{
+		RegionId:    client.Region,
+		SnapshotIds: []string{snapshotId},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) < 1 {
+		return nil, GetNotFoundErrorFromString(fmt.Sprintf("Snapshot %s not found", snapshotId))
+	}
+	return &snapshots[0], nil
+}
+
+func (client *AliyunClient) DescribeAutoSnapshotPolicy(policyId string) (*AutoSnapshotPolicyType, error) {
+	args := url.Values{}
+	args.Set("RegionId", string(client.Region))
+	args.Set("AutoSnapshotPolicyId", policyId)
+
+	var response DescribeAutoSnapshotPolicyExResponse
+	err := client.ecsConn().Invoke("DescribeAutoSnapshotPolicyEx", args, &response)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range response.AutoSnapshotPolicies.AutoSnapshotPolicy {
+		if policy.AutoSnapshotPolicyId == policyId {
+			return &policy, nil
+		}
+	}
+	return nil, GetNotFoundErrorFromString(fmt.Sprintf("Automatic snapshot policy %s not found", policyId))
+}
+
+// DescribeAutoSnapshotPolicyDisks returns the IDs of the disks which the automatic snapshot policy is applied to.
+func (client *AliyunClient) DescribeAutoSnapshotPolicyDisks(policyId string) ([]string, error) {
+	args := url.Values{}
+	args.Set("RegionId", string(client.Region))
+	args.Set("AutoSnapshotPolicyId", policyId)
+	args.Set("PageSize", "50")
+
+	var diskIds []string
+	for page := 1; ; page++ {
+		args.Set("PageNumber", strconv.Itoa(page))
+		var response DescribeDiskAutoSnapshotPoliciesResponse
+		err := client.ecsConn().Invoke("DescribeDisks", args, &response)
+		if err != nil {
+			return nil, err
+		}
+		for _, disk := range response.Disks.Disk {
+			if disk.AutoSnapshotPolicyId == policyId {
+				diskIds = append(diskIds, disk.DiskId)
+			}
+		}
+		if response.NextPage() == nil {
+			break
+		}
+	}
+	return diskIds, nil
+}
+
+// The statuses of the images which DescribeImages returns when it's asked for all of them,
+// while it returns the available ones by default.
+const allImageStatuses = ecs.ImageStatus("Creating,Available,UnAvailable,CreateFailed")
+
+// QueryImageById returns the image in any status, or a not found error if it doesn't exist.
+func (client *AliyunClient) QueryImageById(imageId string) (*ecs.ImageType, error) {
+	images, _, err := client.ecsConn().DescribeImages(&ecs.DescribeImagesArgs{
+		RegionId: client.Region,
+		ImageId:  imageId,
+		Status:   allImageStatuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(images) < 1 {
+		return nil, GetNotFoundErrorFromString(fmt.Sprintf("Image %s not found", imageId))
+	}
+	return &images[0], nil
+}
+
+// DescribeImageShareAccounts returns the IDs of the accounts which the image is shared with.
+func (client *AliyunClient) DescribeImageShareAccounts(imageId string) ([]string, error) {
+	args := url.Values{}
+	args.Set("RegionId", string(client.Region))
+	args.Set("ImageId", imageId)
+	args.Set("PageSize", "50")
+
+	var accounts []string
+	for page := 1; ; page++ {
+		args.Set("PageNumber", strconv.Itoa(page))
+		var response ecs.ImageSharePermissionResponse
+		err := client.ecsConn().Invoke("DescribeImageSharePermission", args, &response)
+		if err != nil {
+			return nil, err
+		}
+		for _, account := range response.Accounts.Account {
+			accounts = append(accounts, account.AliyunId)
+		}
+		if len(response.Accounts.Account) < 50 {
+			break
+		}
+	}
+	return accounts, nil
+}
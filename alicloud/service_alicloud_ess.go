@@ -15,7 +15,7 @@ func (client *AliyunClient) DescribeScalingGroupById(sgId string) (*ess.ScalingG
 		ScalingGroupId: []string{sgId},
 	}
 
-	sgs, _, err := client.essconn.DescribeScalingGroups(&args)
+	sgs, _, err := client.essConn().DescribeScalingGroups(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +34,7 @@ func (client *AliyunClient) DescribeScalingConfigurationById(configId string) (*
 		ScalingConfigurationId: []string{configId},
 	}
 
-	cs, _, err := client.essconn.DescribeScalingConfigurations(&args)
+	cs, _, err := client.essConn().DescribeScalingConfigurations(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +52,7 @@ func (client *AliyunClient) ActiveScalingConfigurationById(sgId, configId string
 		ActiveScalingConfigurationId: configId,
 	}
 
-	_, err := client.essconn.ModifyScalingGroup(&args)
+	_, err := client.essConn().ModifyScalingGroup(&args)
 	return err
 }
 
@@ -78,7 +78,7 @@ func (client *AliyunClient) DescribeScalingRuleById(sgId, ruleId string) (*ess.S
 		ScalingRuleId:  []string{ruleId},
 	}
 
-	cs, _, err := client.essconn.DescribeScalingRules(&args)
+	cs, _, err := client.essConn().DescribeScalingRules(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +96,7 @@ func (client *AliyunClient) DeleteScalingRuleById(ruleId string) error {
 		ScalingRuleId: ruleId,
 	}
 
-	_, err := client.essconn.DeleteScalingRule(&args)
+	_, err := client.essConn().DeleteScalingRule(&args)
 	return err
 }
 
@@ -106,7 +106,7 @@ func (client *AliyunClient) DescribeScheduleById(scheduleId string) (*ess.Schedu
 		ScheduledTaskId: []string{scheduleId},
 	}
 
-	cs, _, err := client.essconn.DescribeScheduledTasks(&args)
+	cs, _, err := client.essConn().DescribeScheduledTasks(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -124,14 +124,14 @@ func (client *AliyunClient) DeleteScheduleById(scheduleId string) error {
 		ScheduledTaskId: scheduleId,
 	}
 
-	_, err := client.essconn.DeleteScheduledTask(&args)
+	_, err := client.essConn().DeleteScheduledTask(&args)
 	return err
 }
 
 func (client *AliyunClient) DeleteScalingGroupById(sgId string) error {
 	return resource.Retry(5*time.Minute, func() *resource.RetryError {
 
-		_, err := client.essconn.DeleteScalingGroup(&ess.DeleteScalingGroupArgs{
+		_, err := client.essConn().DeleteScalingGroup(&ess.DeleteScalingGroupArgs{
 			ScalingGroupId: sgId,
 			ForceDelete:    true,
 		})
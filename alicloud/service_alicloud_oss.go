@@ -6,7 +6,11 @@ import (
 
 func (client *AliyunClient) QueryOssBucketById(id string) (info *oss.BucketInfo, err error) {
 
-	bucket, err := client.ossconn.GetBucketInfo(id)
+	ossconn, err := client.ossConn()
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := ossconn.GetBucketInfo(id)
 	if err != nil {
 		return nil, err
 	}
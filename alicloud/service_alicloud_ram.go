@@ -136,7 +136,7 @@ func AssemblePolicyDocument(document []interface{}, version string) (string, err
 
 // Judge whether the role policy contains service "ecs.aliyuncs.com"
 func (client *AliyunClient) JudgeRolePolicyPrincipal(roleName string) error {
-	conn := client.ramconn
+	conn := client.ramConn()
 	resp, err := conn.GetRole(ram.RoleQueryRequest{RoleName: roleName})
 	if err != nil {
 		return fmt.Errorf("GetRole %s got an error: %#v", roleName, err)
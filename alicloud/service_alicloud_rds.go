@@ -28,7 +28,7 @@ func (client *AliyunClient) DescribeDBInstanceById(id string) (instance *rds.DBI
 	arrtArgs := rds.DescribeDBInstancesArgs{
 		DBInstanceId: id,
 	}
-	resp, err := client.rdsconn.DescribeDBInstanceAttribute(&arrtArgs)
+	resp, err := client.rdsConn().DescribeDBInstanceAttribute(&arrtArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +43,7 @@ func (client *AliyunClient) DescribeDBInstanceById(id string) (instance *rds.DBI
 }
 
 func (client *AliyunClient) DescribeDatabaseAccount(instanceId, accountName string) (ds *rds.DBInstanceAccount, err error) {
-	conn := client.rdsconn
+	conn := client.rdsConn()
 	resp, err := conn.DescribeAccounts(&rds.DescribeAccountsArgs{
 		DBInstanceId: instanceId,
 		AccountName:  accountName,
@@ -61,7 +61,7 @@ func (client *AliyunClient) DescribeDatabaseAccount(instanceId, accountName stri
 
 func (client *AliyunClient) DescribeDatabaseByName(instanceId, dbName string) (ds *rds.Database, err error) {
 
-	resp, err := client.rdsconn.DescribeDatabases(&rds.DescribeDatabasesArgs{
+	resp, err := client.rdsConn().DescribeDatabases(&rds.DescribeDatabasesArgs{
 		DBInstanceId: instanceId,
 		DBName:       dbName,
 	})
@@ -76,12 +76,12 @@ func (client *AliyunClient) DescribeDatabaseByName(instanceId, dbName string) (d
 }
 
 func (client *AliyunClient) AllocateDBPublicConnection(instanceId, prefix, port string) error {
-	conn := client.rdsconn
+	conn := client.rdsConn()
 	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
 		if _, err := conn.AllocateInstancePublicConnection(&rds.AllocateInstancePublicConnectionArgs{
 			DBInstanceId:           instanceId,
 			ConnectionStringPrefix: prefix,
-			Port: port,
+			Port:                   port,
 		}); err != nil {
 			if IsExceptedError(err, NetTypeExists) {
 				connection, err := client.DescribeDBInstanceNetInfoByIpType(instanceId, rds.Public)
@@ -90,7 +90,7 @@ func (client *AliyunClient) AllocateDBPublicConnection(instanceId, prefix, port
 				}
 				return resource.NonRetryableError(fmt.Errorf("The connection string with specified prefix %s has already existed. "+
 					"Please import it using ID '%s:%s' or specify a new 'connection_prefix' and try again.", prefix, instanceId, connection.ConnectionString))
-			} else if IsExceptedError(err, OperationDeniedDBInstanceStatus) {
+			} else if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Allocate db connection got an error: %#v.", err))
 			}
 
@@ -112,7 +112,7 @@ func (client *AliyunClient) AllocateDBPublicConnection(instanceId, prefix, port
 
 func (client *AliyunClient) DescribeDBInstanceNetInfos(instanceId string) ([]rds.DBInstanceNetInfo, error) {
 
-	resp, err := client.rdsconn.DescribeDBInstanceNetInfo(&rds.DescribeDBInstanceNetInfoArgs{
+	resp, err := client.rdsConn().DescribeDBInstanceNetInfo(&rds.DescribeDBInstanceNetInfoArgs{
 		DBInstanceId: instanceId,
 	})
 
@@ -158,8 +158,8 @@ func (client *AliyunClient) GrantAccountPrivilege(instanceId, account, dbName, p
 
 	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
 		ag := args
-		if _, err := client.rdsconn.GrantAccountPrivilege(&ag); err != nil {
-			if IsExceptedError(err, OperationDeniedDBInstanceStatus) {
+		if _, err := client.rdsConn().GrantAccountPrivilege(&ag); err != nil {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Grant DB %s account %s privilege got an error: %#v.", dbName, account, err))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Grant DB %s account %s privilege got an error: %#v.", dbName, account, err))
@@ -171,7 +171,7 @@ func (client *AliyunClient) GrantAccountPrivilege(instanceId, account, dbName, p
 		return err
 	}
 
-	if err := client.rdsconn.WaitForAccountPrivilege(instanceId, account, dbName, rds.AccountPrivilege(privilege), 200); err != nil {
+	if err := client.rdsConn().WaitForAccountPrivilege(instanceId, account, dbName, rds.AccountPrivilege(privilege), 200); err != nil {
 		return fmt.Errorf("Wait for grantting DB %s account %s privilege got an error: %#v.", dbName, account, err)
 	}
 
@@ -187,8 +187,8 @@ func (client *AliyunClient) RevokeAccountPrivilege(instanceId, account, dbName s
 
 	err := resource.Retry(3*time.Minute, func() *resource.RetryError {
 		ag := args
-		if err := client.rdsconn.RevokeAccountPrivilege(&ag); err != nil {
-			if IsExceptedError(err, OperationDeniedDBInstanceStatus) {
+		if err := client.rdsConn().RevokeAccountPrivilege(&ag); err != nil {
+			if IsConflictError(err) {
 				return resource.RetryableError(fmt.Errorf("Revoke DB %s account %s privilege got an error: %#v.", dbName, account, err))
 			}
 			return resource.NonRetryableError(fmt.Errorf("Revoke DB %s account %s privilege got an error: %#v.", dbName, account, err))
@@ -200,7 +200,7 @@ func (client *AliyunClient) RevokeAccountPrivilege(instanceId, account, dbName s
 		return err
 	}
 
-	if err := client.rdsconn.WaitForAccountPrivilegeRevoked(instanceId, account, dbName, 200); err != nil {
+	if err := client.rdsConn().WaitForAccountPrivilegeRevoked(instanceId, account, dbName, 200); err != nil {
 		return fmt.Errorf("Wait for revoking DB %s account %s privilege got an error: %#v.", dbName, account, err)
 	}
 
@@ -208,7 +208,7 @@ func (client *AliyunClient) RevokeAccountPrivilege(instanceId, account, dbName s
 }
 
 func (client *AliyunClient) ReleaseDBPublicConnection(instanceId, connection string) error {
-	conn := client.rdsconn
+	conn := client.rdsConn()
 
 	if err := conn.ReleaseInstancePublicConnection(&rds.ReleaseInstancePublicConnectionArgs{
 		DBInstanceId:            instanceId,
@@ -220,12 +220,12 @@ func (client *AliyunClient) ReleaseDBPublicConnection(instanceId, connection str
 }
 
 func (client *AliyunClient) SwitchDBInstanceNetType(instanceId, prefix string, port int, vswitchId string) error {
-	conn := client.rdsconn
+	conn := client.rdsConn()
 
 	if err := conn.SwitchDBInstanceNetType(&rds.SwitchDBInstanceNetTypeArgs{
 		DBInstanceId:           instanceId,
 		ConnectionStringPrefix: prefix,
-		Port: port,
+		Port:                   port,
 	}); err != nil {
 		return err
 	}
@@ -253,11 +253,11 @@ func (client *AliyunClient) ConfigDBBackup(instanceId, backupTime, backupPeriod
 		BackupPolicy: bargs,
 	}
 
-	if _, err := client.rdsconn.ModifyBackupPolicy(&args); err != nil {
+	if _, err := client.rdsConn().ModifyBackupPolicy(&args); err != nil {
 		return err
 	}
 
-	if err := client.rdsconn.WaitForInstance(instanceId, rds.Running, 600); err != nil {
+	if err := client.rdsConn().WaitForInstance(instanceId, rds.Running, 600); err != nil {
 		return err
 	}
 	return nil
@@ -269,11 +269,11 @@ func (client *AliyunClient) ModifyDBSecurityIps(instanceId, ips string) error {
 		SecurityIps:  ips,
 	}
 
-	if _, err := client.rdsconn.ModifySecurityIps(&args); err != nil {
+	if _, err := client.rdsConn().ModifySecurityIps(&args); err != nil {
 		return err
 	}
 
-	if err := client.rdsconn.WaitForInstance(instanceId, rds.Running, 600); err != nil {
+	if err := client.rdsConn().WaitForInstance(instanceId, rds.Running, 600); err != nil {
 		return err
 	}
 	return nil
@@ -284,7 +284,7 @@ func (client *AliyunClient) DescribeDBSecurityIps(instanceId string) (ips []rds.
 		DBInstanceId: instanceId,
 	}
 
-	resp, err := client.rdsconn.DescribeDBInstanceIPs(&args)
+	resp, err := client.rdsConn().DescribeDBInstanceIPs(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -332,7 +332,7 @@ func (client *AliyunClient) GetSecurityIps(instanceId string, securityIps interf
 }
 
 func (client *AliyunClient) ModifyDBClassStorage(instanceId, class, storage string) error {
-	conn := client.rdsconn
+	conn := client.rdsConn()
 	args := rds.ModifyDBInstanceSpecArgs{
 		DBInstanceId:      instanceId,
 		PayType:           rds.Postpaid,
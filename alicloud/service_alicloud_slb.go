@@ -6,7 +6,7 @@ import (
 
 func (client *AliyunClient) DescribeLoadBalancerAttribute(slbId string) (*slb.LoadBalancerType, error) {
 
-	loadBalancer, err := client.slbconn.NewDescribeLoadBalancerAttribute(&slb.NewDescribeLoadBalancerAttributeArgs{
+	loadBalancer, err := client.slbConn().NewDescribeLoadBalancerAttribute(&slb.NewDescribeLoadBalancerAttributeArgs{
 		RegionId:       client.Region,
 		LoadBalancerId: slbId,
 	})
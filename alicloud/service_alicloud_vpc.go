@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"strings"
+	"time"
 
 	"github.com/denverdino/aliyungo/common"
 	"github.com/denverdino/aliyungo/ecs"
@@ -9,6 +10,8 @@ import (
 
 const Negative = ecs.Spec("Negative")
 
+const NatGatewayStatusAvailable = "Available"
+
 func (client *AliyunClient) DescribeEipAddress(allocationId string) (*ecs.EipAddressSetType, error) {
 
 	args := ecs.DescribeEipAddressesArgs{
@@ -16,7 +19,7 @@ func (client *AliyunClient) DescribeEipAddress(allocationId string) (*ecs.EipAdd
 		AllocationId: allocationId,
 	}
 
-	eips, _, err := client.ecsconn.DescribeEipAddresses(&args)
+	eips, _, err := client.ecsConn().DescribeEipAddresses(&args)
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +37,7 @@ func (client *AliyunClient) DescribeNatGateway(natGatewayId string) (*ecs.NatGat
 		NatGatewayId: natGatewayId,
 	}
 
-	natGateways, _, err := client.vpcconn.DescribeNatGateways(args)
+	natGateways, _, err := client.vpcConn().DescribeNatGateways(args)
 	//fmt.Println("natGateways %#v", natGateways)
 	if err != nil {
 		return nil, err
@@ -47,13 +50,35 @@ func (client *AliyunClient) DescribeNatGateway(natGatewayId string) (*ecs.NatGat
 	return &natGateways[0], nil
 }
 
+// WaitForNatGateway waits for the nat gateway to reach the status, for at most timeout seconds.
+func (client *AliyunClient) WaitForNatGateway(natGatewayId string, status string, timeout int) error {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	for {
+		natGateway, err := client.DescribeNatGateway(natGatewayId)
+		if err != nil {
+			return err
+		}
+		if natGateway.Status == status {
+			break
+		}
+		timeout = timeout - ecs.DefaultWaitForInterval
+		if timeout <= 0 {
+			return common.GetClientErrorFromString("Timeout")
+		}
+		time.Sleep(ecs.DefaultWaitForInterval * time.Second)
+	}
+	return nil
+}
+
 func (client *AliyunClient) DescribeVpc(vpcId string) (*ecs.VpcSetType, error) {
 	args := ecs.DescribeVpcsArgs{
 		RegionId: client.Region,
 		VpcId:    vpcId,
 	}
 
-	vpcs, _, err := client.ecsconn.DescribeVpcs(&args)
+	vpcs, _, err := client.ecsConn().DescribeVpcs(&args)
 	if err != nil {
 		if NotFoundError(err) {
 			return nil, nil
@@ -77,7 +102,7 @@ func (client *AliyunClient) DescribeSnatEntry(snatTableId string, snatEntryId st
 		SnatTableId: snatTableId,
 	}
 
-	snatEntries, _, err := client.vpcconn.DescribeSnatTableEntries(args)
+	snatEntries, _, err := client.vpcConn().DescribeSnatTableEntries(args)
 
 	//this special deal cause the DescribeSnatEntry can't find the records would be throw "cant find the snatTable error"
 	//so judge the snatEntries length priority
@@ -112,7 +137,7 @@ func (client *AliyunClient) DescribeForwardEntry(forwardTableId string, forwardE
 		ForwardTableId: forwardTableId,
 	}
 
-	forwardEntries, _, err := client.vpcconn.DescribeForwardTableEntries(args)
+	forwardEntries, _, err := client.vpcConn().DescribeForwardTableEntries(args)
 	//this special deal cause the DescribeSnatEntry can't find the records would be throw "cant find the snatTable error"
 	//so judge the snatEntries length priority
 	if len(forwardEntries) == 0 {
@@ -140,7 +165,7 @@ func (client *AliyunClient) DescribeForwardEntry(forwardTableId string, forwardE
 
 // describe vswitch by param filters
 func (client *AliyunClient) QueryVswitches(args *ecs.DescribeVSwitchesArgs) (vswitches []ecs.VSwitchSetType, err error) {
-	vsws, _, err := client.ecsconn.DescribeVSwitches(args)
+	vsws, _, err := client.ecsConn().DescribeVSwitches(args)
 	if err != nil {
 		if NotFoundError(err) {
 			return nil, nil
@@ -169,7 +194,7 @@ func (client *AliyunClient) QueryVswitchById(vpcId, vswitchId string) (vsw *ecs.
 }
 
 func (client *AliyunClient) QueryRouteTables(args *ecs.DescribeRouteTablesArgs) (routeTables []ecs.RouteTableSetType, err error) {
-	rts, _, err := client.ecsconn.DescribeRouteTables(args)
+	rts, _, err := client.ecsConn().DescribeRouteTables(args)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +234,7 @@ func (client *AliyunClient) QueryRouteEntry(routeTableId, cidrBlock, nextHopType
 
 func (client *AliyunClient) GetVpcIdByVSwitchId(vswitchId string) (vpcId string, err error) {
 
-	vs, _, err := client.ecsconn.DescribeVpcs(&ecs.DescribeVpcsArgs{
+	vs, _, err := client.ecsConn().DescribeVpcs(&ecs.DescribeVpcsArgs{
 		RegionId: client.Region,
 	})
 	if err != nil {
@@ -0,0 +1,150 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/mitchellh/go-homedir"
+)
+
+// The default shared credentials file is the one written by the aliyun CLI.
+const DefaultSharedCredentialsFile = "~/.aliyun/config.json"
+
+const DefaultProfileName = "default"
+
+// Credential modes of the aliyun CLI profile
+type ProfileMode string
+
+const (
+	AKMode         = ProfileMode("AK")
+	StsTokenMode   = ProfileMode("StsToken")
+	RamRoleArnMode = ProfileMode("RamRoleArn")
+	EcsRamRoleMode = ProfileMode("EcsRamRole")
+)
+
+type SharedCredentials struct {
+	Current  string          `json:"current"`
+	Profiles []SharedProfile `json:"profiles"`
+}
+
+type SharedProfile struct {
+	Name            string      `json:"name"`
+	Mode            ProfileMode `json:"mode"`
+	AccessKeyId     string      `json:"access_key_id"`
+	AccessKeySecret string      `json:"access_key_secret"`
+	StsToken        string      `json:"sts_token"`
+	RamRoleName     string      `json:"ram_role_name"`
+	RamRoleArn      string      `json:"ram_role_arn"`
+	RamSessionName  string      `json:"ram_session_name"`
+	ExpiredSeconds  int         `json:"expired_seconds"`
+	RegionId        string      `json:"region_id"`
+}
+
+// loadSharedCredentials fills in the credentials and region which have not been specified in the provider block
+// or the ALICLOUD_* environment variables from a profile of the shared credentials file.
+// The resolution order is:
+//  1. Static 'access_key', 'secret_key', 'security_token' and 'region' in the provider block.
+//  2. Environment variables ALICLOUD_ACCESS_KEY, ALICLOUD_SECRET_KEY, ALICLOUD_SECURITY_TOKEN and ALICLOUD_REGION.
+//  3. The 'profile' in the 'shared_credentials_file'. When 'profile' is empty, the file's current profile is used.
+//
+// The first two are merged by the schema before configuring provider, so only the last one is handled here.
+func (c *Config) loadSharedCredentials() error {
+	if c.Profile != "" && c.hasCredentials() {
+		log.Printf("[WARN] The credentials of profile %s are ignored, since the credentials are specified by the provider block "+
+			"or the environment variables, which take precedence over the shared credentials file.", c.Profile)
+	}
+	if c.hasCredentials() && c.Region != "" {
+		return nil
+	}
+
+	explicit := c.Profile != "" || c.SharedCredentialsFile != ""
+	path := c.SharedCredentialsFile
+	if path == "" {
+		path = DefaultSharedCredentialsFile
+	}
+
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("Expanding shared credentials file %s got an error: %#v.", path, err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return nil
+		}
+		return fmt.Errorf("Reading shared credentials file %s got an error: %#v.", path, err)
+	}
+
+	var credentials SharedCredentials
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return fmt.Errorf("Parsing shared credentials file %s got an error: %#v.", path, err)
+	}
+
+	name := c.Profile
+	if name == "" {
+		name = credentials.Current
+	}
+	if name == "" {
+		name = DefaultProfileName
+	}
+
+	var profile *SharedProfile
+	for i := range credentials.Profiles {
+		if credentials.Profiles[i].Name == name {
+			profile = &credentials.Profiles[i]
+			break
+		}
+	}
+	if profile == nil {
+		if !explicit {
+			return nil
+		}
+		return fmt.Errorf("Profile %s is not found in the shared credentials file %s.", name, path)
+	}
+
+	log.Printf("[DEBUG] Loading profile %s from shared credentials file %s.", name, path)
+	if c.Region == "" {
+		c.Region = common.Region(profile.RegionId)
+	}
+
+	if c.hasCredentials() {
+		return nil
+	}
+
+	switch profile.Mode {
+	case AKMode, "":
+	case StsTokenMode:
+		c.SecurityToken = profile.StsToken
+	case RamRoleArnMode:
+		if c.RoleArn == "" {
+			c.RoleArn = profile.RamRoleArn
+			c.RoleSessionName = profile.RamSessionName
+			if c.RoleSessionName == "" {
+				c.RoleSessionName = DefaultRoleSessionName
+			}
+			if profile.ExpiredSeconds > 0 {
+				c.RoleSessionExpiration = profile.ExpiredSeconds
+			}
+		}
+	case EcsRamRoleMode:
+		c.EcsRoleName = profile.RamRoleName
+		return nil
+	default:
+		return fmt.Errorf("The mode %s of profile %s is not supported. Expected modes: %s, %s, %s, %s.",
+			profile.Mode, name, AKMode, StsTokenMode, RamRoleArnMode, EcsRamRoleMode)
+	}
+	c.AccessKey = profile.AccessKeyId
+	c.SecretKey = profile.AccessKeySecret
+
+	return nil
+}
+
+// hasCredentials reports whether the credentials have been specified by the provider block or environment variables.
+func (c *Config) hasCredentials() bool {
+	return (c.AccessKey != "" && c.SecretKey != "") || c.EcsRoleName != ""
+}
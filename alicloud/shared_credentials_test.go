@@ -0,0 +1,227 @@
+package alicloud
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/denverdino/aliyungo/common"
+	"github.com/mitchellh/go-homedir"
+)
+
+const testSharedCredentialsFile = "test-fixtures/shared_credentials/config.json"
+
+func TestLoadSharedCredentials(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   Config
+		expected Config
+	}{
+		{
+			name: "current profile",
+			config: Config{
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				AccessKey: "DevAccessKey",
+				SecretKey: "DevSecretKey",
+				Region:    common.Shanghai,
+			},
+		},
+		{
+			name: "default profile without current",
+			config: Config{
+				SharedCredentialsFile: "test-fixtures/shared_credentials/no_current.json",
+			},
+			expected: Config{
+				AccessKey: "DefaultAccessKey",
+				SecretKey: "DefaultSecretKey",
+				Region:    common.Hangzhou,
+			},
+		},
+		{
+			name: "named profile",
+			config: Config{
+				Profile:               "default",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				AccessKey: "DefaultAccessKey",
+				SecretKey: "DefaultSecretKey",
+				Region:    common.Hangzhou,
+			},
+		},
+		{
+			name: "sts token profile",
+			config: Config{
+				Profile:               "sts",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				AccessKey:     "StsAccessKey",
+				SecretKey:     "StsSecretKey",
+				SecurityToken: "StsSecurityToken",
+				Region:        common.Beijing,
+			},
+		},
+		{
+			name: "ram role arn profile",
+			config: Config{
+				Profile:               "role",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				AccessKey:             "RoleAccessKey",
+				SecretKey:             "RoleSecretKey",
+				Region:                common.Beijing,
+				RoleArn:               "acs:ram::1234567890:role/terraform",
+				RoleSessionName:       "ci",
+				RoleSessionExpiration: 900,
+			},
+		},
+		{
+			name: "ecs ram role profile",
+			config: Config{
+				Profile:               "ecs",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				Region:      common.Hangzhou,
+				EcsRoleName: "terraform-runner",
+			},
+		},
+		{
+			name: "ecs_role_name wins over profile",
+			config: Config{
+				EcsRoleName:           "terraform-builder",
+				Profile:               "sts",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				Region:      common.Beijing,
+				EcsRoleName: "terraform-builder",
+			},
+		},
+		{
+			name: "assume_role block wins over profile role",
+			config: Config{
+				Profile:               "role",
+				SharedCredentialsFile: testSharedCredentialsFile,
+				RoleArn:               "acs:ram::1234567890:role/admin",
+				RoleSessionName:       DefaultRoleSessionName,
+			},
+			expected: Config{
+				AccessKey:       "RoleAccessKey",
+				SecretKey:       "RoleSecretKey",
+				Region:          common.Beijing,
+				RoleArn:         "acs:ram::1234567890:role/admin",
+				RoleSessionName: DefaultRoleSessionName,
+			},
+		},
+		{
+			name: "config and environment credentials win over profile",
+			config: Config{
+				AccessKey:             "StaticAccessKey",
+				SecretKey:             "StaticSecretKey",
+				Profile:               "sts",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				AccessKey: "StaticAccessKey",
+				SecretKey: "StaticSecretKey",
+				Region:    common.Beijing,
+			},
+		},
+		{
+			name: "config and environment region wins over profile",
+			config: Config{
+				Region:                common.Qingdao,
+				Profile:               "sts",
+				SharedCredentialsFile: testSharedCredentialsFile,
+			},
+			expected: Config{
+				AccessKey:     "StsAccessKey",
+				SecretKey:     "StsSecretKey",
+				SecurityToken: "StsSecurityToken",
+				Region:        common.Qingdao,
+			},
+		},
+		{
+			name: "fully specified config skips the file",
+			config: Config{
+				AccessKey:             "StaticAccessKey",
+				SecretKey:             "StaticSecretKey",
+				Region:                common.Qingdao,
+				Profile:               "not-exist",
+				SharedCredentialsFile: "test-fixtures/shared_credentials/not_exist.json",
+			},
+			expected: Config{
+				AccessKey: "StaticAccessKey",
+				SecretKey: "StaticSecretKey",
+				Region:    common.Qingdao,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		config := c.config
+		if err := config.loadSharedCredentials(); err != nil {
+			t.Fatalf("%s: loading shared credentials got an error: %#v", c.name, err)
+		}
+		// Profile and SharedCredentialsFile are inputs, not results.
+		config.Profile = ""
+		config.SharedCredentialsFile = ""
+		if !reflect.DeepEqual(config, c.expected) {
+			t.Fatalf("%s: expected config %#v, got %#v", c.name, c.expected, config)
+		}
+	}
+}
+
+func TestLoadSharedCredentials_invalid(t *testing.T) {
+	cases := map[string]Config{
+		"missing profile": Config{
+			Profile:               "not-exist",
+			SharedCredentialsFile: testSharedCredentialsFile,
+		},
+		"missing file": Config{
+			SharedCredentialsFile: "test-fixtures/shared_credentials/not_exist.json",
+		},
+		"malformed file": Config{
+			SharedCredentialsFile: "test-fixtures/shared_credentials/invalid.json",
+		},
+		"unsupported mode": Config{
+			Profile:               "unknown",
+			SharedCredentialsFile: testSharedCredentialsFile,
+		},
+	}
+
+	for name, config := range cases {
+		if err := config.loadSharedCredentials(); err == nil {
+			t.Fatalf("%s: expected an error, got nil", name)
+		}
+	}
+}
+
+func TestLoadSharedCredentials_defaultFileNotExist(t *testing.T) {
+	home, err := ioutil.TempDir("", "alicloud-home")
+	if err != nil {
+		t.Fatalf("creating temp dir got an error: %#v", err)
+	}
+	defer os.RemoveAll(home)
+
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+	os.Setenv("HOME", home)
+
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+
+	config := Config{}
+	if err := config.loadSharedCredentials(); err != nil {
+		t.Fatalf("a missing default shared credentials file should be ignored, got an error: %#v", err)
+	}
+	if !reflect.DeepEqual(config, Config{}) {
+		t.Fatalf("expected an empty config, got %#v", config)
+	}
+}
@@ -0,0 +1,100 @@
+package alicloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// The resources which carry the deprecated aliases of their attributes declare the schema version 1,
+// whose state keeps the values of the aliases in their canonical attributes too. The state of version 0
+// is migrated by copying the aliases to the canonical attributes, so the aliases can be removed from the
+// schema later without losing the values of the state written by the old versions of the provider.
+// An alias stays in the state as long as the schema still accepts it, otherwise the next plan would
+// show a diff of every configuration which still sets it.
+
+// stateAlias is a deprecated attribute of a resource. An alias without the canonical attribute has been
+// obsoleted, and is dropped from the state once the schema no longer accepts it.
+type stateAlias struct {
+	name      string
+	canonical string
+}
+
+var instanceStateAliases = []stateAlias{
+	{name: "subnet_id", canonical: "vswitch_id"},
+	{name: "io_optimized"},
+}
+
+var slbListenerStateAliases = []stateAlias{
+	{name: "lb_port", canonical: "frontend_port"},
+	{name: "instance_port", canonical: "backend_port"},
+	{name: "lb_protocol", canonical: "protocol"},
+}
+
+var dbInstanceStateAliases = []stateAlias{
+	{name: "db_instance_class", canonical: "instance_type"},
+	{name: "db_instance_storage", canonical: "instance_storage"},
+}
+
+var vpcStateAliases = []stateAlias{
+	{name: "router_table_id", canonical: "route_table_id"},
+}
+
+var essScalingConfigurationStateAliases = []stateAlias{
+	{name: "io_optimized"},
+}
+
+func resourceAlicloudInstanceMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	return migrateStateAliases(v, is, instanceStateAliases, resourceAliyunInstance().Schema)
+}
+
+func resourceAlicloudSlbListenerMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	return migrateStateAliases(v, is, slbListenerStateAliases, resourceAliyunSlbListener().Schema)
+}
+
+func resourceAlicloudDBInstanceMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	return migrateStateAliases(v, is, dbInstanceStateAliases, resourceAlicloudDBInstance().Schema)
+}
+
+func resourceAlicloudVpcMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	return migrateStateAliases(v, is, vpcStateAliases, resourceAliyunVpc().Schema)
+}
+
+func resourceAlicloudEssScalingConfigurationMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	return migrateStateAliases(v, is, essScalingConfigurationStateAliases, resourceAlicloudEssScalingConfiguration().Schema)
+}
+
+func migrateStateAliases(v int, is *terraform.InstanceState, aliases []stateAlias, accepted map[string]*schema.Schema) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found AliCloud State v0; migrating to v1")
+		return migrateStateAliasesV0toV1(is, aliases, accepted)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+func migrateStateAliasesV0toV1(is *terraform.InstanceState, aliases []stateAlias, accepted map[string]*schema.Schema) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	for _, alias := range aliases {
+		value, ok := is.Attributes[alias.name]
+		if !ok {
+			continue
+		}
+		// The canonical attribute wins when both were set, since it is the one the resource reads.
+		if alias.canonical != "" && is.Attributes[alias.canonical] == "" && value != "" {
+			is.Attributes[alias.canonical] = value
+			log.Printf("[DEBUG] Migrated the attribute %s to %s.", alias.name, alias.canonical)
+		}
+		if _, ok := accepted[alias.name]; !ok {
+			delete(is.Attributes, alias.name)
+		}
+	}
+	return is, nil
+}
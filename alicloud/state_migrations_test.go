@@ -0,0 +1,107 @@
+package alicloud
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAlicloudStateMigrations(t *testing.T) {
+	cases := map[string]struct {
+		migrate  func(int, *terraform.InstanceState, interface{}) (*terraform.InstanceState, error)
+		version  int
+		before   map[string]string
+		expected map[string]string
+	}{
+		"instance subnet_id to vswitch_id": {
+			migrate:  resourceAlicloudInstanceMigrateState,
+			before:   map[string]string{"subnet_id": "vsw-1", "io_optimized": "optimized", "instance_name": "foo"},
+			expected: map[string]string{"subnet_id": "vsw-1", "vswitch_id": "vsw-1", "io_optimized": "optimized", "instance_name": "foo"},
+		},
+		"instance keeps vswitch_id": {
+			migrate:  resourceAlicloudInstanceMigrateState,
+			before:   map[string]string{"subnet_id": "vsw-1", "vswitch_id": "vsw-2"},
+			expected: map[string]string{"subnet_id": "vsw-1", "vswitch_id": "vsw-2"},
+		},
+		"slb listener ports and protocol": {
+			migrate: resourceAlicloudSlbListenerMigrateState,
+			before:  map[string]string{"lb_port": "80", "instance_port": "8080", "lb_protocol": "http", "bandwidth": "10"},
+			expected: map[string]string{
+				"lb_port": "80", "instance_port": "8080", "lb_protocol": "http",
+				"frontend_port": "80", "backend_port": "8080", "protocol": "http", "bandwidth": "10",
+			},
+		},
+		"db instance class and storage": {
+			migrate: resourceAlicloudDBInstanceMigrateState,
+			before:  map[string]string{"db_instance_class": "rds.mysql.t1.small", "db_instance_storage": "10", "instance_type": ""},
+			expected: map[string]string{
+				"db_instance_class": "rds.mysql.t1.small", "db_instance_storage": "10",
+				"instance_type": "rds.mysql.t1.small", "instance_storage": "10",
+			},
+		},
+		"vpc router_table_id to route_table_id": {
+			migrate:  resourceAlicloudVpcMigrateState,
+			before:   map[string]string{"router_table_id": "vtb-1", "route_table_id": ""},
+			expected: map[string]string{"router_table_id": "vtb-1", "route_table_id": "vtb-1"},
+		},
+		"ess scaling configuration io_optimized": {
+			migrate:  resourceAlicloudEssScalingConfigurationMigrateState,
+			before:   map[string]string{"io_optimized": "none", "instance_type": "ecs.n4.large"},
+			expected: map[string]string{"io_optimized": "none", "instance_type": "ecs.n4.large"},
+		},
+	}
+
+	for name, c := range cases {
+		is := &terraform.InstanceState{
+			ID:         "i-abc",
+			Attributes: c.before,
+		}
+		is, err := c.migrate(c.version, is, nil)
+		if err != nil {
+			t.Fatalf("%s: migrating state got an error: %#v", name, err)
+		}
+		if !reflect.DeepEqual(is.Attributes, c.expected) {
+			t.Fatalf("%s: expected attributes %#v, got %#v", name, c.expected, is.Attributes)
+		}
+	}
+}
+
+func TestAlicloudStateMigrations_obsoleted(t *testing.T) {
+	aliases := []stateAlias{
+		{name: "subnet_id", canonical: "vswitch_id"},
+		{name: "io_optimized"},
+	}
+	accepted := map[string]*schema.Schema{
+		"vswitch_id": &schema.Schema{Type: schema.TypeString, Optional: true},
+	}
+	is := &terraform.InstanceState{
+		ID:         "i-abc",
+		Attributes: map[string]string{"subnet_id": "vsw-1", "io_optimized": "optimized"},
+	}
+	is, err := migrateStateAliases(0, is, aliases, accepted)
+	if err != nil {
+		t.Fatalf("migrating state got an error: %#v", err)
+	}
+	expected := map[string]string{"vswitch_id": "vsw-1"}
+	if !reflect.DeepEqual(is.Attributes, expected) {
+		t.Fatalf("expected the aliases the schema no longer accepts to be dropped, got %#v", is.Attributes)
+	}
+}
+
+func TestAlicloudStateMigrations_empty(t *testing.T) {
+	var is *terraform.InstanceState
+	if _, err := resourceAlicloudInstanceMigrateState(0, is, nil); err != nil {
+		t.Fatalf("migrating the nil state got an error: %#v", err)
+	}
+
+	is = &terraform.InstanceState{}
+	if _, err := resourceAlicloudInstanceMigrateState(0, is, nil); err != nil {
+		t.Fatalf("migrating the empty state got an error: %#v", err)
+	}
+
+	if _, err := resourceAlicloudInstanceMigrateState(1, is, nil); err == nil {
+		t.Fatalf("expected an error of migrating the unknown schema version")
+	}
+}
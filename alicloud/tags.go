@@ -24,41 +24,33 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
-// setTags is a helper to set the tags for a resource. It expects the
-// tags field to be named "tags"
-func setTags(client *AliyunClient, resourceType ecs.TagResourceType, d *schema.ResourceData) error {
+// setTags is a helper to set the tags for a resource by the tag API of its service.
+// It expects the tags field to be named "tags"
+func setTags(client *AliyunClient, service tagService, d *schema.ResourceData) error {
 
-	conn := client.ecsconn
+	defaults := client.defaultTags()
 
-	if d.HasChange("tags") {
+	if d.HasChange("tags") || (d.IsNewResource() && len(defaults) > 0) {
 		oraw, nraw := d.GetChange("tags")
-		o := oraw.(map[string]interface{})
-		n := nraw.(map[string]interface{})
+		// the default tags have been applied on the resource unless it is being created
+		o := map[string]interface{}{}
+		if !d.IsNewResource() {
+			o = mergeDefaultTags(defaults, oraw.(map[string]interface{}))
+		}
+		n := mergeDefaultTags(defaults, nraw.(map[string]interface{}))
 		create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
 
 		// Set tags
 		if len(remove) > 0 {
 			log.Printf("[DEBUG] Removing tags: %#v from %s", remove, d.Id())
-			err := RemoveTags(conn, &RemoveTagsArgs{
-				RegionId:     client.Region,
-				ResourceId:   d.Id(),
-				ResourceType: resourceType,
-				Tag:          remove,
-			})
-			if err != nil {
+			if err := service.removeTags(client, d.Id(), remove); err != nil {
 				return fmt.Errorf("Remove tags got error: %s", err)
 			}
 		}
 
 		if len(create) > 0 {
 			log.Printf("[DEBUG] Creating tags: %s for %s", create, d.Id())
-			err := AddTags(conn, &AddTagsArgs{
-				RegionId:     client.Region,
-				ResourceId:   d.Id(),
-				ResourceType: resourceType,
-				Tag:          create,
-			})
-			if err != nil {
+			if err := service.addTags(client, d.Id(), create); err != nil {
 				return fmt.Errorf("Creating tags got error: %s", err)
 			}
 		}
@@ -67,6 +59,57 @@ func setTags(client *AliyunClient, resourceType ecs.TagResourceType, d *schema.R
 	return nil
 }
 
+// readTags sets the tags of a resource described by the tag API of its service,
+// without the ones it inherits from the provider's default tags.
+func readTags(client *AliyunClient, service tagService, d *schema.ResourceData) error {
+	tags, err := service.describeTags(client, d.Id())
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]string, len(tags))
+	for _, t := range tags {
+		m[t.Key] = t.Value
+	}
+	d.Set("tags", removeDefaultTags(client.defaultTags(), m, d.Get("tags").(map[string]interface{})))
+	return nil
+}
+
+// defaultTags returns the provider's default tags.
+func (client *AliyunClient) defaultTags() map[string]string {
+	client.lock.Lock()
+	defer client.lock.Unlock()
+	return client.config.DefaultTags
+}
+
+// mergeDefaultTags returns the tags applied on a resource: the provider's default tags
+// overridden by the resource's own ones.
+func mergeDefaultTags(defaults map[string]string, tags map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(defaults)+len(tags))
+	for k, v := range defaults {
+		result[k] = v
+	}
+	for k, v := range tags {
+		result[k] = v
+	}
+	return result
+}
+
+// removeDefaultTags returns the tags of a resource without the ones it inherits from the provider's default tags,
+// so that they don't show up in the plan. A tag set by the resource itself, as in own, is kept.
+func removeDefaultTags(defaults map[string]string, tags map[string]string, own map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if _, ok := own[k]; !ok {
+			if value, ok := defaults[k]; ok && value == v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
+
 // diffTags takes our tags locally and the ones remotely and returns
 // the set of tags that must be created, and the set of tags that must
 // be destroyed.
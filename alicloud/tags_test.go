@@ -0,0 +1,239 @@
+package alicloud
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/denverdino/aliyungo/common"
+)
+
+func TestMergeDefaultTags(t *testing.T) {
+	cases := []struct {
+		defaults map[string]string
+		tags     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			defaults: nil,
+			tags:     map[string]interface{}{"Name": "web"},
+			expected: map[string]interface{}{"Name": "web"},
+		},
+		{
+			defaults: map[string]string{"CostCenter": "1024", "Owner": "ops"},
+			tags:     map[string]interface{}{},
+			expected: map[string]interface{}{"CostCenter": "1024", "Owner": "ops"},
+		},
+		{
+			defaults: map[string]string{"CostCenter": "1024", "Owner": "ops"},
+			tags:     map[string]interface{}{"Name": "web", "Owner": "dev"},
+			expected: map[string]interface{}{"CostCenter": "1024", "Owner": "dev", "Name": "web"},
+		},
+	}
+
+	for _, c := range cases {
+		if merged := mergeDefaultTags(c.defaults, c.tags); !reflect.DeepEqual(merged, c.expected) {
+			t.Fatalf("expected merging %#v into %#v to be %#v, got %#v", c.tags, c.defaults, c.expected, merged)
+		}
+	}
+}
+
+func TestRemoveDefaultTags(t *testing.T) {
+	defaults := map[string]string{"CostCenter": "1024", "Owner": "ops"}
+	cases := []struct {
+		tags     map[string]string
+		own      map[string]interface{}
+		expected map[string]string
+	}{
+		{
+			// inherited tags are hidden
+			tags:     map[string]string{"CostCenter": "1024", "Owner": "ops", "Name": "web"},
+			own:      map[string]interface{}{"Name": "web"},
+			expected: map[string]string{"Name": "web"},
+		},
+		{
+			// a default tag overridden by the resource is kept
+			tags:     map[string]string{"CostCenter": "1024", "Owner": "dev"},
+			own:      map[string]interface{}{"Owner": "dev"},
+			expected: map[string]string{"Owner": "dev"},
+		},
+		{
+			// a default tag set by the resource with the same value is kept
+			tags:     map[string]string{"CostCenter": "1024", "Owner": "ops"},
+			own:      map[string]interface{}{"Owner": "ops"},
+			expected: map[string]string{"Owner": "ops"},
+		},
+		{
+			// a tag whose value differs from the default one was not inherited, e.g. after default_tags changes
+			tags:     map[string]string{"CostCenter": "2048", "Owner": "ops"},
+			own:      map[string]interface{}{},
+			expected: map[string]string{"CostCenter": "2048"},
+		},
+	}
+
+	for _, c := range cases {
+		if tags := removeDefaultTags(defaults, c.tags, c.own); !reflect.DeepEqual(tags, c.expected) {
+			t.Fatalf("expected removing default tags from %#v to be %#v, got %#v", c.tags, c.expected, tags)
+		}
+	}
+}
+
+// testTagServer records the requests to the tag APIs and responds them with the body returned by respond.
+type testTagServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func newTestTagServer(respond func(r *http.Request) string) *testTagServer {
+	server := &testTagServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		body, _ := ioutil.ReadAll(r.Body)
+		server.lock.Lock()
+		server.requests = append(server.requests, r)
+		server.bodies = append(server.bodies, string(body))
+		server.lock.Unlock()
+		w.Write([]byte(respond(r)))
+	}))
+	return server
+}
+
+func testTagClient(t *testing.T, code ServiceCode, endpoint string) *AliyunClient {
+	config := Config{
+		AccessKey: "AccessKey",
+		SecretKey: "SecretKey",
+		Region:    common.Beijing,
+		Endpoints: map[ServiceCode]string{code: endpoint},
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("building client got an error: %#v", err)
+	}
+	return client
+}
+
+func expectTagParams(t *testing.T, r *http.Request, expected map[string]string) {
+	for k, v := range expected {
+		if value := r.Form.Get(k); value != v {
+			t.Fatalf("expected parameter %s of %s to be %q, got %q", k, r.Form.Get("Action"), v, value)
+		}
+	}
+}
+
+func TestVpcTags(t *testing.T) {
+	server := newTestTagServer(func(r *http.Request) string {
+		if r.Form.Get("Action") == "ListTagResources" {
+			return `{"RequestId": "test-request-id", "NextToken": "", "TagResources": {"TagResource": [
+				{"ResourceId": "vpc-1", "ResourceType": "VPC", "TagKey": "Owner", "TagValue": "ops"}]}}`
+		}
+		return `{"RequestId": "test-request-id"}`
+	})
+	defer server.Close()
+	client := testTagClient(t, VpcCode, server.URL)
+
+	if err := VpcTagResourceVpc.addTags(client, "vpc-1", []Tag{{Key: "Owner", Value: "ops"}}); err != nil {
+		t.Fatalf("adding tags got an error: %#v", err)
+	}
+	if err := VpcTagResourceVpc.removeTags(client, "vpc-1", []Tag{{Key: "Name", Value: "web"}}); err != nil {
+		t.Fatalf("removing tags got an error: %#v", err)
+	}
+	tags, err := VpcTagResourceVpc.describeTags(client, "vpc-1")
+	if err != nil {
+		t.Fatalf("describing tags got an error: %#v", err)
+	}
+	if expected := []Tag{{Key: "Owner", Value: "ops"}}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("expected tags %#v, got %#v", expected, tags)
+	}
+
+	expectTagParams(t, server.requests[0], map[string]string{
+		"Action": "TagResources", "ResourceType": "VPC", "ResourceId.1": "vpc-1", "Tag.1.Key": "Owner", "Tag.1.Value": "ops",
+	})
+	expectTagParams(t, server.requests[1], map[string]string{
+		"Action": "UntagResources", "ResourceType": "VPC", "ResourceId.1": "vpc-1", "TagKey.1": "Name",
+	})
+	expectTagParams(t, server.requests[2], map[string]string{
+		"Action": "ListTagResources", "ResourceType": "VPC", "ResourceId.1": "vpc-1",
+	})
+}
+
+func TestRdsTags(t *testing.T) {
+	server := newTestTagServer(func(r *http.Request) string {
+		if r.Form.Get("Action") == "DescribeTags" {
+			return `{"RequestId": "test-request-id", "Items": {"TagInfos": [{"TagKey": "Owner", "TagValue": "ops"}]}}`
+		}
+		return `{"RequestId": "test-request-id"}`
+	})
+	defer server.Close()
+	client := testTagClient(t, RdsCode, server.URL)
+
+	if err := (rdsTags{}).addTags(client, "rm-1", []Tag{{Key: "Owner", Value: "ops"}}); err != nil {
+		t.Fatalf("adding tags got an error: %#v", err)
+	}
+	if err := (rdsTags{}).removeTags(client, "rm-1", []Tag{{Key: "Name", Value: "web"}}); err != nil {
+		t.Fatalf("removing tags got an error: %#v", err)
+	}
+	tags, err := (rdsTags{}).describeTags(client, "rm-1")
+	if err != nil {
+		t.Fatalf("describing tags got an error: %#v", err)
+	}
+	if expected := []Tag{{Key: "Owner", Value: "ops"}}; !reflect.DeepEqual(tags, expected) {
+		t.Fatalf("expected tags %#v, got %#v", expected, tags)
+	}
+
+	expectTagParams(t, server.requests[0], map[string]string{
+		"Action": "AddTagsToResource", "DBInstanceId": "rm-1", "Tags": `{"Owner":"ops"}`,
+	})
+	expectTagParams(t, server.requests[1], map[string]string{
+		"Action": "RemoveTagsFromResource", "DBInstanceId": "rm-1", "Tag.1.key": "Name", "Tag.1.value": "web",
+	})
+	expectTagParams(t, server.requests[2], map[string]string{
+		"Action": "DescribeTags", "DBInstanceId": "rm-1",
+	})
+}
+
+func TestOssTags(t *testing.T) {
+	server := newTestTagServer(func(r *http.Request) string {
+		if r.Method == "GET" {
+			return `<Tagging><TagSet><Tag><Key>Owner</Key><Value>ops</Value></Tag></TagSet></Tagging>`
+		}
+		return ""
+	})
+	defer server.Close()
+	client := testTagClient(t, OssCode, server.URL)
+
+	if err := (ossTags{}).addTags(client, "bucket", []Tag{{Key: "Name", Value: "web"}}); err != nil {
+		t.Fatalf("adding tags got an error: %#v", err)
+	}
+	if err := (ossTags{}).removeTags(client, "bucket", []Tag{{Key: "Owner", Value: "ops"}}); err != nil {
+		t.Fatalf("removing tags got an error: %#v", err)
+	}
+
+	var methods []string
+	for _, r := range server.requests {
+		if _, ok := r.URL.Query()["tagging"]; !ok || strings.Trim(r.URL.Path, "/") != "bucket" {
+			t.Fatalf("expected a request to the tagging of the bucket, got %s", r.URL)
+		}
+		methods = append(methods, r.Method)
+	}
+	// removing the last tag deletes the bucket's tagging
+	if expected := []string{"GET", "PUT", "GET", "DELETE"}; !reflect.DeepEqual(methods, expected) {
+		t.Fatalf("expected requests %v, got %v", expected, methods)
+	}
+
+	// adding keeps the current tags
+	var keys []string
+	for _, k := range strings.Split(server.bodies[1], "<Key>")[1:] {
+		keys = append(keys, strings.Split(k, "</Key>")[0])
+	}
+	sort.Strings(keys)
+	if expected := []string{"Name", "Owner"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected putting tags %v, got %s", expected, server.bodies[1])
+	}
+}
@@ -568,20 +568,13 @@ func validateImageOwners(v interface{}, k string) (ws []string, errors []error)
 	return
 }
 
+// validateRegion only checks the format of a region ID, e.g. cn-beijing or ap-southeast-1. Whether the region exists
+// is checked by its client unless skip_region_validation is set, so a region newer than the SDK can still be used.
 func validateRegion(v interface{}, k string) (ws []string, errors []error) {
-	if value := v.(string); value != "" {
-		region := common.Region(value)
-		var valid string
-		for _, re := range common.ValidRegions {
-			if region == re {
-				return
-			}
-			valid = valid + ", " + string(re)
-		}
+	if value := v.(string); value != "" && !regexp.MustCompile(`^[a-z]{2}(-[a-z0-9]+)+$`).MatchString(value) {
 		errors = append(errors, fmt.Errorf(
-			"%q must contain a valid Region ID , expected %#v, got %q",
-			k, valid, value))
-
+			"%q must contain a valid Region ID, e.g. %s, got %q",
+			k, common.Beijing, value))
 	}
 	return
 }
@@ -1078,3 +1071,57 @@ func validateInstanceSpotStrategy(v interface{}, k string) (ws []string, errors
 	}
 	return
 }
+
+func validateSnapshotName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < 2 || len(value) > 128 {
+		errors = append(errors, fmt.Errorf("%q must be 2 to 128 characters long", k))
+	}
+
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		errors = append(errors, fmt.Errorf("%s cannot starts with http:// or https://", k))
+	}
+
+	// The names starting with auto are reserved for the automatic snapshots.
+	if strings.HasPrefix(value, "auto") {
+		errors = append(errors, fmt.Errorf("%s cannot starts with auto", k))
+	}
+	return
+}
+
+func validateSnapshotPolicyName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < 2 || len(value) > 128 {
+		errors = append(errors, fmt.Errorf("%q must be 2 to 128 characters long", k))
+	}
+
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		errors = append(errors, fmt.Errorf("%s cannot starts with http:// or https://", k))
+	}
+	return
+}
+
+// validateSnapshotRetentionDays allows -1, which keeps the automatic snapshots permanently, or 1 to 65536 days.
+func validateSnapshotRetentionDays(v interface{}, k string) (ws []string, errors []error) {
+	if value := v.(int); value != -1 && (value < 1 || value > 65536) {
+		errors = append(errors, fmt.Errorf("%q must be -1 or between 1 and 65536, got %d", k, value))
+	}
+	return
+}
+
+func validateImageName(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if len(value) < 2 || len(value) > 128 {
+		errors = append(errors, fmt.Errorf("%q must be 2 to 128 characters long", k))
+	}
+
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		errors = append(errors, fmt.Errorf("%s cannot starts with http:// or https://", k))
+	}
+
+	// The names starting with aliyun or acs: are reserved for the images of Alibaba Cloud.
+	if strings.HasPrefix(value, "aliyun") || strings.HasPrefix(value, "acs:") {
+		errors = append(errors, fmt.Errorf("%s cannot starts with aliyun or acs:", k))
+	}
+	return
+}
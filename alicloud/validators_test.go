@@ -1,6 +1,9 @@
 package alicloud
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestValidateInstancePort(t *testing.T) {
 	validPorts := []int{1, 22, 80, 100, 8088, 65535}
@@ -482,3 +485,57 @@ func TestValidateIntegerInRange(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRegion(t *testing.T) {
+	validRegions := []string{"", "cn-beijing", "ap-southeast-1", "cn-shanghai-finance-1", "me-new-1"}
+	for _, v := range validRegions {
+		_, errors := validateRegion(v, "region")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid region: %q", v, errors)
+		}
+	}
+
+	invalidRegions := []string{"beijing", "cn_beijing", "CN-BEIJING", "cn-beijing-"}
+	for _, v := range invalidRegions {
+		_, errors := validateRegion(v, "region")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid region", v)
+		}
+	}
+}
+
+func TestValidateSnapshotName(t *testing.T) {
+	validNames := []string{"tf-testAccSnapshot", "daily-backup", "快照"}
+	for _, v := range validNames {
+		_, errors := validateSnapshotName(v, "name")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid snapshot name: %q", v, errors)
+		}
+	}
+
+	invalidNames := []string{"a", "auto-backup", "http://backup", strings.Repeat("a", 129)}
+	for _, v := range invalidNames {
+		_, errors := validateSnapshotName(v, "name")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid snapshot name", v)
+		}
+	}
+}
+
+func TestValidateSnapshotRetentionDays(t *testing.T) {
+	validDays := []int{-1, 1, 7, 65536}
+	for _, v := range validDays {
+		_, errors := validateSnapshotRetentionDays(v, "retention_days")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be valid retention days: %q", v, errors)
+		}
+	}
+
+	invalidDays := []int{-2, 0, 65537}
+	for _, v := range invalidDays {
+		_, errors := validateSnapshotRetentionDays(v, "retention_days")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be invalid retention days", v)
+		}
+	}
+}